@@ -0,0 +1,12 @@
+# Find out which identity provider authenticates a user of an application
+data "sci_authentication_rule_evaluation" "employee" {
+  application_id = "valid-uuid"
+  user_type      = "employee"
+  user_group_ids = ["valid-group-uuid"]
+  email          = "jane.doe@example.com"
+  ip_address     = "10.1.2.3"
+}
+
+output "matched_rule" {
+  value = data.sci_authentication_rule_evaluation.employee.matched_rule
+}
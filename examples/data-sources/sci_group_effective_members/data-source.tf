@@ -0,0 +1,10 @@
+# List the users which are members of a group, directly or through nested groups
+data "sci_group_effective_members" "by_group" {
+  group_id = "valid-uuid"
+}
+
+# Only expand nested groups up to the second level
+data "sci_group_effective_members" "limited_depth" {
+  group_id  = "valid-uuid"
+  max_depth = 2
+}
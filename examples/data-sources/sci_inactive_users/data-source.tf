@@ -0,0 +1,13 @@
+# Read all users that have not been modified for a year
+resource "time_offset" "one_year_ago" {
+  offset_years = -1
+}
+
+data "sci_inactive_users" "all" {
+  last_modified_before = time_offset.one_year_ago.rfc3339
+}
+
+# Read all users not modified since the beginning of 2024
+data "sci_inactive_users" "since_2024" {
+  last_modified_before = "2024-01-01T00:00:00Z"
+}
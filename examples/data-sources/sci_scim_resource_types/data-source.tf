@@ -0,0 +1,3 @@
+# List all SCIM resource types supported by the tenant
+data "sci_scim_resource_types" "all" {
+}
@@ -0,0 +1,3 @@
+# Read the SCIM features supported by the tenant
+data "sci_scim_service_provider_config" "config" {
+}
@@ -0,0 +1,13 @@
+# Read all groups that have not been modified for a year
+resource "time_offset" "one_year_ago" {
+  offset_years = -1
+}
+
+data "sci_stale_groups" "all" {
+  last_modified_before = time_offset.one_year_ago.rfc3339
+}
+
+# Read the stale groups without members
+output "empty_stale_groups" {
+  value = [for group in data.sci_stale_groups.all.values : group.id if group.members == 0]
+}
@@ -0,0 +1,11 @@
+# Read the OpenID configuration of the tenant
+data "sci_tenant_oidc_configuration" "tenant" {}
+
+# Pass the issuer and the JSON Web Key Set to a relying party establishing trust with the tenant
+output "tenant_issuer" {
+  value = data.sci_tenant_oidc_configuration.tenant.issuer
+}
+
+output "tenant_jwks" {
+  value = data.sci_tenant_oidc_configuration.tenant.jwks
+}
@@ -0,0 +1,12 @@
+# Read the SAML metadata of the tenant
+data "sci_tenant_saml_metadata" "tenant" {}
+
+# Pass the metadata to a service provider establishing trust with the tenant
+output "tenant_saml_metadata" {
+  value = data.sci_tenant_saml_metadata.tenant.metadata_xml
+}
+
+# Read the default single sign-on endpoint of the tenant
+output "tenant_sso_endpoint" {
+  value = one([for endpoint in data.sci_tenant_saml_metadata.tenant.sso_endpoints : endpoint.location if endpoint.default])
+}
@@ -0,0 +1,10 @@
+# List the groups a user is a direct member of
+data "sci_user_memberships" "by_id" {
+  user_id = "valid-uuid"
+}
+
+# List the groups a user is a member of, including nested groups
+data "sci_user_memberships" "by_user_name" {
+  user_name      = "jdoe"
+  include_nested = true
+}
@@ -0,0 +1,12 @@
+# Show the configuration derived from the metadata of a SAML service provider
+locals {
+  sp_metadata = provider::sci::parse_saml_metadata(file("${path.module}/sp-metadata.xml"))
+}
+
+output "acs_locations" {
+  value = [for endpoint in local.sp_metadata.acs_endpoints : endpoint.location]
+}
+
+output "signing_certificates_valid_to" {
+  value = [for certificate in local.sp_metadata.signing_certificates : certificate.valid_to]
+}
@@ -46,6 +46,21 @@ resource "sci_application" "basic_application" {
   }
 }
 
+# Create a child application which does not inherit the assertion attributes of its parent application
+resource "sci_application" "child_application" {
+  name                  = "My Child Application"
+  parent_application_id = "app_0987654321"         # Must be a valid UUID
+  override_inherited    = ["assertion_attributes"] # Refer to the documentation for valid values
+  authentication_schema = {
+    assertion_attributes = [
+      {
+        attribute_name  = "email"
+        attribute_value = "mail"
+      }
+    ]
+  }
+}
+
 # Create a SAML2 application in SAP Cloud Identity Services
 resource "sci_application" "saml2_application" {
   name        = "My Basic SAML2 Application"
@@ -85,6 +100,22 @@ resource "sci_application" "saml2_application" {
       sign_assertions              = false
       sign_auth_responses          = false
       digest_algorithm             = "sha256" # Refer to the documentation for valid values
+      proxy_authn_request = {
+        authn_context      = "urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport" # Refer to the documentation for valid values
+        issuer_name_suffix = "my-saml2-application"
+      }
+    }
+  }
+}
+
+# Create a SAML2 application with the configuration derived from the metadata of the service provider
+resource "sci_application" "saml2_metadata_application" {
+  name = "My SAML2 Application from Metadata"
+  authentication_schema = {
+    sso_type = "saml2"
+    saml2_config = {
+      metadata_xml     = file("${path.module}/sp-metadata.xml")
+      digest_algorithm = "sha256" # Refer to the documentation for valid values
     }
   }
 }
@@ -126,4 +157,18 @@ resource "sci_application" "oidc_application" {
       }
     }
   }
-}
\ No newline at end of file
+}
+# Pass the client registration of the OIDC application on to its deployment
+output "oidc_application_client" {
+  value = {
+    client_id              = sci_application.oidc_application.oidc_client.client_id
+    issuer                 = sci_application.oidc_application.oidc_client.issuer
+    authorization_endpoint = sci_application.oidc_application.oidc_client.authorization_endpoint
+    token_endpoint         = sci_application.oidc_application.oidc_client.token_endpoint
+  }
+}
+
+# Pass the identity provider endpoints of the SAML2 application on to its deployment
+output "saml2_application_idp" {
+  value = sci_application.saml2_application.saml2_idp
+}
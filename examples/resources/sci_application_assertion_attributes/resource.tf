@@ -0,0 +1,44 @@
+# Manage the assertion attributes of an application
+resource "sci_application_assertion_attributes" "attributes" {
+  application_id = "valid-uuid"
+  assertion_attributes = [
+    {
+      attribute_name  = "email"
+      attribute_value = "mail"
+    },
+    {
+      attribute_name  = "cost_center"
+      attribute_value = "urn:sap:cloud:scim:schemas:extension:custom:2.0:Employee:costCenter"
+    }
+  ]
+  advanced_assertion_attributes = [
+    {
+      source          = "Expression"
+      attribute_name  = "full_name"
+      attribute_value = "$${user.firstName} $${user.lastName}"
+    },
+    {
+      source          = "Corporate Identity Provider"
+      attribute_name  = "department"
+      attribute_value = "department"
+    }
+  ]
+}
+
+# Disable attributes inherited from the parent application of a child application
+resource "sci_application_assertion_attributes" "child_attributes" {
+  application_id                          = "valid-child-uuid"
+  disabled_inherited_assertion_attributes = ["first_name", "last_name"]
+}
+
+# Leave the attributes to the resource above when managing the application
+resource "sci_application" "application" {
+  name = "My Application"
+
+  lifecycle {
+    ignore_changes = [
+      authentication_schema.assertion_attributes,
+      authentication_schema.advanced_assertion_attributes,
+    ]
+  }
+}
@@ -0,0 +1,3 @@
+# terraform import sci_application_authentication_rules.<resource_name> <application_id>
+
+terraform import sci_application_authentication_rules.my_rules dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0
@@ -0,0 +1,28 @@
+# Manage the ordered authentication rules of an application
+resource "sci_application_authentication_rules" "rules" {
+  application_id = "valid-uuid"
+  rules = [
+    {
+      identity_provider_id = "valid-idp-uuid-1"
+      user_type            = "partner" # Refer to the documentation for valid values
+      user_email_domain    = "*.partner.com"
+    },
+    {
+      identity_provider_id = "valid-idp-uuid-2"
+      user_group           = "valid-group-uuid"
+      ip_network_range     = "10.0.0.0/8"
+    }
+  ]
+}
+
+# Leave the rules to the resource above when managing the application
+resource "sci_application" "application" {
+  name = "My Application"
+  authentication_schema = {
+    default_authenticating_idp = "valid-idp-uuid-3"
+  }
+
+  lifecycle {
+    ignore_changes = [authentication_schema.conditional_authentication]
+  }
+}
@@ -95,6 +95,7 @@ resource "sci_corporate_idp" "oidc_example" {
     token_endpoint_auth_method = "clientSecretPost"
     scopes                     = ["openid", "email", "profile"]
     enable_pkce                = true
+    resolve_discovery          = true
 
     additional_config = {
       enforce_nonce                = true
@@ -103,3 +104,15 @@ resource "sci_corporate_idp" "oidc_example" {
     }
   }
 }
+
+# Example 3: SAML2 Corporate IdP configured from the metadata of the identity provider
+resource "sci_corporate_idp" "saml2_metadata_example" {
+  display_name = "My ADFS Corporate IdP"
+  name         = "my-adfs-idp"
+  type         = "microsoftADFS"
+
+  saml2_config = {
+    metadata_file    = "${path.module}/adfs-metadata.xml"
+    digest_algorithm = "sha256"
+  }
+}
@@ -12,3 +12,19 @@ resource "sci_group" "basic_group" {
     description = "Group for terraform users"
   }
 }
+
+# Create a group with the attributes of a custom schema in SAP Cloud Identity Services
+resource "sci_group" "classified_group" {
+  display_name = "My Classified Group"
+  schemas = [
+    "urn:ietf:params:scim:schemas:core:2.0:Group",
+    "urn:sap:cloud:scim:schemas:extension:custom:2.0:Group",
+    "urn:custom:SCI:1.0:Group" # The custom schema must be listed in the schemas
+  ]
+  custom_schemas = jsonencode({
+    "urn:custom:SCI:1.0:Group" : {
+      owner          = "jdoe@example.com"
+      classification = "confidential"
+    }
+  })
+}
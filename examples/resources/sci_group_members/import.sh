@@ -0,0 +1,3 @@
+# terraform import sci_group_members.<resource_name> <group_id>
+
+terraform import sci_group_members.my_group_members dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0
@@ -0,0 +1,12 @@
+# Assign a set of members to a group, leaving other members untouched
+resource "sci_group_members" "basic_group_members" {
+  group_id   = "valid-uuid"
+  member_ids = ["valid-uuid-1", "valid-uuid-2"]
+}
+
+# Manage all members of a group, removing any member not listed
+resource "sci_group_members" "authoritative_group_members" {
+  group_id      = "valid-uuid"
+  member_ids    = ["valid-uuid-1", "valid-uuid-2"]
+  authoritative = true
+}
@@ -0,0 +1,3 @@
+# terraform import sci_schema.<resource_name> <schema_id>
+
+terraform import sci_schema.basic_schema urn:sap:Terraform
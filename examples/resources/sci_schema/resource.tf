@@ -21,3 +21,37 @@ resource "sci_schema" "basic_schema" {
   ]
   description = "Test Schema"
 }
+
+# Create a schema with a complex attribute in SAP Cloud Identity Services
+resource "sci_schema" "complex_schema" {
+  id   = "urn:sap:TerraformAddress"
+  name = "TerraformAddress"
+  attributes = [
+    {
+      name        = "address"
+      type        = "complex" # Complex attributes must define sub_attributes
+      mutability  = "readWrite"
+      returned    = "default"
+      uniqueness  = "none"
+      multivalued = false
+      sub_attributes = [
+        {
+          name        = "street"
+          type        = "string" # Sub-attributes cannot be of type complex
+          mutability  = "readWrite"
+          returned    = "default"
+          uniqueness  = "none"
+          multivalued = false
+        },
+        {
+          name        = "zip_code"
+          type        = "string"
+          mutability  = "readWrite"
+          returned    = "default"
+          uniqueness  = "none"
+          multivalued = false
+        }
+      ]
+    }
+  ]
+}
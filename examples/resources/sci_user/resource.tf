@@ -45,3 +45,16 @@ resource "sci_user" "new_user" {
     }
   })
 }
+
+
+# Create a user in SAP Cloud Identity Services, which is deactivated instead of deleted on destroy
+resource "sci_user" "new_user" {
+  user_name = "jdoe"
+  emails = [
+    {
+      value = "john.doe@sap.com",
+      type  = "work"
+    }
+  ]
+  deletion_mode = "deactivate" # Refer to the documentation for valid values
+}
@@ -0,0 +1,3 @@
+# terraform import sci_user_group_memberships.<resource_name> <user_id>
+
+terraform import sci_user_group_memberships.my_memberships dd005d8b-1fee-4e6b-b6ff-cb9a197b7fe0
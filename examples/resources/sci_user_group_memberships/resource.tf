@@ -0,0 +1,12 @@
+# Assign a user to a set of groups, leaving other memberships untouched
+resource "sci_user_group_memberships" "basic_memberships" {
+  user_id   = "valid-uuid"
+  group_ids = ["valid-uuid-1", "valid-uuid-2"]
+}
+
+# Manage all direct memberships of a user, removing the user from any group not listed
+resource "sci_user_group_memberships" "authoritative_memberships" {
+  user_id       = "valid-uuid"
+  group_ids     = ["valid-uuid-1", "valid-uuid-2"]
+  authoritative = true
+}
@@ -0,0 +1,7 @@
+# Set the password of an existing user in SAP Cloud Identity Services
+resource "sci_user_password" "break_glass" {
+  user_id                    = sci_user.break_glass.id
+  password_wo                = var.break_glass_password # Must satisfy the password policy of the tenant
+  rotation_trigger           = "2026-Q4"                # Change the value to set the password again
+  force_change_on_next_logon = false
+}
@@ -0,0 +1,27 @@
+# Manage the users listed in a CSV file in SAP Cloud Identity Services
+# The CSV file must contain the columns user_name and email, the columns given_name, family_name, display_name, user_type and active are optional
+resource "sci_users_bulk" "contractors" {
+  filter = "userName sw \"ext_\""
+  users  = csvdecode(file("${path.module}/contractors.csv"))
+}
+
+
+# Manage a list of users in SAP Cloud Identity Services
+resource "sci_users_bulk" "new_users" {
+  batch_size      = 50
+  max_concurrency = 10
+  users = [
+    {
+      user_name   = "jdoe"
+      email       = "john.doe@sap.com"
+      given_name  = "John"
+      family_name = "Doe"
+      user_type   = "employee"
+      active      = true
+    },
+    {
+      user_name = "jsmith"
+      email     = "jane.smith@sap.com"
+    }
+  ]
+}
@@ -3,16 +3,21 @@ module github.com/SAP/terraform-provider-sap-cloud-identity-services
 go 1.26
 
 require (
+	github.com/beevik/etree v1.7.0
 	github.com/hashicorp/terraform-plugin-framework v1.19.0
 	github.com/hashicorp/terraform-plugin-framework-validators v0.19.0
 	github.com/hashicorp/terraform-plugin-go v0.31.0
 	github.com/hashicorp/terraform-plugin-testing v1.16.0
+	github.com/russellhaering/goxmldsig v1.6.1
 	github.com/stretchr/testify v1.12.1
 	gopkg.in/dnaeon/go-vcr.v3 v3.2.0
 	software.sslmate.com/src/go-pkcs12 v0.7.3
 )
 
-require go.yaml.in/yaml/v3 v3.0.5 // indirect
+require (
+	github.com/jonboulle/clockwork v0.5.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+)
 
 require (
 	github.com/ProtonMail/go-crypto v1.4.1 // indirect
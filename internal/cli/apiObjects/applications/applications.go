@@ -115,15 +115,18 @@ type EncryptionCertificateData struct {
 	Base64Certificate string `json:"base64Certificate" tfsdk:"base64_certificate"`
 	ValidFrom         string `json:"validFrom,omitempty" tfsdk:"valid_from"`
 	ValidTo           string `json:"validTo,omitempty" tfsdk:"valid_to"`
+	corporateidps.CertificateDetails
 }
 
 type ProxyAuthnRequest struct {
-	AuthenticationContext string `json:"authenticationContext,omitempty" tfsdk:"auth_context"`
+	AuthenticationContext string `json:"authenticationContext,omitempty" tfsdk:"authn_context"`
 	IssuerNameSuffix      string `json:"issuerNameSuffix,omitempty" tfsdk:"issuer_name_suffix"`
 }
 
 type SamlConfiguration struct {
 	SamlMetadataUrl           string                                 `json:"samlMetadataUrl,omitempty" tfsdk:"saml_metadata_url"`
+	MetadataXml               string                                 `json:"-" tfsdk:"metadata_xml"`
+	MetadataSigningCert       string                                 `json:"-" tfsdk:"metadata_signing_certificate"`
 	DefaultNameIdFormat       string                                 `json:"defaultNameIdFormat,omitempty" tfsdk:"default_name_id_format"`
 	AcsEndpoints              []Saml2AcsEndpoint                     `json:"acsEndpoints,omitempty" tfsdk:"acs_endpoints"`
 	SloEndpoints              []Saml2SLOEndpoint                     `json:"sloEndpoints,omitempty" tfsdk:"slo_endpoints"`
@@ -136,7 +139,7 @@ type SamlConfiguration struct {
 	CertificatesForSigning    []corporateidps.SigningCertificateData `json:"certificatesForSigning,omitempty" tfsdk:"signing_certificates"`
 	CertificateForEncryption  *EncryptionCertificateData             `json:"certificateForEncryption,omitempty" tfsdk:"encryption_certificate"`
 	DigestAlgorithm           string                                 `json:"digestAlgorithm,omitempty" tfsdk:"digest_algorithm"`
-	// ProxyAuthnRequest *ProxyAuthnRequest `json:"proxyAuthnRequest,omitempty" tfsdk:"proxy_auth_request"`
+	ProxyAuthnRequest         *ProxyAuthnRequest                     `json:"proxyAuthnRequest,omitempty" tfsdk:"proxy_authn_request"`
 }
 
 type ConsumedService struct {
@@ -226,16 +229,16 @@ type AuthenticationSchema struct {
 	SapManagedAttributes          *SapManagedAttributes        `json:"sapManagedAttributes,omitempty"`
 	RestApiAuthentication         *RestApiAuthentication       `json:"restApiAuthentication,omitempty"`
 	FallbackSubjectNameIdentifier string                       `json:"fallbackSubjectNameIdentifier,omitempty"`
+	DisabledInheritedProperties   *DisabledInheritedProperties `json:"disabledInheritedProperties,omitempty"`
+	ClientId                      string                       `json:"clientId,omitempty"`
 	// RiskBasedAuthentication       RBAConfiguration            `json:"riskBasedAuthentication"`
 	// HomeUrl								string 							`json:"homeUrl"`
 	// RememberMeExpirationTimeInMonths	string 							`json:"rememberMeExpirationTimeInMonths,omitempty"`
 	// PasswordPolicy						string 							`json:"passwordPolicy"`
 	// UserAccess							UserAccess 						`json:"userAccess,omitempty"`
 	// CompanyId							string 							`json:"companyId"`
-	// ClientId							string 							`json:"clientId"`
-	// ApiCertificates						[]ApiCertificateData			`json:"apiCertificates"`
+	ApiCertificates []ApiCertificateData `json:"apiCertificates,omitempty"`
 	// JwtClientAuthCredentials			[]JwtClientAuthCredential		`json:"jwtClientAuthCredentials"`
-	// DisabledInheritedProperties			DisabledInheritedProperties		`json:"disabledInheritedProperties,omitempty"`
 	// SocialSignOn						bool 							`json:"socialSignOn,omitempty"`
 	// SpnegoEnabled						bool 							`json:"spnegoEnabled,omitempty"`
 	// BiometricAuthenticationEnabled		bool 							`json:"biometricAuthenticationEnabled,omitempty"`
@@ -0,0 +1,50 @@
+package bulk
+
+const (
+	BulkRequestSchema  = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	BulkResponseSchema = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+
+	// prefix of the values referencing the resource created by another operation of the same request
+	BulkIdReferencePrefix = "bulkId:"
+)
+
+type Operation struct {
+	Method  string `json:"method"`
+	BulkId  string `json:"bulkId,omitempty"`
+	Version string `json:"version,omitempty"`
+	Path    string `json:"path"`
+	Data    any    `json:"data,omitempty"`
+}
+
+type BulkRequest struct {
+	Schemas      []string    `json:"schemas"`
+	FailOnErrors int         `json:"failOnErrors,omitempty"`
+	Operations   []Operation `json:"Operations"`
+}
+
+type OperationResponse struct {
+	Method   string `json:"method"`
+	BulkId   string `json:"bulkId,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Location string `json:"location,omitempty"`
+	// the status is returned as a string by most servers, but some return it as a number
+	Status   any `json:"status"`
+	Response any `json:"response,omitempty"`
+}
+
+type BulkResponse struct {
+	Schemas    []string            `json:"schemas"`
+	Operations []OperationResponse `json:"Operations"`
+}
+
+// Limits of the bulk endpoint as advertised by the ServiceProviderConfig
+type Limits struct {
+	Supported      bool `json:"supported" tfsdk:"supported"`
+	MaxOperations  int  `json:"maxOperations,omitempty" tfsdk:"max_operations"`
+	MaxPayloadSize int  `json:"maxPayloadSize,omitempty" tfsdk:"max_payload_size"`
+}
+
+// Reference returns the value referencing the resource created by the operation with the given bulk ID
+func Reference(bulkId string) string {
+	return BulkIdReferencePrefix + bulkId
+}
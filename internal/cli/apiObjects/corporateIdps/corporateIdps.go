@@ -19,6 +19,17 @@ type SigningCertificateData struct {
 	IsDefault         bool   `json:"isDefault" tfsdk:"default"`
 	ValidFrom         string `json:"validFrom,omitempty" tfsdk:"valid_from"`
 	ValidTo           string `json:"validTo,omitempty" tfsdk:"valid_to"`
+	CertificateDetails
+}
+
+// CertificateDetails are read by the provider from the X.509 certificate and are not sent to the API
+// they are nil if the certificate cannot be parsed
+type CertificateDetails struct {
+	SubjectDn         *string `json:"-" tfsdk:"subject_dn"`
+	Sha1Fingerprint   *string `json:"-" tfsdk:"sha1_fingerprint"`
+	Sha256Fingerprint *string `json:"-" tfsdk:"sha256_fingerprint"`
+	NotBefore         *string `json:"-" tfsdk:"not_before"`
+	NotAfter          *string `json:"-" tfsdk:"not_after"`
 }
 
 type AssertionAttribute struct {
@@ -35,6 +46,9 @@ type SAML2Configuration struct {
 	DigestAlgorithm        string                   `json:"digestAlgorithm,omitempty" tfsdk:"digest_algorithm"`
 	IncludeScoping         bool                     `json:"includeScoping,omitempty" tfsdk:"include_scoping"`
 	SamlMetadataUrl        string                   `json:"samlMetadataUrl,omitempty" tfsdk:"saml_metadata_url"`
+	MetadataXml            string                   `json:"-" tfsdk:"metadata_xml"`
+	MetadataFile           string                   `json:"-" tfsdk:"metadata_file"`
+	MetadataSigningCert    string                   `json:"-" tfsdk:"metadata_signing_certificate"`
 	SloEndpoints           []SAML2SLOEndpoint       `json:"sloEndpoints,omitempty" tfsdk:"slo_endpoints"`
 	SsoEndpoints           []SAML2SSOEndpoint       `json:"ssoEndpoints,omitempty" tfsdk:"sso_endpoints"`
 }
@@ -58,6 +72,7 @@ type OIDCConfiguration struct {
 	JwkSetPlain              string                `json:"jwkSetPlain,omitempty" tfsdk:"jwks"`
 	JwksUri                  string                `json:"jwksUri,omitempty" tfsdk:"jwks_uri"`
 	PkceEnabled              bool                  `json:"pkceEnabled,omitempty" tfsdk:"enable_pkce"`
+	ResolveDiscovery         bool                  `json:"-" tfsdk:"resolve_discovery"`
 	// RefreshDelay             int                   `json:"refreshDelay,omitempty"`
 	Scopes                  []string `json:"scopes,omitempty" tfsdk:"scopes"`
 	SubjectNameIdentifier   string   `json:"subjectNameIdentifier,omitempty" tfsdk:"subject_name_identifier"`
@@ -66,6 +81,20 @@ type OIDCConfiguration struct {
 	UserInfoEndpoint        string   `json:"userInfoEndpoint,omitempty" tfsdk:"user_info_endpoint"`
 }
 
+// OIDCDiscovery is the OpenID Provider Metadata of an identity provider, served by its discovery endpoint
+type OIDCDiscovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint,omitempty"`
+	TokenEndpoint                     string   `json:"token_endpoint,omitempty"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint,omitempty"`
+	EndSessionEndpoint                string   `json:"end_session_endpoint,omitempty"`
+	JwksUri                           string   `json:"jwks_uri,omitempty"`
+	ScopesSupported                   []string `json:"scopes_supported,omitempty"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported,omitempty"`
+	// the JSON Web Key Set referenced by JwksUri
+	Jwks string `json:"-"`
+}
+
 type Meta struct {
 	Created          string `json:"created,omitempty"`
 	CreatedBy        string `json:"createdBy,omitempty"`
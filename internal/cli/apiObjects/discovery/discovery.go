@@ -0,0 +1,70 @@
+package discovery
+
+import "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/bulk"
+
+const (
+	PaginationMethodCursor = "cursor"
+	PaginationMethodIndex  = "index"
+)
+
+type Supported struct {
+	Supported bool `json:"supported" tfsdk:"supported"`
+}
+
+type Filter struct {
+	Supported  bool `json:"supported" tfsdk:"supported"`
+	MaxResults int  `json:"maxResults,omitempty" tfsdk:"max_results"`
+}
+
+// Pagination as defined by RFC 9865, tenants which do not advertise it only support index based pagination
+type Pagination struct {
+	Cursor                  bool   `json:"cursor" tfsdk:"cursor"`
+	Index                   bool   `json:"index" tfsdk:"index"`
+	DefaultPaginationMethod string `json:"defaultPaginationMethod,omitempty" tfsdk:"default_pagination_method"`
+	DefaultPageSize         int    `json:"defaultPageSize,omitempty" tfsdk:"default_page_size"`
+	MaxPageSize             int    `json:"maxPageSize,omitempty" tfsdk:"max_page_size"`
+	CursorTimeout           int    `json:"cursorTimeout,omitempty" tfsdk:"cursor_timeout"`
+}
+
+type AuthenticationScheme struct {
+	Type             string `json:"type" tfsdk:"type"`
+	Name             string `json:"name" tfsdk:"name"`
+	Description      string `json:"description,omitempty" tfsdk:"description"`
+	SpecUri          string `json:"specUri,omitempty" tfsdk:"spec_uri"`
+	DocumentationUri string `json:"documentationUri,omitempty" tfsdk:"documentation_uri"`
+	Primary          bool   `json:"primary,omitempty" tfsdk:"primary"`
+}
+
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas,omitempty"`
+	DocumentationUri      string                 `json:"documentationUri,omitempty"`
+	Patch                 Supported              `json:"patch"`
+	Bulk                  bulk.Limits            `json:"bulk"`
+	Filter                Filter                 `json:"filter"`
+	ChangePassword        Supported              `json:"changePassword"`
+	Sort                  Supported              `json:"sort"`
+	Etag                  Supported              `json:"etag"`
+	Pagination            *Pagination            `json:"pagination,omitempty"`
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes,omitempty"`
+}
+
+type SchemaExtension struct {
+	Schema   string `json:"schema" tfsdk:"schema"`
+	Required bool   `json:"required" tfsdk:"required"`
+}
+
+type ResourceType struct {
+	Schemas          []string          `json:"schemas,omitempty"`
+	Id               string            `json:"id,omitempty"`
+	Name             string            `json:"name"`
+	Endpoint         string            `json:"endpoint"`
+	Description      string            `json:"description,omitempty"`
+	Schema           string            `json:"schema"`
+	SchemaExtensions []SchemaExtension `json:"schemaExtensions,omitempty"`
+}
+
+type ResourceTypesResponse struct {
+	Schemas      []string       `json:"schemas,omitempty"`
+	Resources    []ResourceType `json:"Resources"`
+	TotalResults int            `json:"totalResults,omitempty"`
+}
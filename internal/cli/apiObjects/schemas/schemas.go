@@ -16,6 +16,8 @@ type Attribute struct {
 	Returned        string   `json:"returned,omitempty"`
 	Uniqueness      string   `json:"uniqueness,omitempty"`
 	ReferenceTypes  []string `json:"referenceTypes,omitempty"`
+	// only attributes of type complex have sub-attributes, which cannot be nested further
+	SubAttributes []Attribute `json:"subAttributes,omitempty"`
 }
 
 type Schema struct {
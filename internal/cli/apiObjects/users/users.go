@@ -33,6 +33,11 @@ type SAPExtension struct {
 	// ValidTo   string `json:"validTo"`
 }
 
+// PasswordDetails is part of the SAP user extension and carries the status of the user's password
+type PasswordDetails struct {
+	Status string `json:"status,omitempty"`
+}
+
 type Manager struct {
 	DisplayName string `json:"displayName"`
 	Value       string `json:"value"`
@@ -150,4 +155,5 @@ type UsersResponse struct {
 	StartIndex   int      `json:"startIndex,omitempty"`
 	StartId      string   `json:"startId,omitempty"`
 	NextId       string   `json:"nextId,omitempty"`
+	NextCursor   string   `json:"nextCursor,omitempty"`
 }
@@ -0,0 +1,358 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/bulk"
+)
+
+// limits used if the ServiceProviderConfig of the tenant does not advertise any
+const (
+	DefaultBulkMaxOperations  = 100
+	DefaultBulkMaxPayloadSize = 1048576
+)
+
+var ErrBulkNotSupported = errors.New("the SCIM bulk endpoint is not supported by the tenant")
+
+var bulkIdReference = regexp.MustCompile(regexp.QuoteMeta(bulk.BulkIdReferencePrefix) + `([^"/]+)`)
+
+type BulkCli struct {
+	cliClient *Client
+	limits    *bulk.Limits
+}
+
+// BulkOperationResult is the outcome of a single operation of a bulk request
+type BulkOperationResult struct {
+	// position of the operation in the list of operations passed to Execute
+	Index    int
+	Method   string
+	BulkId   string
+	Path     string
+	Status   int
+	Location string
+	Version  string
+	Response any
+	// set if the operation failed or could not be sent
+	Err error
+}
+
+// ResourceId returns the ID of the resource created or modified by the operation
+func (r BulkOperationResult) ResourceId() string {
+	if len(r.Location) > 0 {
+		return path.Base(r.Location)
+	}
+
+	if responseMap, ok := r.Response.(map[string]any); ok {
+		if id, ok := responseMap["id"].(string); ok {
+			return id
+		}
+	}
+
+	return ""
+}
+
+func NewBulkCli(cliClient *Client) BulkCli {
+	return BulkCli{cliClient: cliClient}
+}
+
+func (b *BulkCli) getUrl() string {
+	return "scim/Bulk"
+}
+
+// SetLimits overrides the limits of the bulk endpoint, instead of reading them from the ServiceProviderConfig
+func (b *BulkCli) SetLimits(limits bulk.Limits) {
+	b.cliClient.discoveryMutex.Lock()
+	defer b.cliClient.discoveryMutex.Unlock()
+
+	b.limits = &limits
+}
+
+// Limits returns the limits of the bulk endpoint advertised by the ServiceProviderConfig of the tenant
+// the limits discovered by the client are used if available, otherwise they are read once and reused for subsequent calls
+// the limits are shared by all resources using the client and are guarded by the discovery mutex of the client
+func (b *BulkCli) Limits(ctx context.Context) (bulk.Limits, error) {
+
+	if limits := b.cachedLimits(); limits != nil {
+		return *limits, nil
+	}
+
+	var limits bulk.Limits
+
+	if config := b.cliClient.capabilities(ctx); config != nil {
+		limits = config.Bulk
+	} else {
+		discoveryCli := NewDiscoveryCli(b.cliClient)
+
+		config, err := discoveryCli.GetServiceProviderConfig(ctx)
+		if err != nil {
+			return bulk.Limits{}, err
+		}
+
+		limits = config.Bulk
+	}
+
+	if limits.MaxOperations <= 0 {
+		limits.MaxOperations = DefaultBulkMaxOperations
+	}
+	if limits.MaxPayloadSize <= 0 {
+		limits.MaxPayloadSize = DefaultBulkMaxPayloadSize
+	}
+
+	b.cliClient.discoveryMutex.Lock()
+	defer b.cliClient.discoveryMutex.Unlock()
+
+	// limits set or read concurrently in the meantime are kept
+	if b.limits == nil {
+		b.limits = &limits
+	}
+	return *b.limits, nil
+}
+
+func (b *BulkCli) cachedLimits() *bulk.Limits {
+	b.cliClient.discoveryMutex.Lock()
+	defer b.cliClient.discoveryMutex.Unlock()
+
+	return b.limits
+}
+
+// Execute sends the operations to the SCIM bulk endpoint
+// The operations are split into as many requests as needed to respect the limits of the tenant.
+// Operations without a bulk ID are assigned one, so that each operation of the response can be mapped to its request.
+// References in the form "bulkId:<id>" to operations sent in an earlier request are replaced by the ID of the created resource.
+//
+// A result is returned for each operation, in the order of the operations.
+// An error is only returned if a request could not be processed as a whole, in which case the results of the operations
+// which have not been processed are marked as failed with the same error.
+func (b *BulkCli) Execute(ctx context.Context, operations []bulk.Operation) ([]BulkOperationResult, error) {
+
+	limits, err := b.Limits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !limits.Supported {
+		return nil, ErrBulkNotSupported
+	}
+
+	operations = assignBulkIds(operations)
+
+	results := make([]BulkOperationResult, len(operations))
+	for i, operation := range operations {
+		results[i] = BulkOperationResult{
+			Index:  i,
+			Method: operation.Method,
+			BulkId: operation.BulkId,
+			Path:   operation.Path,
+		}
+	}
+
+	// IDs of the resources created by the requests already sent, keyed by the bulk ID
+	createdIds := map[string]string{}
+	// bulk IDs of operations which failed, operations referencing them cannot succeed
+	failedIds := map[string]bool{}
+
+	pending := make([]int, len(operations))
+	for i := range operations {
+		pending[i] = i
+	}
+
+	for len(pending) > 0 {
+
+		chunk := []int{}
+		chunkOperations := []bulk.Operation{}
+		chunkSize := bulkRequestOverhead()
+
+		for len(pending) > 0 && len(chunk) < limits.MaxOperations {
+			index := pending[0]
+
+			operation, err := resolveBulkIdReferences(operations[index], createdIds, failedIds)
+			if err == nil {
+				operationSize := bulkOperationSize(operation)
+
+				if operationSize+bulkRequestOverhead() > limits.MaxPayloadSize {
+					err = fmt.Errorf("the operation exceeds the maximum payload size of %d bytes", limits.MaxPayloadSize)
+				} else if chunkSize+operationSize > limits.MaxPayloadSize {
+					break
+				} else {
+					chunkSize += operationSize
+					chunk = append(chunk, index)
+					chunkOperations = append(chunkOperations, operation)
+				}
+			}
+
+			if err != nil {
+				results[index].Err = err
+				failedIds[operations[index].BulkId] = true
+			}
+
+			pending = pending[1:]
+		}
+
+		if len(chunk) == 0 {
+			continue
+		}
+
+		res, err := b.send(ctx, chunkOperations)
+		if err != nil {
+			for _, index := range append(chunk, pending...) {
+				results[index].Err = err
+			}
+			return results, err
+		}
+
+		chunkIndexByBulkId := make(map[string]int, len(chunk))
+		for _, index := range chunk {
+			chunkIndexByBulkId[operations[index].BulkId] = index
+		}
+
+		for _, operationResponse := range res.Operations {
+			index, found := chunkIndexByBulkId[operationResponse.BulkId]
+			if !found {
+				continue
+			}
+
+			results[index] = bulkOperationResultFrom(results[index], operationResponse)
+
+			if results[index].Err != nil {
+				failedIds[results[index].BulkId] = true
+			} else if results[index].Method == "POST" {
+				createdIds[results[index].BulkId] = results[index].ResourceId()
+			}
+		}
+
+		// operations which are not part of the response have not been processed by the server
+		for _, index := range chunk {
+			if results[index].Status == 0 && results[index].Err == nil {
+				results[index].Err = fmt.Errorf("the operation %s was not processed by the server", results[index].BulkId)
+				failedIds[results[index].BulkId] = true
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (b *BulkCli) send(ctx context.Context, operations []bulk.Operation) (bulk.BulkResponse, error) {
+
+	reqBody := bulk.BulkRequest{
+		Schemas:    []string{bulk.BulkRequestSchema},
+		Operations: operations,
+	}
+
+	res, _, err := b.cliClient.Execute(ctx, "POST", b.getUrl(), nil, reqBody, "", ScimRequestHeader, nil)
+	if err != nil {
+		return bulk.BulkResponse{}, err
+	}
+
+	response, _, err := unMarshalResponse[bulk.BulkResponse](res, false)
+	return response, err
+}
+
+// assignBulkIds returns a copy of the operations in which every operation has a unique bulk ID
+func assignBulkIds(operations []bulk.Operation) []bulk.Operation {
+
+	assigned := make([]bulk.Operation, len(operations))
+	used := make(map[string]bool, len(operations))
+
+	for _, operation := range operations {
+		if len(operation.BulkId) > 0 {
+			used[operation.BulkId] = true
+		}
+	}
+
+	for i, operation := range operations {
+		if len(operation.BulkId) == 0 {
+			bulkId := fmt.Sprintf("operation-%d", i)
+			for suffix := 1; used[bulkId]; suffix++ {
+				bulkId = fmt.Sprintf("operation-%d-%d", i, suffix)
+			}
+			operation.BulkId = bulkId
+			used[bulkId] = true
+		}
+		assigned[i] = operation
+	}
+
+	return assigned
+}
+
+// resolveBulkIdReferences replaces the references to operations sent in an earlier request with the ID of the created resource
+// references to operations of the same request are left as is and resolved by the server
+func resolveBulkIdReferences(operation bulk.Operation, createdIds map[string]string, failedIds map[string]bool) (bulk.Operation, error) {
+
+	var resolveErr error
+
+	resolve := func(value string) string {
+		return bulkIdReference.ReplaceAllStringFunc(value, func(reference string) string {
+			bulkId := strings.TrimPrefix(reference, bulk.BulkIdReferencePrefix)
+
+			if failedIds[bulkId] {
+				resolveErr = fmt.Errorf("the referenced operation %s failed", bulkId)
+			}
+
+			if id, found := createdIds[bulkId]; found {
+				return id
+			}
+			return reference
+		})
+	}
+
+	operation.Path = resolve(operation.Path)
+
+	if operation.Data != nil {
+		data, err := json.Marshal(operation.Data)
+		if err != nil {
+			return operation, err
+		}
+
+		if resolved := resolve(string(data)); resolved != string(data) {
+			var resolvedData any
+			if err := json.Unmarshal([]byte(resolved), &resolvedData); err != nil {
+				return operation, err
+			}
+			operation.Data = resolvedData
+		}
+	}
+
+	return operation, resolveErr
+}
+
+func bulkOperationResultFrom(result BulkOperationResult, operationResponse bulk.OperationResponse) BulkOperationResult {
+
+	result.Status, _ = strconv.Atoi(fmt.Sprint(operationResponse.Status))
+	result.Location = operationResponse.Location
+	result.Version = operationResponse.Version
+	result.Response = operationResponse.Response
+
+	if result.Status < 200 || result.Status >= 300 {
+		responseError, _, err := unMarshalResponse[ScimResponseError](operationResponse.Response, false)
+		if err != nil || len(responseError.Detail) == 0 {
+			responseError.Detail = fmt.Sprintf("the operation failed with status %d", result.Status)
+		}
+		responseError.Status = strconv.Itoa(result.Status)
+		result.Err = responseError
+	}
+
+	return result
+}
+
+// bulkRequestOverhead returns the size of an encoded bulk request without operations
+func bulkRequestOverhead() int {
+	encoded, _ := json.Marshal(bulk.BulkRequest{
+		Schemas:    []string{bulk.BulkRequestSchema},
+		Operations: []bulk.Operation{},
+	})
+	return len(encoded)
+}
+
+// bulkOperationSize returns the size of an encoded operation, including the separator in the list of operations
+func bulkOperationSize(operation bulk.Operation) int {
+	encoded, _ := json.Marshal(operation)
+	return len(encoded) + 1
+}
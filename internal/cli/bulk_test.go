@@ -0,0 +1,351 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/bulk"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var bulkPath = "/scim/Bulk"
+var serviceProviderConfigPath = "/scim/ServiceProviderConfig"
+
+// bulkTestServer processes the bulk requests like a SCIM server, creating a resource for every POST operation
+// the bulk requests received are recorded in the order in which they are received
+func bulkTestServer(t *testing.T, limits bulk.Limits, requests *[]bulk.BulkRequest, status func(bulk.Operation) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path == serviceProviderConfigPath {
+			res, _ := json.Marshal(map[string]any{"bulk": limits})
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+			return
+		}
+
+		var req bulk.BulkRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, bulkPath, r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		*requests = append(*requests, req)
+
+		res := bulk.BulkResponse{
+			Schemas: []string{bulk.BulkResponseSchema},
+		}
+
+		for _, operation := range req.Operations {
+			operationResponse := bulk.OperationResponse{
+				Method: operation.Method,
+				BulkId: operation.BulkId,
+				Status: status(operation),
+			}
+
+			if operationResponse.Status == "201" {
+				operationResponse.Location = "https://example.com/scim" + operation.Path + "/id-" + operation.BulkId
+			}
+
+			if strings.HasPrefix(operationResponse.Status.(string), "4") {
+				operationResponse.Response = ScimResponseError{
+					Schemas:  []string{"urn:ietf:params:scim:api:messages:2.0:Error"},
+					Status:   operationResponse.Status.(string),
+					ScimType: "invalidValue",
+					Detail:   "operation failed",
+				}
+			}
+
+			res.Operations = append(res.Operations, operationResponse)
+		}
+
+		encoded, _ := json.Marshal(res)
+		_, err := w.Write(encoded)
+		assert.NoError(t, err, "Failed to write response")
+	}
+}
+
+func successStatus(operation bulk.Operation) string {
+	switch operation.Method {
+	case "POST":
+		return "201"
+	case "DELETE":
+		return "204"
+	default:
+		return "200"
+	}
+}
+
+func TestBulk_Execute(t *testing.T) {
+
+	t.Run("validate the API request", func(t *testing.T) {
+
+		requests := []bulk.BulkRequest{}
+
+		client, srv := testClient(bulkTestServer(t, bulk.Limits{Supported: true, MaxOperations: 10, MaxPayloadSize: 10000}, &requests, successStatus))
+		defer srv.Close()
+
+		operations := []bulk.Operation{
+			{
+				Method: "POST",
+				BulkId: "user",
+				Path:   "/Users",
+				Data:   map[string]any{"userName": "user_for_testing"},
+			},
+			{
+				Method: "DELETE",
+				Path:   "/Users/valid-user-id",
+			},
+		}
+
+		results, err := client.Bulk.Execute(context.TODO(), operations)
+
+		assert.NoError(t, err)
+		assert.Len(t, requests, 1)
+		assert.Equal(t, []string{bulk.BulkRequestSchema}, requests[0].Schemas)
+		assert.Equal(t, "user", requests[0].Operations[0].BulkId)
+		assert.Equal(t, "operation-1", requests[0].Operations[1].BulkId)
+
+		assert.Len(t, results, 2)
+
+		assert.Equal(t, 0, results[0].Index)
+		assert.Equal(t, 201, results[0].Status)
+		assert.Equal(t, "id-user", results[0].ResourceId())
+		assert.NoError(t, results[0].Err)
+
+		assert.Equal(t, 1, results[1].Index)
+		assert.Equal(t, 204, results[1].Status)
+		assert.NoError(t, results[1].Err)
+	})
+
+	t.Run("validate the split by maximum operations with references", func(t *testing.T) {
+
+		requests := []bulk.BulkRequest{}
+
+		client, srv := testClient(bulkTestServer(t, bulk.Limits{Supported: true, MaxOperations: 2, MaxPayloadSize: 10000}, &requests, successStatus))
+		defer srv.Close()
+
+		operations := []bulk.Operation{
+			{Method: "POST", BulkId: "user1", Path: "/Users", Data: map[string]any{"userName": "user1"}},
+			{Method: "POST", BulkId: "user2", Path: "/Users", Data: map[string]any{"userName": "user2"}},
+			{Method: "POST", BulkId: "user3", Path: "/Users", Data: map[string]any{"userName": "user3"}},
+			{
+				Method: "POST",
+				BulkId: "group",
+				Path:   "/Groups",
+				Data: map[string]any{
+					"displayName": "group",
+					"members": []any{
+						map[string]any{"value": bulk.Reference("user1")},
+						map[string]any{"value": bulk.Reference("user3")},
+					},
+				},
+			},
+			{Method: "DELETE", Path: "/Groups/" + bulk.Reference("group")},
+		}
+
+		results, err := client.Bulk.Execute(context.TODO(), operations)
+
+		assert.NoError(t, err)
+		assert.Len(t, requests, 3)
+		assert.Len(t, requests[0].Operations, 2)
+		assert.Len(t, requests[1].Operations, 2)
+		assert.Len(t, requests[2].Operations, 1)
+
+		// the reference to user1 is resolved, since it was created by an earlier request
+		// the reference to user3 is left to the server, since it is part of the same request
+		assert.Equal(t, map[string]any{
+			"displayName": "group",
+			"members": []any{
+				map[string]any{"value": "id-user1"},
+				map[string]any{"value": bulk.Reference("user3")},
+			},
+		}, requests[1].Operations[1].Data)
+
+		assert.Equal(t, "/Groups/id-group", requests[2].Operations[0].Path)
+
+		for i, result := range results {
+			assert.Equal(t, i, result.Index)
+			assert.NoError(t, result.Err)
+		}
+	})
+
+	t.Run("validate the split by maximum payload size", func(t *testing.T) {
+
+		requests := []bulk.BulkRequest{}
+
+		operation := func(bulkId string, size int) bulk.Operation {
+			return bulk.Operation{
+				Method: "POST",
+				BulkId: bulkId,
+				Path:   "/Users",
+				Data:   map[string]any{"userName": strings.Repeat("a", size)},
+			}
+		}
+
+		maxPayloadSize := bulkRequestOverhead() + 2*bulkOperationSize(operation("1", 100))
+
+		client, srv := testClient(bulkTestServer(t, bulk.Limits{Supported: true, MaxOperations: 100, MaxPayloadSize: maxPayloadSize}, &requests, successStatus))
+		defer srv.Close()
+
+		operations := []bulk.Operation{
+			operation("1", 100),
+			operation("2", 100),
+			operation("3", 100),
+			operation("4", maxPayloadSize),
+			operation("5", 100),
+		}
+
+		results, err := client.Bulk.Execute(context.TODO(), operations)
+
+		assert.NoError(t, err)
+		assert.Len(t, requests, 2)
+		assert.Len(t, requests[0].Operations, 2)
+		assert.Len(t, requests[1].Operations, 2)
+
+		for _, request := range requests {
+			encoded, _ := json.Marshal(request)
+			assert.LessOrEqual(t, len(encoded), maxPayloadSize)
+		}
+
+		assert.NoError(t, results[2].Err)
+		assert.EqualError(t, results[3].Err, fmt.Sprintf("the operation exceeds the maximum payload size of %d bytes", maxPayloadSize))
+		assert.Equal(t, 0, results[3].Status)
+		assert.NoError(t, results[4].Err)
+	})
+
+	t.Run("validate the results of failed operations", func(t *testing.T) {
+
+		requests := []bulk.BulkRequest{}
+
+		client, srv := testClient(bulkTestServer(t, bulk.Limits{Supported: true, MaxOperations: 1, MaxPayloadSize: 10000}, &requests, func(operation bulk.Operation) string {
+			if operation.BulkId == "invalid" {
+				return "400"
+			}
+			return successStatus(operation)
+		}))
+		defer srv.Close()
+
+		operations := []bulk.Operation{
+			{Method: "POST", BulkId: "invalid", Path: "/Users", Data: map[string]any{"userName": ""}},
+			{Method: "PATCH", Path: "/Groups/valid-group-id", Data: map[string]any{"members": bulk.Reference("invalid")}},
+			{Method: "DELETE", Path: "/Users/valid-user-id"},
+		}
+
+		results, err := client.Bulk.Execute(context.TODO(), operations)
+
+		assert.NoError(t, err)
+
+		// the operation referencing the failed operation is not sent
+		assert.Len(t, requests, 2)
+
+		var scimErr ScimResponseError
+		assert.True(t, errors.As(results[0].Err, &scimErr))
+		assert.Equal(t, "400", scimErr.Status)
+		assert.Equal(t, "invalidValue", scimErr.ScimType)
+		assert.Equal(t, "SCIM error 400 \noperation failed", results[0].Err.Error())
+
+		assert.EqualError(t, results[1].Err, "the referenced operation invalid failed")
+
+		assert.Equal(t, 204, results[2].Status)
+		assert.NoError(t, results[2].Err)
+	})
+
+	t.Run("validate the numeric status of operations", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == serviceProviderConfigPath {
+				_, err := w.Write([]byte(`{"bulk":{"supported":true}}`))
+				assert.NoError(t, err, "Failed to write response")
+				return
+			}
+
+			_, err := w.Write([]byte(`{"Operations":[{"method":"DELETE","bulkId":"operation-0","status":204}]}`))
+			assert.NoError(t, err, "Failed to write response")
+		}))
+		defer srv.Close()
+
+		results, err := client.Bulk.Execute(context.TODO(), []bulk.Operation{{Method: "DELETE", Path: "/Users/valid-user-id"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 204, results[0].Status)
+		assert.NoError(t, results[0].Err)
+	})
+
+	t.Run("validate the limits are read concurrently", func(t *testing.T) {
+
+		requests := []bulk.BulkRequest{}
+
+		client, srv := testClient(bulkTestServer(t, bulk.Limits{Supported: true, MaxOperations: 2, MaxPayloadSize: 10000}, &requests, successStatus))
+		defer srv.Close()
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				limits, err := client.Bulk.Limits(context.TODO())
+
+				assert.NoError(t, err)
+				assert.Equal(t, 2, limits.MaxOperations)
+			})
+		}
+		wg.Wait()
+	})
+
+	t.Run("validate the API request with the bulk endpoint not supported", func(t *testing.T) {
+
+		requests := []bulk.BulkRequest{}
+
+		client, srv := testClient(bulkTestServer(t, bulk.Limits{Supported: false}, &requests, successStatus))
+		defer srv.Close()
+
+		_, err := client.Bulk.Execute(context.TODO(), []bulk.Operation{{Method: "DELETE", Path: "/Users/valid-user-id"}})
+
+		assert.ErrorIs(t, err, ErrBulkNotSupported)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("validate the API request with error", func(t *testing.T) {
+
+		resErr, _ := json.Marshal(ScimResponseError{
+			Detail: "too many operations",
+			Status: "413",
+		})
+
+		configRequests := 0
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == serviceProviderConfigPath {
+				configRequests++
+				_, err := w.Write([]byte(`{"bulk":{"supported":true,"maxOperations":1}}`))
+				assert.NoError(t, err, "Failed to write response")
+				return
+			}
+
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			_, err := w.Write(resErr)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+		defer srv.Close()
+
+		operations := []bulk.Operation{
+			{Method: "DELETE", Path: "/Users/valid-user-id"},
+			{Method: "DELETE", Path: "/Users/other-user-id"},
+		}
+
+		results, err := client.Bulk.Execute(context.TODO(), operations)
+
+		assert.EqualError(t, err, "SCIM error 413 \ntoo many operations")
+		assert.Len(t, results, 2)
+		assert.EqualError(t, results[0].Err, "SCIM error 413 \ntoo many operations")
+		assert.EqualError(t, results[1].Err, "SCIM error 413 \ntoo many operations")
+
+		// the limits are read only once
+		_, _ = client.Bulk.Execute(context.TODO(), operations)
+		assert.Equal(t, 1, configRequests)
+	})
+}
@@ -3,9 +3,12 @@ package cli
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"io"
 
@@ -15,6 +18,7 @@ import (
 	"net/url"
 
 	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/discovery"
 )
 
 // Error message for cases when the GET call for listing resources fails with a 404
@@ -22,9 +26,43 @@ import (
 var emptyResponseError, _ = regexp.Compile("Unable to find (.+)")
 
 type ScimResponseError struct {
-	Detail  string   `json:"detail"`
-	Schemas []string `json:"schemas"`
-	Status  string   `json:"status"`
+	Detail   string   `json:"detail"`
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+}
+
+func (e ScimResponseError) Error() string {
+	return fmt.Sprintf("SCIM error %s \n%s", e.Status, e.Detail)
+}
+
+// ScimErrorStatus retrieves the HTTP status of an error returned by a SCIM endpoint, or 0 if the error carries no status
+func ScimErrorStatus(err error) int {
+
+	var scimErr ScimResponseError
+	if errors.As(err, &scimErr) {
+		status, _ := strconv.Atoi(scimErr.Status)
+		return status
+	}
+
+	// errors which do not have a SCIM error body are formatted as "SCIM error <status>"
+	var status int
+	if _, scanErr := fmt.Sscanf(err.Error(), "SCIM error %d", &status); scanErr == nil {
+		return status
+	}
+
+	return 0
+}
+
+// IsPreconditionFailed checks whether the error indicates that the resource was modified since the version sent with If-Match was read
+func IsPreconditionFailed(err error) bool {
+	return ScimErrorStatus(err) == http.StatusPreconditionFailed
+}
+
+// isMethodNotSupported checks whether the error indicates that the endpoint does not offer the HTTP method
+func isMethodNotSupported(err error) bool {
+	status := ScimErrorStatus(err)
+	return status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented
 }
 
 type ErrorDetail struct {
@@ -42,6 +80,9 @@ const RequestHeader = "application/json"
 const ScimRequestHeader = "application/scim+json"
 const ScimUpdateSchemas = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
 
+// ETagHeader is the response header carrying the version of a SCIM resource
+const ETagHeader = "ETag"
+
 func NewClient(h *http.Client, u *url.URL) *Client {
 	return &Client{
 		HttpClient: h,
@@ -53,9 +94,23 @@ type Client struct {
 	HttpClient         *http.Client
 	ServerURL          *url.URL
 	AuthorizationToken string
+
+	// capabilities of the tenant, nil if they have not been discovered
+	serviceProviderConfig *discovery.ServiceProviderConfig
+	discoverOnFirstUse    bool
+	discoveryMutex        sync.Mutex
 }
 
 func (c *Client) DoRequest(ctx context.Context, method string, endpoint string, queryStrings map[string]string, body any, customSchemas string, reqHeader string) (*http.Response, error) {
+	return c.doRequest(ctx, method, endpoint, queryStrings, body, customSchemas, reqHeader, nil)
+}
+
+// doRequest sends the request with the additional request headers, such as preconditions
+func (c *Client) doRequest(ctx context.Context, method string, endpoint string, queryStrings map[string]string, body any, customSchemas string, reqHeader string, reqHeaders map[string]string) (*http.Response, error) {
+	if c.HttpClient == nil {
+		return nil, fmt.Errorf("no HTTP client configured")
+	}
+
 	parsedUrl, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
@@ -105,15 +160,24 @@ func (c *Client) DoRequest(ctx context.Context, method string, endpoint string,
 	req.Header.Set("DataServiceVersion", "2.0")
 	req.Header.Set("Content-Type", reqHeader)
 
+	for k, v := range reqHeaders {
+		req.Header.Set(k, v)
+	}
+
 	return c.HttpClient.Do(req)
 }
 
 func (c *Client) Execute(ctx context.Context, method string, endpoint string, queryStrings map[string]string, body any, customSchemas string, reqHeader string, headers []string) (any, map[string]string, error) {
+	return c.executeWithHeaders(ctx, method, endpoint, queryStrings, body, customSchemas, reqHeader, nil, headers)
+}
+
+// executeWithHeaders executes the request with the additional request headers, such as preconditions
+func (c *Client) executeWithHeaders(ctx context.Context, method string, endpoint string, queryStrings map[string]string, body any, customSchemas string, reqHeader string, reqHeaders map[string]string, headers []string) (any, map[string]string, error) {
 
 	var O any
 	out := make(map[string]string, len(headers))
 
-	res, err := c.DoRequest(ctx, method, endpoint, queryStrings, body, customSchemas, reqHeader)
+	res, err := c.doRequest(ctx, method, endpoint, queryStrings, body, customSchemas, reqHeader, reqHeaders)
 
 	if err != nil {
 		return nil, out, err
@@ -134,7 +198,8 @@ func (c *Client) Execute(ctx context.Context, method string, endpoint string, qu
 			var responseError ScimResponseError
 
 			if err = json.Unmarshal(rawBody, &responseError); err == nil && responseError.Detail != "" {
-				err = fmt.Errorf("SCIM error %s \n%s", responseError.Status, responseError.Detail)
+				// the error is returned as is, so that callers can inspect the status and the SCIM error type
+				err = responseError
 			} else {
 				err = fmt.Errorf("SCIM error %d \n%s", res.StatusCode, string(rawBody))
 			}
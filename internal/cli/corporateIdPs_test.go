@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -564,3 +565,101 @@ func TestCorporateIdPs_Delete(t *testing.T) {
 		assert.Equal(t, "error 400 \ndelete failed : server error", err.Error())
 	})
 }
+
+func TestCorporateIdPs_ResolveOidcDiscovery(t *testing.T) {
+
+	jwks := `{
+		"keys": [{"kty": "RSA", "kid": "key-1", "n": "AQAB", "e": "AQAB"}]
+	}`
+
+	// discoveryServer is a local stand-in for the discovery endpoints of an identity provider
+	discoveryServer := func(t *testing.T, configuration map[string]any) *httptest.Server {
+		var srv *httptest.Server
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			// the credentials of the tenant must never be sent to the identity provider
+			assert.Empty(t, r.Header.Get("Authorization"))
+			assert.Equal(t, "GET", r.Method)
+
+			switch r.URL.Path {
+			case "/.well-known/openid-configuration":
+				body := map[string]any{
+					"issuer":                 srv.URL,
+					"authorization_endpoint": srv.URL + "/authorize",
+					"token_endpoint":         srv.URL + "/token",
+					"userinfo_endpoint":      srv.URL + "/userinfo",
+					"end_session_endpoint":   srv.URL + "/logout",
+					"jwks_uri":               srv.URL + "/jwks",
+					"scopes_supported":       []string{"openid", "email"},
+				}
+				for k, v := range configuration {
+					body[k] = v
+				}
+				_ = json.NewEncoder(w).Encode(body)
+			case "/jwks":
+				_, _ = w.Write([]byte(jwks))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		return srv
+	}
+
+	t.Run("validate the discovery", func(t *testing.T) {
+
+		srv := discoveryServer(t, nil)
+		defer srv.Close()
+
+		client, tenant := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request to the tenant: %s", r.URL.Path)
+		}))
+		defer tenant.Close()
+		client.AuthorizationToken = "Bearer tenant-token"
+
+		// the issuer and the metadata URL are accepted as discovery URL
+		for _, discoveryUrl := range []string{srv.URL, srv.URL + "/", srv.URL + "/.well-known/openid-configuration"} {
+
+			discovery, err := client.CorporateIdP.ResolveOidcDiscovery(context.TODO(), discoveryUrl)
+
+			assert.NoError(t, err)
+			assert.Equal(t, corporateidps.OIDCDiscovery{
+				Issuer:                srv.URL,
+				AuthorizationEndpoint: srv.URL + "/authorize",
+				TokenEndpoint:         srv.URL + "/token",
+				UserInfoEndpoint:      srv.URL + "/userinfo",
+				EndSessionEndpoint:    srv.URL + "/logout",
+				JwksUri:               srv.URL + "/jwks",
+				ScopesSupported:       []string{"openid", "email"},
+				Jwks:                  `{"keys":[{"kty":"RSA","kid":"key-1","n":"AQAB","e":"AQAB"}]}`,
+			}, discovery)
+		}
+	})
+
+	t.Run("validate the discovery - error", func(t *testing.T) {
+
+		srv := discoveryServer(t, map[string]any{"issuer": ""})
+		defer srv.Close()
+
+		client, tenant := testClient(nil)
+		defer tenant.Close()
+
+		_, err := client.CorporateIdP.ResolveOidcDiscovery(context.TODO(), srv.URL)
+		assert.EqualError(t, err, fmt.Sprintf("the OpenID configuration of %s/.well-known/openid-configuration does not contain an issuer", srv.URL))
+
+		_, err = client.CorporateIdP.ResolveOidcDiscovery(context.TODO(), srv.URL+"/unknown/.well-known/openid-configuration")
+		assert.EqualError(t, err, fmt.Sprintf("unable to read %s/unknown/.well-known/openid-configuration : status 404", srv.URL))
+	})
+
+	t.Run("validate the discovery - invalid JWKS", func(t *testing.T) {
+
+		srv := discoveryServer(t, nil)
+		defer srv.Close()
+		jwks = `{"keys": [`
+
+		client, tenant := testClient(nil)
+		defer tenant.Close()
+
+		_, err := client.CorporateIdP.ResolveOidcDiscovery(context.TODO(), srv.URL)
+		assert.EqualError(t, err, fmt.Sprintf("the JSON Web Key Set of %s/jwks is invalid : unexpected end of JSON input", srv.URL))
+	})
+}
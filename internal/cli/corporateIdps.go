@@ -1,9 +1,12 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 	"time"
 
@@ -13,12 +16,25 @@ import (
 
 var corporateIdPPollInterval = 5 * time.Second
 
+// the path of the OpenID Provider Metadata, which is appended to discovery URLs denoting the issuer
+const oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+// the maximum size of the documents read from the discovery endpoints of identity providers
+const maxOidcDiscoveryDocumentSize = 1 << 20
+
 type CorporateIdPsCli struct {
 	cliClient *Client
+
+	// DiscoveryHttpClient sends the requests to the discovery endpoints of identity providers
+	// it is separate from the client of the tenant, so that the credentials of the tenant are never sent to an identity provider
+	DiscoveryHttpClient *http.Client
 }
 
 func NewCorporateIdPCli(cliClient *Client) CorporateIdPsCli {
-	return CorporateIdPsCli{cliClient: cliClient}
+	return CorporateIdPsCli{
+		cliClient:           cliClient,
+		DiscoveryHttpClient: &http.Client{Timeout: 30 * time.Second},
+	}
 }
 
 func (c *CorporateIdPsCli) getUrl() string {
@@ -75,6 +91,70 @@ func (c *CorporateIdPsCli) Update(ctx context.Context, args []generic.PatchReque
 	return c.pollUntilUpdated(ctx, idpId, args)
 }
 
+// ResolveOidcDiscovery reads the OpenID Provider Metadata of the discovery URL and the JSON Web Key Set it references
+// a discovery URL which does not end with the well-known path is treated as the issuer, the JSON Web Key Set is returned compacted
+func (c *CorporateIdPsCli) ResolveOidcDiscovery(ctx context.Context, discoveryUrl string) (corporateidps.OIDCDiscovery, error) {
+
+	metadataUrl := discoveryUrl
+	if !strings.HasSuffix(metadataUrl, oidcDiscoveryPath) {
+		metadataUrl = strings.TrimSuffix(metadataUrl, "/") + oidcDiscoveryPath
+	}
+
+	metadata, err := c.getDiscoveryDocument(ctx, metadataUrl)
+	if err != nil {
+		return corporateidps.OIDCDiscovery{}, err
+	}
+
+	var discovery corporateidps.OIDCDiscovery
+	if err := json.Unmarshal(metadata, &discovery); err != nil {
+		return corporateidps.OIDCDiscovery{}, fmt.Errorf("the OpenID configuration of %s is invalid : %s", metadataUrl, err)
+	}
+
+	if len(discovery.Issuer) == 0 {
+		return corporateidps.OIDCDiscovery{}, fmt.Errorf("the OpenID configuration of %s does not contain an issuer", metadataUrl)
+	}
+
+	if len(discovery.JwksUri) > 0 {
+		jwks, err := c.getDiscoveryDocument(ctx, discovery.JwksUri)
+		if err != nil {
+			return corporateidps.OIDCDiscovery{}, err
+		}
+
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, jwks); err != nil {
+			return corporateidps.OIDCDiscovery{}, fmt.Errorf("the JSON Web Key Set of %s is invalid : %s", discovery.JwksUri, err)
+		}
+		discovery.Jwks = compacted.String()
+	}
+
+	return discovery, nil
+}
+
+// getDiscoveryDocument reads a document from the discovery endpoint of an identity provider, without the credentials of the tenant
+func (c *CorporateIdPsCli) getDiscoveryDocument(ctx context.Context, documentUrl string) ([]byte, error) {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, documentUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", RequestHeader)
+
+	res, err := c.DiscoveryHttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s : %s", documentUrl, err)
+	}
+
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read %s : status %d", documentUrl, res.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, maxOidcDiscoveryDocumentSize))
+}
+
 func (c *CorporateIdPsCli) Delete(ctx context.Context, idpId string) error {
 	_, _, err := c.cliClient.Execute(ctx, "DELETE", fmt.Sprintf("%s%s", c.getUrl(), idpId), nil, nil, "", RequestHeader, nil)
 	return err
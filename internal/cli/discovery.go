@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/discovery"
+)
+
+type DiscoveryCli struct {
+	cliClient *Client
+}
+
+func NewDiscoveryCli(cliClient *Client) DiscoveryCli {
+	return DiscoveryCli{cliClient: cliClient}
+}
+
+func (d *DiscoveryCli) getUrl() string {
+	return "scim/"
+}
+
+func (d *DiscoveryCli) GetServiceProviderConfig(ctx context.Context) (discovery.ServiceProviderConfig, error) {
+
+	res, _, err := d.cliClient.Execute(ctx, "GET", d.getUrl()+"ServiceProviderConfig", nil, nil, "", ScimRequestHeader, nil)
+	if err != nil {
+		return discovery.ServiceProviderConfig{}, err
+	}
+
+	config, _, err := unMarshalResponse[discovery.ServiceProviderConfig](res, false)
+	return config, err
+}
+
+func (d *DiscoveryCli) GetResourceTypes(ctx context.Context) (discovery.ResourceTypesResponse, error) {
+
+	res, _, err := d.cliClient.Execute(ctx, "GET", d.getUrl()+"ResourceTypes", nil, nil, "", ScimRequestHeader, nil)
+	if err != nil {
+		return discovery.ResourceTypesResponse{}, err
+	}
+
+	resourceTypes, _, err := unMarshalResponse[discovery.ResourceTypesResponse](res, false)
+	return resourceTypes, err
+}
+
+// Discover reads the capabilities of the tenant and caches them on the client
+// the strategies used for filtering, paging, bulk operations and ETags are chosen based on the cached capabilities,
+// if the capabilities have not been discovered, the behaviour of a tenant supporting filters and index based paging is assumed
+// see also DiscoverOnFirstUse, which defers the discovery until the capabilities are needed
+func (c *SciClient) Discover(ctx context.Context) error {
+
+	config, err := c.Discovery.GetServiceProviderConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := c.Discovery.GetResourceTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.ServiceProviderConfig = &config
+	c.ResourceTypes = resourceTypes.Resources
+	c.Client.setCapabilities(&config)
+
+	return nil
+}
+
+// GetServiceProviderConfig returns the cached capabilities of the tenant, they are read if they have not been discovered yet
+func (c *SciClient) GetServiceProviderConfig(ctx context.Context) (discovery.ServiceProviderConfig, error) {
+
+	if c.ServiceProviderConfig == nil {
+		if err := c.Discover(ctx); err != nil {
+			return discovery.ServiceProviderConfig{}, err
+		}
+	}
+
+	return *c.ServiceProviderConfig, nil
+}
+
+// GetResourceTypes returns the cached resource types of the tenant, they are read if they have not been discovered yet
+func (c *SciClient) GetResourceTypes(ctx context.Context) ([]discovery.ResourceType, error) {
+
+	if c.ServiceProviderConfig == nil {
+		if err := c.Discover(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.ResourceTypes, nil
+}
+
+// DiscoverOnFirstUse reads the capabilities of the tenant when they are first needed by a call, instead of upfront
+// calls which do not depend on the capabilities do not read them at all
+func (c *SciClient) DiscoverOnFirstUse() {
+	c.Client.discoveryMutex.Lock()
+	defer c.Client.discoveryMutex.Unlock()
+
+	c.Client.discoverOnFirstUse = true
+}
+
+// capabilities returns the capabilities of the tenant, which are read once on first use if enabled
+// nil is returned if the capabilities have not been discovered or could not be read, in which case the default behaviour is assumed
+// the mutex is not held while the capabilities are read, so that concurrent calls are not blocked by the request
+// concurrent calls on first use may each read the capabilities, the first result is kept
+func (c *Client) capabilities(ctx context.Context) *discovery.ServiceProviderConfig {
+	c.discoveryMutex.Lock()
+	config, discover := c.serviceProviderConfig, c.discoverOnFirstUse
+	c.discoveryMutex.Unlock()
+
+	if config != nil || !discover {
+		return config
+	}
+
+	discoveryCli := NewDiscoveryCli(c)
+	discovered, err := discoveryCli.GetServiceProviderConfig(ctx)
+
+	c.discoveryMutex.Lock()
+	defer c.discoveryMutex.Unlock()
+
+	// the discovery is only attempted once, a failure is not repeated with every call
+	c.discoverOnFirstUse = false
+
+	if err == nil && c.serviceProviderConfig == nil {
+		c.serviceProviderConfig = &discovered
+	}
+
+	return c.serviceProviderConfig
+}
+
+// setCapabilities caches the discovered capabilities of the tenant
+func (c *Client) setCapabilities(config *discovery.ServiceProviderConfig) {
+	c.discoveryMutex.Lock()
+	defer c.discoveryMutex.Unlock()
+
+	c.serviceProviderConfig = config
+}
+
+// filterSupported reports whether the tenant supports filters, which is assumed if the capabilities have not been discovered
+func (c *Client) filterSupported(ctx context.Context) bool {
+	config := c.capabilities(ctx)
+	return config == nil || config.Filter.Supported
+}
+
+// patchSupported reports whether the tenant supports PATCH, which is not assumed if the capabilities have not been discovered
+func (c *Client) patchSupported(ctx context.Context) bool {
+	config := c.capabilities(ctx)
+	return config != nil && config.Patch.Supported
+}
+
+// etagSupported reports whether the tenant supports ETags, which is not assumed if the capabilities have not been discovered
+func (c *Client) etagSupported(ctx context.Context) bool {
+	config := c.capabilities(ctx)
+	return config != nil && config.Etag.Supported
+}
+
+// ifMatch returns the If-Match precondition for the given version of a resource
+// no precondition is returned if the version is unknown or the tenant does not support ETags
+func (c *Client) ifMatch(ctx context.Context, version string) map[string]string {
+
+	if len(version) == 0 || !c.etagSupported(ctx) {
+		return nil
+	}
+
+	// the version is sent as an entity tag, which must be quoted
+	if !strings.HasPrefix(version, `"`) && !strings.HasPrefix(version, `W/"`) {
+		version = strconv.Quote(version)
+	}
+
+	return map[string]string{
+		"If-Match": version,
+	}
+}
+
+// pageSize limits the requested page size to the maximum allowed by the tenant
+func (c *Client) pageSize(ctx context.Context, requested int) int {
+
+	config := c.capabilities(ctx)
+	if config == nil {
+		return requested
+	}
+
+	if pagination := config.Pagination; pagination != nil && pagination.MaxPageSize > 0 {
+		requested = min(requested, pagination.MaxPageSize)
+	}
+
+	if maxResults := config.Filter.MaxResults; maxResults > 0 {
+		requested = min(requested, maxResults)
+	}
+
+	return requested
+}
+
+// cursorPaging reports whether lists must be read with cursor based pagination
+// cursors are only used if the tenant does not support index based pagination or prefers cursors
+func (c *Client) cursorPaging(ctx context.Context) bool {
+
+	config := c.capabilities(ctx)
+	if config == nil || config.Pagination == nil {
+		return false
+	}
+
+	pagination := config.Pagination
+	return pagination.Cursor && (!pagination.Index || pagination.DefaultPaginationMethod == discovery.PaginationMethodCursor)
+}
@@ -0,0 +1,319 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/bulk"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/discovery"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var resourceTypesPath = "/scim/ResourceTypes"
+
+var serviceProviderConfigBody = discovery.ServiceProviderConfig{
+	Schemas: []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+	Patch:   discovery.Supported{Supported: true},
+	Bulk: bulk.Limits{
+		Supported:      true,
+		MaxOperations:  1000,
+		MaxPayloadSize: 1048576,
+	},
+	Filter: discovery.Filter{
+		Supported:  true,
+		MaxResults: 100,
+	},
+	Etag: discovery.Supported{Supported: true},
+	Pagination: &discovery.Pagination{
+		Cursor:                  true,
+		Index:                   true,
+		DefaultPaginationMethod: discovery.PaginationMethodIndex,
+		MaxPageSize:             50,
+	},
+	AuthenticationSchemes: []discovery.AuthenticationScheme{
+		{
+			Type: "httpbasic",
+			Name: "HTTP Basic",
+		},
+	},
+}
+
+var resourceTypesBody = discovery.ResourceTypesResponse{
+	Resources: []discovery.ResourceType{
+		{
+			Id:       "User",
+			Name:     "User",
+			Endpoint: "/Users",
+			Schema:   "urn:ietf:params:scim:schemas:core:2.0:User",
+			SchemaExtensions: []discovery.SchemaExtension{
+				{
+					Schema:   "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User",
+					Required: false,
+				},
+			},
+		},
+		{
+			Id:       "Group",
+			Name:     "Group",
+			Endpoint: "/Groups",
+			Schema:   "urn:ietf:params:scim:schemas:core:2.0:Group",
+		},
+	},
+	TotalResults: 2,
+}
+
+// discoveryTestHandler answers the discovery requests with the given capabilities and passes any other request to the handler
+func discoveryTestHandler(t *testing.T, config discovery.ServiceProviderConfig, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var res []byte
+
+		switch r.URL.Path {
+		case serviceProviderConfigPath:
+			res, _ = json.Marshal(config)
+		case resourceTypesPath:
+			res, _ = json.Marshal(resourceTypesBody)
+		default:
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		_, err := w.Write(res)
+		assert.NoError(t, err, "Failed to write response")
+	}
+}
+
+func TestDiscovery_GetServiceProviderConfig(t *testing.T) {
+
+	t.Run("validate the API request", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, _ := json.Marshal(serviceProviderConfigBody)
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[discovery.ServiceProviderConfig](t, r, serviceProviderConfigPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		res, err := client.Discovery.GetServiceProviderConfig(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, serviceProviderConfigBody, res)
+	})
+
+	t.Run("validate the API request with error", func(t *testing.T) {
+
+		resErr, _ := json.Marshal(ScimResponseError{
+			Detail: "unauthorized",
+			Status: "401",
+		})
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, err := w.Write(resErr)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[discovery.ServiceProviderConfig](t, r, serviceProviderConfigPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		_, err := client.Discovery.GetServiceProviderConfig(context.TODO())
+
+		assert.Error(t, err)
+		assert.Equal(t, "SCIM error 401 \nunauthorized", err.Error())
+	})
+}
+
+func TestDiscovery_GetResourceTypes(t *testing.T) {
+
+	t.Run("validate the API request", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, _ := json.Marshal(resourceTypesBody)
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[discovery.ResourceTypesResponse](t, r, resourceTypesPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		res, err := client.Discovery.GetResourceTypes(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, resourceTypesBody, res)
+	})
+}
+
+func TestDiscovery_Discover(t *testing.T) {
+
+	t.Run("validate the capabilities are cached", func(t *testing.T) {
+
+		requests := map[string]int{}
+
+		client, srv := testClient(func(w http.ResponseWriter, r *http.Request) {
+			requests[r.URL.Path]++
+			discoveryTestHandler(t, serviceProviderConfigBody, nil)(w, r)
+		})
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		config, err := client.GetServiceProviderConfig(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, serviceProviderConfigBody, config)
+
+		resourceTypes, err := client.GetResourceTypes(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, resourceTypesBody.Resources, resourceTypes)
+
+		// the bulk limits are taken from the discovered capabilities
+		limits, err := client.Bulk.Limits(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, serviceProviderConfigBody.Bulk, limits)
+
+		assert.Equal(t, 1, requests[serviceProviderConfigPath])
+		assert.Equal(t, 1, requests[resourceTypesPath])
+	})
+
+	t.Run("validate the capabilities are discovered on first use", func(t *testing.T) {
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, nil))
+
+		defer srv.Close()
+
+		resourceTypes, err := client.GetResourceTypes(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Len(t, resourceTypes, 2)
+		assert.NotNil(t, client.ServiceProviderConfig)
+	})
+
+	t.Run("validate the capabilities are only read by the calls depending on them", func(t *testing.T) {
+
+		requests := map[string]int{}
+
+		client, srv := testClient(func(w http.ResponseWriter, r *http.Request) {
+			requests[r.URL.Path]++
+			discoveryTestHandler(t, serviceProviderConfigBody, nil)(w, r)
+		})
+
+		defer srv.Close()
+
+		// without discovery on first use, the default behaviour is assumed
+		assert.Equal(t, 500, client.Client.pageSize(context.TODO(), 500))
+		assert.Zero(t, requests[serviceProviderConfigPath])
+
+		client.DiscoverOnFirstUse()
+
+		assert.Nil(t, client.Client.ifMatch(context.TODO(), ""))
+		assert.Zero(t, requests[serviceProviderConfigPath])
+
+		assert.Equal(t, 50, client.Client.pageSize(context.TODO(), 500))
+		assert.Equal(t, 20, client.Client.pageSize(context.TODO(), 20))
+		assert.Equal(t, 1, requests[serviceProviderConfigPath])
+		assert.Zero(t, requests[resourceTypesPath])
+	})
+
+	t.Run("validate a failed discovery on first use is not repeated", func(t *testing.T) {
+
+		requests := 0
+
+		client, srv := testClient(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		defer srv.Close()
+
+		client.DiscoverOnFirstUse()
+
+		assert.True(t, client.Client.filterSupported(context.TODO()))
+		assert.False(t, client.Client.etagSupported(context.TODO()))
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("validate the capabilities are not locked while they are read", func(t *testing.T) {
+
+		reading := make(chan struct{})
+		release := make(chan struct{})
+
+		client, srv := testClient(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == serviceProviderConfigPath {
+				close(reading)
+				<-release
+			}
+			discoveryTestHandler(t, serviceProviderConfigBody, nil)(w, r)
+		})
+
+		defer srv.Close()
+
+		client.DiscoverOnFirstUse()
+
+		discovered := make(chan *discovery.ServiceProviderConfig)
+		go func() {
+			discovered <- client.Client.capabilities(context.TODO())
+		}()
+
+		<-reading
+
+		// the capabilities and the bulk limits can be set while the capabilities are read
+		config := serviceProviderConfigBody
+		config.Filter.MaxResults = 10
+		client.Client.setCapabilities(&config)
+		client.Bulk.SetLimits(bulk.Limits{Supported: true, MaxOperations: 5, MaxPayloadSize: 100})
+
+		close(release)
+
+		// capabilities set in the meantime are kept
+		assert.Equal(t, &config, <-discovered)
+
+		limits, err := client.Bulk.Limits(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, 5, limits.MaxOperations)
+	})
+
+	t.Run("validate the page size is limited by the capabilities", func(t *testing.T) {
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, nil))
+
+		defer srv.Close()
+
+		assert.Equal(t, 500, client.Client.pageSize(context.TODO(), 500))
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		assert.Equal(t, 50, client.Client.pageSize(context.TODO(), 500))
+		assert.Equal(t, 20, client.Client.pageSize(context.TODO(), 20))
+	})
+	t.Run("validate the If-Match precondition depends on the capabilities", func(t *testing.T) {
+
+		config := serviceProviderConfigBody
+		config.Etag = discovery.Supported{Supported: false}
+
+		client, srv := testClient(discoveryTestHandler(t, config, nil))
+
+		defer srv.Close()
+
+		// ETags are not assumed if the capabilities have not been discovered
+		assert.Nil(t, client.Client.ifMatch(context.TODO(), `W/"1"`))
+
+		assert.NoError(t, client.Discover(context.TODO()))
+		assert.Nil(t, client.Client.ifMatch(context.TODO(), `W/"1"`))
+
+		etagConfig := config
+		etagConfig.Etag.Supported = true
+		client.Client.setCapabilities(&etagConfig)
+
+		assert.Equal(t, map[string]string{"If-Match": `W/"1"`}, client.Client.ifMatch(context.TODO(), `W/"1"`))
+		assert.Equal(t, map[string]string{"If-Match": `"1"`}, client.Client.ifMatch(context.TODO(), "1"))
+		assert.Nil(t, client.Client.ifMatch(context.TODO(), ""))
+	})
+}
@@ -2,15 +2,44 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 )
 
+// DefaultMembersChunkSize is the maximum number of members added or removed with a single PATCH request, if not configured otherwise
+const DefaultMembersChunkSize = 100
+
+// memberFilter matches the path of an operation on a single member of a group
+var memberFilter = regexp.MustCompile(`^members\[value eq "(.+)"\]$`)
+
 type GroupsCli struct {
 	cliClient *Client
+
+	// MembersChunkSize limits the number of members added or removed with a single PATCH request
+	MembersChunkSize int
+}
+
+// GroupPartiallyUpdatedError is returned if an update split into several requests failed after some of the requests were applied
+type GroupPartiallyUpdatedError struct {
+	Applied int
+	Total   int
+	Err     error
+}
+
+func (e GroupPartiallyUpdatedError) Error() string {
+	return fmt.Sprintf("the group has only been partially updated, %d of %d requests were applied : %s", e.Applied, e.Total, e.Err)
+}
+
+func (e GroupPartiallyUpdatedError) Unwrap() error {
+	return e.Err
 }
 
 func NewGroupCli(cliClient *Client) GroupsCli {
@@ -21,58 +50,390 @@ func (g *GroupsCli) getUrl() string {
 	return "scim/Groups/"
 }
 
-func (g *GroupsCli) Get(ctx context.Context) (groups.GroupsResponse, string, error) {
+func (g *GroupsCli) Get(ctx context.Context) (groups.GroupsResponse, map[int]string, error) {
 
 	res, _, err := g.cliClient.Execute(ctx, "GET", g.getUrl(), nil, nil, "", ScimRequestHeader, nil)
 
 	if err != nil {
-		return groups.GroupsResponse{}, "", err
+		return groups.GroupsResponse{}, map[int]string{}, err
+	}
+
+	groupsList := groups.GroupsResponse{}
+	customSchemas := map[int]string{}
+
+	// the attribute Resources is omitted from the response if the tenant has no groups
+	resources, _ := res.(map[string]any)["Resources"].([]any)
+
+	for i, r := range resources {
+
+		// each group is unmarshalled individually and the respective custom schemas are retrieved and added to the map
+		var group groups.Group
+		group, customSchemas[i], err = unMarshalResponse[groups.Group](r, true)
+
+		if err != nil {
+			return groups.GroupsResponse{}, map[int]string{}, err
+		}
+		groupsList.Resources = append(groupsList.Resources, group)
 	}
 
-	return unMarshalResponse[groups.GroupsResponse](res, false)
+	return groupsList, customSchemas, nil
+}
+
+// List retrieves all the groups matching the SCIM filter
+// the groups are read page by page, with at most pageSize groups per request
+// cursor based pagination is used instead of index based pagination if required by the tenant
+func (g *GroupsCli) List(ctx context.Context, filter string, pageSize int) (groups.GroupsResponse, error) {
+
+	groupsList := groups.GroupsResponse{}
+
+	query := map[string]string{
+		"count": strconv.Itoa(g.cliClient.pageSize(ctx, pageSize)),
+	}
+
+	if len(filter) > 0 {
+		if !g.cliClient.filterSupported(ctx) {
+			return groups.GroupsResponse{}, fmt.Errorf("filters are not supported by the tenant")
+		}
+		query["filter"] = filter
+	}
+
+	cursorPaging := g.cliClient.cursorPaging(ctx)
+	if cursorPaging {
+		// an empty cursor requests the first page
+		query["cursor"] = ""
+	} else {
+		query["startIndex"] = "1"
+	}
+
+	for {
+		res, _, err := g.cliClient.Execute(ctx, "GET", g.getUrl(), query, nil, "", ScimRequestHeader, nil)
+		if err != nil {
+			return groups.GroupsResponse{}, err
+		}
+
+		page, _, err := unMarshalResponse[groups.GroupsResponse](res, false)
+		if err != nil {
+			return groups.GroupsResponse{}, err
+		}
+
+		groupsList.Resources = append(groupsList.Resources, page.Resources...)
+		groupsList.TotalResults = page.TotalResults
+
+		// the attribute Resources is omitted from the response if no groups match the filter
+		if len(page.Resources) == 0 {
+			break
+		}
+
+		if cursorPaging {
+			if len(page.NextCursor) == 0 {
+				break
+			}
+			query["cursor"] = page.NextCursor
+		} else {
+			if len(groupsList.Resources) >= groupsList.TotalResults {
+				break
+			}
+			query["startIndex"] = strconv.Itoa(len(groupsList.Resources) + 1)
+		}
+	}
+
+	return groupsList, nil
 }
 
 func (g *GroupsCli) GetByGroupId(ctx context.Context, groupId string) (groups.Group, string, error) {
+	return g.getByGroupId(ctx, groupId, "")
+}
 
-	res, _, err := g.cliClient.Execute(ctx, "GET", fmt.Sprintf("%s%s", g.getUrl(), groupId), nil, nil, "", ScimRequestHeader, nil)
+// getByGroupId reads the group and validates that the custom schemas are part of the response
+func (g *GroupsCli) getByGroupId(ctx context.Context, groupId string, customSchemas string) (groups.Group, string, error) {
+
+	res, headers, err := g.cliClient.Execute(ctx, "GET", fmt.Sprintf("%s%s", g.getUrl(), groupId), nil, nil, "", ScimRequestHeader, []string{ETagHeader})
 
 	if err != nil {
 		return groups.Group{}, "", err
 	}
 
-	return unMarshalResponse[groups.Group](res, false)
+	if len(customSchemas) > 0 {
+		if result, err := validateCustomSchemasResponse(res, customSchemas); !result {
+			return groups.Group{}, "", err
+		}
+	}
+
+	group, cS, err := unMarshalResponse[groups.Group](res, true)
+	group.Meta.Version = versionFrom(group.Meta, headers)
+
+	return group, cS, err
 }
 
-func (g *GroupsCli) Create(ctx context.Context, args *groups.Group) (groups.Group, string, error) {
+// Create creates the group
+// if the group has more members than the configured chunk size, it is created with the first chunk of members and the remaining members are added afterwards.
+// If adding the remaining members fails, the created group is returned with a GroupPartiallyUpdatedError.
+func (g *GroupsCli) Create(ctx context.Context, customSchemas string, args *groups.Group) (groups.Group, string, error) {
+
+	var remaining []groups.GroupMember
+	if chunkSize := g.membersChunkSize(); len(args.GroupMembers) > chunkSize {
+		group := *args
+		group.GroupMembers, remaining = args.GroupMembers[:chunkSize], args.GroupMembers[chunkSize:]
+		args = &group
+	}
 
-	res, _, err := g.cliClient.Execute(ctx, "POST", g.getUrl(), nil, args, "", ScimRequestHeader, nil)
+	res, headers, err := g.cliClient.Execute(ctx, "POST", g.getUrl(), nil, args, customSchemas, ScimRequestHeader, []string{ETagHeader})
 
 	if err != nil {
 		return groups.Group{}, "", err
 	}
 
-	return unMarshalResponse[groups.Group](res, false)
-}
+	if len(customSchemas) > 0 {
+		if result, err := validateCustomSchemasResponse(res, customSchemas); !result {
+			return groups.Group{}, "", err
+		}
+	}
 
-func (g *GroupsCli) Update(ctx context.Context, args []generic.PatchRequest, groupId string) (groups.Group, string, error) {
+	group, cS, err := unMarshalResponse[groups.Group](res, false)
+	group.Meta.Version = versionFrom(group.Meta, headers)
 
-	reqBody := groups.PatchRequestBody{
-		Schemas:    []string{ScimUpdateSchemas},
-		Operations: args,
+	if err != nil || len(remaining) == 0 {
+		return group, cS, err
 	}
 
-	_, _, err := g.cliClient.Execute(ctx, "PATCH", fmt.Sprintf("%s%s", g.getUrl(), groupId), nil, reqBody, "", ScimRequestHeader, nil)
+	updated, updatedCS, err := g.Update(ctx, []generic.PatchRequest{{
+		Op:    "add",
+		Path:  "members",
+		Value: remaining,
+	}}, group.Id, customSchemas, group.Meta.Version)
+
+	if err == nil {
+		return updated, updatedCS, nil
+	}
 
+	// the creation of the group counts as the first of the requests
+	partialErr := GroupPartiallyUpdatedError{
+		Applied: 1,
+		Total:   1 + len(chunkMemberOperations([]generic.PatchRequest{{Op: "add", Path: "members", Value: remaining}}, g.membersChunkSize())),
+		Err:     err,
+	}
+
+	var updateErr GroupPartiallyUpdatedError
+	if errors.As(err, &updateErr) {
+		partialErr.Applied += updateErr.Applied
+		partialErr.Err = updateErr.Err
+		return updated, updatedCS, partialErr
+	}
+
+	return group, cS, partialErr
+}
+
+// Update patches the group and reads the updated group
+// operations on more members than the configured chunk size are split into several requests, which are applied in order.
+// If a request fails after others have been applied, the group is read again and returned with a GroupPartiallyUpdatedError.
+// if the version of the group is given and the tenant supports ETags, the update fails if the group has been modified since that version
+func (g *GroupsCli) Update(ctx context.Context, args []generic.PatchRequest, groupId string, customSchemas string, version string) (groups.Group, string, error) {
+
+	args, err := g.withoutMemberFilters(ctx, groupId, args)
 	if err != nil {
 		return groups.Group{}, "", err
 	}
 
-	return g.GetByGroupId(ctx, groupId)
+	requests := chunkMemberOperations(args, g.membersChunkSize())
+
+	for i, operations := range requests {
+
+		reqBody := groups.PatchRequestBody{
+			Schemas:    []string{ScimUpdateSchemas},
+			Operations: operations,
+		}
+
+		_, headers, err := g.cliClient.executeWithHeaders(ctx, "PATCH", fmt.Sprintf("%s%s", g.getUrl(), groupId), nil, reqBody, "", ScimRequestHeader, g.cliClient.ifMatch(ctx, version), []string{ETagHeader})
+
+		if err != nil {
+			if i == 0 {
+				return groups.Group{}, "", err
+			}
+
+			// the group is read again, so that the caller knows which of the changes have been applied
+			group, cS, readErr := g.GetByGroupId(ctx, groupId)
+			if readErr != nil {
+				return groups.Group{}, "", err
+			}
+
+			return group, cS, GroupPartiallyUpdatedError{
+				Applied: i,
+				Total:   len(requests),
+				Err:     err,
+			}
+		}
+
+		// the following requests are only sent with a precondition if the tenant returns the new version
+		version = headers[ETagHeader]
+	}
+
+	return g.getByGroupId(ctx, groupId, customSchemas)
+}
+
+func (g *GroupsCli) membersChunkSize() int {
+	if g.MembersChunkSize <= 0 {
+		return DefaultMembersChunkSize
+	}
+	return g.MembersChunkSize
+}
+
+// withoutMemberFilters replaces the removals of single members by a replacement of the members, if the tenant does not support filters
+// the remaining members are read from the group
+func (g *GroupsCli) withoutMemberFilters(ctx context.Context, groupId string, args []generic.PatchRequest) ([]generic.PatchRequest, error) {
+
+	if g.cliClient.filterSupported(ctx) {
+		return args, nil
+	}
+
+	removed := map[string]bool{}
+	operations := []generic.PatchRequest{}
+	position := -1
+
+	for _, arg := range args {
+		if match := memberFilter.FindStringSubmatch(arg.Path); arg.Op == "remove" && match != nil {
+			removed[match[1]] = true
+			if position < 0 {
+				position = len(operations)
+			}
+			continue
+		}
+		operations = append(operations, arg)
+	}
+
+	if len(removed) == 0 {
+		return args, nil
+	}
+
+	group, _, err := g.GetByGroupId(ctx, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := []groups.GroupMember{}
+	for _, member := range group.GroupMembers {
+		if !removed[member.Value] {
+			remaining = append(remaining, member)
+		}
+	}
+
+	return slices.Insert(operations, position, generic.PatchRequest{
+		Op:    "replace",
+		Path:  "members",
+		Value: remaining,
+	}), nil
+}
+
+// chunkMemberOperations splits the operations into requests, each of which adds, replaces or removes at most chunkSize members.
+// Operations on more members are split, a replacement of the members is continued with additions of the remaining members.
+// Every other operation counts as a single member.
+func chunkMemberOperations(args []generic.PatchRequest, chunkSize int) [][]generic.PatchRequest {
+
+	requests := [][]generic.PatchRequest{}
+	operations := []generic.PatchRequest{}
+	size := 0
+
+	next := func() {
+		if len(operations) > 0 {
+			requests = append(requests, operations)
+			operations = []generic.PatchRequest{}
+			size = 0
+		}
+	}
+
+	for _, arg := range args {
+
+		members, ok := memberValues(arg)
+		if !ok || len(members) == 0 {
+			if size >= chunkSize {
+				next()
+			}
+			operations = append(operations, arg)
+			size++
+			continue
+		}
+
+		op := arg.Op
+		for len(members) > 0 {
+			if size >= chunkSize {
+				next()
+			}
+
+			n := min(chunkSize-size, len(members))
+			operations = append(operations, generic.PatchRequest{
+				Op:    op,
+				Path:  arg.Path,
+				Value: members[:n],
+			})
+			size += n
+			members = members[n:]
+
+			// the members of the following chunks must be added to the members replaced by the first chunk
+			if op == "replace" {
+				op = "add"
+			}
+		}
+	}
+
+	next()
+
+	return requests
+}
+
+// memberValues returns the members of an operation on the members of a group
+func memberValues(arg generic.PatchRequest) ([]any, bool) {
+
+	if arg.Path != "members" || arg.Value == nil {
+		return nil, false
+	}
+
+	value := reflect.ValueOf(arg.Value)
+	if value.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	members := make([]any, value.Len())
+	for i := range members {
+		members[i] = value.Index(i).Interface()
+	}
+
+	return members, true
+}
+
+// AddMembers adds the members to the group
+func (g *GroupsCli) AddMembers(ctx context.Context, groupId string, members []groups.GroupMember) (groups.Group, string, error) {
+
+	args := []generic.PatchRequest{
+		{
+			Op:    "add",
+			Path:  "members",
+			Value: members,
+		},
+	}
+
+	return g.Update(ctx, args, groupId, "", "")
+}
+
+// RemoveMembers removes the members with the given IDs from the group
+// if the tenant does not support filters, the members of the group are read and replaced by the remaining members
+func (g *GroupsCli) RemoveMembers(ctx context.Context, groupId string, memberIds []string) (groups.Group, string, error) {
+
+	args := []generic.PatchRequest{}
+
+	for _, memberId := range memberIds {
+		args = append(args, generic.PatchRequest{
+			Op:   "remove",
+			Path: fmt.Sprintf(`members[value eq "%s"]`, memberId),
+		})
+	}
+
+	return g.Update(ctx, args, groupId, "", "")
 }
 
-func (g *GroupsCli) Delete(ctx context.Context, groupId string) error {
+// Delete deletes the group
+// if the version of the group is given and the tenant supports ETags, the deletion fails if the group has been modified since that version
+func (g *GroupsCli) Delete(ctx context.Context, groupId string, version string) error {
 
-	_, _, err := g.cliClient.Execute(ctx, "DELETE", fmt.Sprintf("%s%s", g.getUrl(), groupId), nil, nil, "", ScimRequestHeader, nil)
+	_, _, err := g.cliClient.executeWithHeaders(ctx, "DELETE", fmt.Sprintf("%s%s", g.getUrl(), groupId), nil, nil, "", ScimRequestHeader, g.cliClient.ifMatch(ctx, version), nil)
 
 	return err
 }
@@ -3,10 +3,13 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"testing"
 
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/discovery"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
 
@@ -30,6 +33,13 @@ var groupsBody = groups.Group{
 
 var groupsResponse []byte
 
+var groupCustomSchemas, _ = json.Marshal(map[string]any{
+	"urn:test:terraform:1.0:Group": map[string]any{
+		"owner":          "owner@example.com",
+		"classification": "confidential",
+	},
+})
+
 func TestGroups_Create(t *testing.T) {
 
 	groupsResponse, _ = json.Marshal(groupsBody)
@@ -45,11 +55,44 @@ func TestGroups_Create(t *testing.T) {
 
 		defer srv.Close()
 
-		_, _, err := client.Group.Create(context.TODO(), &groupsBody)
+		_, _, err := client.Group.Create(context.TODO(), "", &groupsBody)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("validate the API request with custom schemas", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(responseWithCustomSchemas(groupsResponse, groupCustomSchemas))
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[groups.Group](t, r, groupsPath, "POST", groupsBody)
+		}))
+
+		defer srv.Close()
+
+		_, _, err := client.Group.Create(context.TODO(), string(groupCustomSchemas), &groupsBody)
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("validate the API request with custom schemas - error", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(groupsResponse)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[groups.Group](t, r, groupsPath, "POST", groupsBody)
+		}))
+
+		defer srv.Close()
+
+		res, _, err := client.Group.Create(context.TODO(), string(groupCustomSchemas), &groupsBody)
+
+		assert.Zero(t, res)
+		assert.EqualError(t, err, "urn:test:terraform:1.0:Group not found in the returned response")
+	})
+
 	t.Run("validate the API request - error", func(t *testing.T) {
 
 		resErr, _ := json.Marshal(ScimResponseError{
@@ -67,7 +110,7 @@ func TestGroups_Create(t *testing.T) {
 
 		defer srv.Close()
 
-		res, _, err := client.Group.Create(context.TODO(), &groupsBody)
+		res, _, err := client.Group.Create(context.TODO(), "", &groupsBody)
 
 		assert.Zero(t, res)
 		assert.Error(t, err)
@@ -102,6 +145,28 @@ func TestGroups_Get(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("validate the API request with custom schemas", func(t *testing.T) {
+
+		groupResponse, _ := json.Marshal(groupsBody)
+		res := fmt.Sprintf(`{"Resources":[%s,%s],"totalResults":2}`, groupResponse, responseWithCustomSchemas(groupResponse, groupCustomSchemas))
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(res))
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[groups.Group](t, r, groupsPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		groupsRes, customSchemas, err := client.Group.Get(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Len(t, groupsRes.Resources, 2)
+		assert.Empty(t, customSchemas[0])
+		assert.JSONEq(t, string(groupCustomSchemas), customSchemas[1])
+	})
+
 	t.Run("validate the API request with error", func(t *testing.T) {
 
 		resErr, _ := json.Marshal(ScimResponseError{
@@ -127,6 +192,62 @@ func TestGroups_Get(t *testing.T) {
 	})
 }
 
+func TestGroups_List(t *testing.T) {
+
+	t.Run("validate the API request with pagination", func(t *testing.T) {
+
+		requests := 0
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			assert.Equal(t, `members.value eq "scim-id-member-1"`, r.URL.Query().Get("filter"))
+			assert.Equal(t, "2", r.URL.Query().Get("count"))
+			assert.Equal(t, fmt.Sprintf("%d", 2*requests-1), r.URL.Query().Get("startIndex"))
+
+			page := []groups.Group{groupsBody, groupsBody}
+			if requests == 2 {
+				page = []groups.Group{groupsBody}
+			}
+
+			res, _ := json.Marshal(groups.GroupsResponse{
+				Resources:    page,
+				TotalResults: 3,
+			})
+
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[groups.Group](t, r, groupsPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		res, err := client.Group.List(context.TODO(), `members.value eq "scim-id-member-1"`, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, requests)
+		assert.Len(t, res.Resources, 3)
+	})
+
+	t.Run("validate the API request with no matching groups", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"totalResults":0}`))
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[groups.Group](t, r, groupsPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		res, err := client.Group.List(context.TODO(), "", 100)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Resources)
+	})
+}
+
 func TestGroups_GetByGroupId(t *testing.T) {
 
 	groupsResponse, _ = json.Marshal(groupsBody)
@@ -147,6 +268,24 @@ func TestGroups_GetByGroupId(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("validate the API request with custom schemas", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(responseWithCustomSchemas(groupsResponse, groupCustomSchemas))
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[groups.Group](t, r, fmt.Sprintf("%s%s", groupsPath, "valid-group-id"), "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		res, customSchemas, err := client.Group.GetByGroupId(context.TODO(), "valid-group-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, groupsBody.DisplayName, res.DisplayName)
+		assert.JSONEq(t, string(groupCustomSchemas), customSchemas)
+	})
+
 	t.Run("validate the API request with error", func(t *testing.T) {
 
 		resErr, _ := json.Marshal(ScimResponseError{
@@ -245,9 +384,39 @@ func TestGroups_Update(t *testing.T) {
 
 		defer srv.Close()
 
-		_, _, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id")
+		_, _, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id", "", "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("validate the API request with custom schemas", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(responseWithCustomSchemas(groupsResponse, groupCustomSchemas))
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		_, customSchemas, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id", string(groupCustomSchemas), "")
 
 		assert.NoError(t, err)
+		assert.JSONEq(t, string(groupCustomSchemas), customSchemas)
+	})
+
+	t.Run("validate the API request with custom schemas - error", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(groupsResponse)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		res, _, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id", string(groupCustomSchemas), "")
+
+		assert.Zero(t, res)
+		assert.EqualError(t, err, "urn:test:terraform:1.0:Group not found in the returned response")
 	})
 
 	t.Run("validate the API request with error", func(t *testing.T) {
@@ -265,12 +434,148 @@ func TestGroups_Update(t *testing.T) {
 
 		defer srv.Close()
 
-		res, _, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id")
+		res, _, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id", "", "")
 
 		assert.Zero(t, res)
 		assert.Error(t, err)
 		assert.Equal(t, "SCIM error 400 \nupdate failed", err.Error())
 	})
+	t.Run("validate the API request with version", func(t *testing.T) {
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PATCH" {
+				assert.Equal(t, `W/"1"`, r.Header.Get("If-Match"))
+			} else {
+				assert.Empty(t, r.Header.Get("If-Match"))
+				w.Header().Set(ETagHeader, `W/"2"`)
+			}
+			_, err := w.Write(groupsResponse)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		res, _, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id", "", `W/"1"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `W/"2"`, res.Meta.Version)
+	})
+
+	t.Run("validate the API request with version - ETags not supported", func(t *testing.T) {
+
+		config := serviceProviderConfigBody
+		config.Etag = discovery.Supported{Supported: false}
+
+		client, srv := testClient(discoveryTestHandler(t, config, func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("If-Match"))
+			_, err := w.Write(groupsResponse)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		_, _, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id", "", `W/"1"`)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("validate the API request with version - precondition failed", func(t *testing.T) {
+
+		resErr, _ := json.Marshal(ScimResponseError{
+			Detail: "version mismatch",
+			Status: "412",
+		})
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, err := w.Write(resErr)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		res, _, err := client.Group.Update(context.TODO(), patchRequests, "valid-group-id", "", `W/"1"`)
+
+		assert.Zero(t, res)
+		assert.True(t, IsPreconditionFailed(err))
+	})
+}
+
+func TestGroups_RemoveMembers(t *testing.T) {
+
+	groupsBody.Id = "valid-group-id"
+	groupsResponse, _ := json.Marshal(groupsBody)
+
+	t.Run("validate the API request", func(t *testing.T) {
+
+		var operations []generic.PatchRequest
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PATCH" {
+				var actualBody groups.PatchRequestBody
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&actualBody))
+				operations = actualBody.Operations
+			}
+			_, err := w.Write(groupsResponse)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		_, _, err := client.Group.RemoveMembers(context.TODO(), "valid-group-id", []string{"scim-id-member-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []generic.PatchRequest{
+			{
+				Op:   "remove",
+				Path: `members[value eq "scim-id-member-1"]`,
+			},
+		}, operations)
+	})
+
+	t.Run("validate the API request with filters not supported", func(t *testing.T) {
+
+		config := serviceProviderConfigBody
+		config.Filter = discovery.Filter{Supported: false}
+
+		var operations []generic.PatchRequest
+
+		client, srv := testClient(discoveryTestHandler(t, config, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PATCH" {
+				var actualBody groups.PatchRequestBody
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&actualBody))
+				operations = actualBody.Operations
+			}
+			_, err := w.Write(groupsResponse)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		_, _, err := client.Group.RemoveMembers(context.TODO(), "valid-group-id", []string{"scim-id-member-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []generic.PatchRequest{
+			{
+				Op:   "replace",
+				Path: "members",
+				Value: []any{
+					map[string]any{
+						"value": "scim-id-member-2",
+						"type":  "User",
+					},
+				},
+			},
+		}, operations)
+	})
 }
 
 func TestGroups_Delete(t *testing.T) {
@@ -283,7 +588,7 @@ func TestGroups_Delete(t *testing.T) {
 
 		defer srv.Close()
 
-		err := client.Group.Delete(context.TODO(), "valid-group-id")
+		err := client.Group.Delete(context.TODO(), "valid-group-id", "")
 
 		assert.NoError(t, err)
 	})
@@ -305,9 +610,438 @@ func TestGroups_Delete(t *testing.T) {
 
 		defer srv.Close()
 
-		err := client.Group.Delete(context.TODO(), "valid-group-id")
+		err := client.Group.Delete(context.TODO(), "valid-group-id", "")
 
 		assert.Error(t, err)
 		assert.Equal(t, "SCIM error 400 \ndelete failed", err.Error())
 	})
+	t.Run("validate the API request with version", func(t *testing.T) {
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, func(w http.ResponseWriter, r *http.Request) {
+			assertCall[groups.Group](t, r, fmt.Sprintf("%s%s", groupsPath, "valid-group-id"), "DELETE", nil)
+			assert.Equal(t, `W/"1"`, r.Header.Get("If-Match"))
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		err := client.Group.Delete(context.TODO(), "valid-group-id", `W/"1"`)
+
+		assert.NoError(t, err)
+	})
+}
+
+// groupsTestTenant manages a single group like a SCIM server, which rejects requests on more members than its limit
+// the PATCH request with the index failAt fails, the PATCH requests received are recorded in the order in which they are received
+type groupsTestTenant struct {
+	t       *testing.T
+	group   groups.Group
+	version int
+	limit   int
+	failAt  int
+
+	patches []groups.PatchRequestBody
+	ifMatch []string
+}
+
+func newGroupsTestTenant(t *testing.T, members int, limit int) *groupsTestTenant {
+
+	tenant := &groupsTestTenant{
+		t:      t,
+		group:  groups.Group{Id: "valid-group-id", DisplayName: "Test Group"},
+		limit:  limit,
+		failAt: -1,
+	}
+	tenant.group.GroupMembers = syntheticMembers("existing", members)
+
+	return tenant
+}
+
+func syntheticMembers(prefix string, count int) []groups.GroupMember {
+	members := make([]groups.GroupMember, count)
+	for i := range members {
+		members[i] = groups.GroupMember{Value: fmt.Sprintf("%s-member-%d", prefix, i), Type: "User"}
+	}
+	return members
+}
+
+func (tenant *groupsTestTenant) handler(w http.ResponseWriter, r *http.Request) {
+
+	switch r.Method {
+	case "POST":
+		var group groups.Group
+		assert.NoError(tenant.t, json.NewDecoder(r.Body).Decode(&group))
+
+		if len(group.GroupMembers) > tenant.limit {
+			tenant.writeError(w, http.StatusRequestEntityTooLarge, "too many members")
+			return
+		}
+
+		group.Id = tenant.group.Id
+		tenant.group = group
+		w.WriteHeader(http.StatusCreated)
+
+	case "PATCH":
+		var req groups.PatchRequestBody
+		assert.NoError(tenant.t, json.NewDecoder(r.Body).Decode(&req))
+
+		tenant.patches = append(tenant.patches, req)
+		tenant.ifMatch = append(tenant.ifMatch, r.Header.Get("If-Match"))
+
+		if tenant.countMembers(req.Operations) > tenant.limit {
+			tenant.writeError(w, http.StatusRequestEntityTooLarge, "too many members")
+			return
+		}
+
+		if len(tenant.patches)-1 == tenant.failAt {
+			tenant.writeError(w, http.StatusInternalServerError, "patch failed")
+			return
+		}
+
+		for _, operation := range req.Operations {
+			tenant.apply(operation)
+		}
+	}
+
+	tenant.version++
+	w.Header().Set(ETagHeader, fmt.Sprintf(`W/"%d"`, tenant.version))
+
+	res, _ := json.Marshal(tenant.group)
+	_, err := w.Write(res)
+	assert.NoError(tenant.t, err, "Failed to write response")
+}
+
+func (tenant *groupsTestTenant) countMembers(operations []generic.PatchRequest) int {
+	count := 0
+	for _, operation := range operations {
+		if members, ok := operation.Value.([]any); ok && operation.Path == "members" {
+			count += len(members)
+		} else {
+			count++
+		}
+	}
+	return count
+}
+
+func (tenant *groupsTestTenant) apply(operation generic.PatchRequest) {
+
+	var members []groups.GroupMember
+	if operation.Value != nil {
+		value, _ := json.Marshal(operation.Value)
+		_ = json.Unmarshal(value, &members)
+	}
+
+	switch match := memberFilter.FindStringSubmatch(operation.Path); {
+	case operation.Op == "remove" && match != nil:
+		tenant.group.GroupMembers = slices.DeleteFunc(tenant.group.GroupMembers, func(member groups.GroupMember) bool {
+			return member.Value == match[1]
+		})
+	case operation.Op == "add" && operation.Path == "members":
+		tenant.group.GroupMembers = append(tenant.group.GroupMembers, members...)
+	case operation.Op == "replace" && operation.Path == "members":
+		tenant.group.GroupMembers = members
+	}
+}
+
+func (tenant *groupsTestTenant) writeError(w http.ResponseWriter, status int, detail string) {
+	res, _ := json.Marshal(ScimResponseError{
+		Detail: detail,
+		Status: fmt.Sprintf("%d", status),
+	})
+	w.WriteHeader(status)
+	_, err := w.Write(res)
+	assert.NoError(tenant.t, err, "Failed to write response")
+}
+
+func (tenant *groupsTestTenant) memberCounts() []int {
+	counts := []int{}
+	for _, patch := range tenant.patches {
+		counts = append(counts, tenant.countMembers(patch.Operations))
+	}
+	return counts
+}
+
+func TestGroups_MembersInChunks(t *testing.T) {
+
+	t.Run("members are added in chunks", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 0, 250)
+
+		client, srv := testClient(tenant.handler)
+		defer srv.Close()
+
+		client.Group.MembersChunkSize = 250
+
+		res, _, err := client.Group.AddMembers(context.TODO(), "valid-group-id", syntheticMembers("new", 3000))
+
+		assert.NoError(t, err)
+		assert.Equal(t, slices.Repeat([]int{250}, 12), tenant.memberCounts())
+		assert.Equal(t, syntheticMembers("new", 3000), res.GroupMembers)
+	})
+
+	t.Run("members are added in chunks of the default size", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 0, DefaultMembersChunkSize)
+
+		client, srv := testClient(tenant.handler)
+		defer srv.Close()
+
+		res, _, err := client.Group.AddMembers(context.TODO(), "valid-group-id", syntheticMembers("new", 2050))
+
+		assert.NoError(t, err)
+		assert.Equal(t, append(slices.Repeat([]int{100}, 20), 50), tenant.memberCounts())
+		assert.Len(t, res.GroupMembers, 2050)
+	})
+
+	t.Run("members are removed in chunks", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 3000, 250)
+
+		client, srv := testClient(tenant.handler)
+		defer srv.Close()
+
+		client.Group.MembersChunkSize = 250
+
+		remove := []string{}
+		for _, member := range tenant.group.GroupMembers[:2000] {
+			remove = append(remove, member.Value)
+		}
+
+		res, _, err := client.Group.RemoveMembers(context.TODO(), "valid-group-id", remove)
+
+		assert.NoError(t, err)
+		assert.Equal(t, slices.Repeat([]int{250}, 8), tenant.memberCounts())
+		assert.Equal(t, syntheticMembers("existing", 3000)[2000:], res.GroupMembers)
+	})
+
+	t.Run("members are removed in chunks with filters not supported", func(t *testing.T) {
+
+		config := serviceProviderConfigBody
+		config.Filter = discovery.Filter{Supported: false}
+
+		tenant := newGroupsTestTenant(t, 3000, 250)
+
+		client, srv := testClient(discoveryTestHandler(t, config, tenant.handler))
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+		client.Group.MembersChunkSize = 250
+
+		remove := []string{}
+		for _, member := range tenant.group.GroupMembers[:2000] {
+			remove = append(remove, member.Value)
+		}
+
+		res, _, err := client.Group.RemoveMembers(context.TODO(), "valid-group-id", remove)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{250, 250, 250, 250}, tenant.memberCounts())
+		assert.Equal(t, "replace", tenant.patches[0].Operations[0].Op)
+		assert.Equal(t, "add", tenant.patches[1].Operations[0].Op)
+		assert.Equal(t, syntheticMembers("existing", 3000)[2000:], res.GroupMembers)
+	})
+
+	t.Run("the tenant rejects chunks above its limit", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 0, 250)
+
+		client, srv := testClient(tenant.handler)
+		defer srv.Close()
+
+		client.Group.MembersChunkSize = 500
+
+		res, _, err := client.Group.AddMembers(context.TODO(), "valid-group-id", syntheticMembers("new", 3000))
+
+		assert.Zero(t, res)
+		assert.Equal(t, "SCIM error 413 \ntoo many members", err.Error())
+		assert.False(t, errors.As(err, &GroupPartiallyUpdatedError{}))
+		assert.Empty(t, tenant.group.GroupMembers)
+	})
+
+	t.Run("the group is read again after a partial failure", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 0, 250)
+		tenant.failAt = 4
+
+		client, srv := testClient(tenant.handler)
+		defer srv.Close()
+
+		client.Group.MembersChunkSize = 250
+
+		res, _, err := client.Group.AddMembers(context.TODO(), "valid-group-id", syntheticMembers("new", 3000))
+
+		var partialErr GroupPartiallyUpdatedError
+		assert.True(t, errors.As(err, &partialErr))
+		assert.Equal(t, 4, partialErr.Applied)
+		assert.Equal(t, 12, partialErr.Total)
+		assert.Equal(t, "the group has only been partially updated, 4 of 12 requests were applied : SCIM error 500 \npatch failed", err.Error())
+
+		assert.Len(t, tenant.patches, 5)
+		assert.Equal(t, syntheticMembers("new", 3000)[:1000], res.GroupMembers)
+	})
+
+	t.Run("the version of the previous chunk is sent with every chunk", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 0, 250)
+		tenant.version = 1
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, tenant.handler))
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+		client.Group.MembersChunkSize = 250
+
+		_, _, err := client.Group.Update(context.TODO(), []generic.PatchRequest{
+			{
+				Op:    "add",
+				Path:  "members",
+				Value: syntheticMembers("new", 1000),
+			},
+		}, "valid-group-id", "", `W/"1"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`W/"1"`, `W/"2"`, `W/"3"`, `W/"4"`}, tenant.ifMatch)
+	})
+
+	t.Run("the group is created with members in chunks", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 0, 250)
+
+		client, srv := testClient(tenant.handler)
+		defer srv.Close()
+
+		client.Group.MembersChunkSize = 250
+
+		res, _, err := client.Group.Create(context.TODO(), "", &groups.Group{
+			DisplayName:  "Test Group",
+			GroupMembers: syntheticMembers("new", 3000),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, slices.Repeat([]int{250}, 11), tenant.memberCounts())
+		assert.Equal(t, syntheticMembers("new", 3000), res.GroupMembers)
+	})
+
+	t.Run("the created group is returned after a partial failure", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 0, 250)
+		tenant.failAt = 2
+
+		client, srv := testClient(tenant.handler)
+		defer srv.Close()
+
+		client.Group.MembersChunkSize = 250
+
+		res, _, err := client.Group.Create(context.TODO(), "", &groups.Group{
+			DisplayName:  "Test Group",
+			GroupMembers: syntheticMembers("new", 3000),
+		})
+
+		var partialErr GroupPartiallyUpdatedError
+		assert.True(t, errors.As(err, &partialErr))
+		assert.Equal(t, 3, partialErr.Applied)
+		assert.Equal(t, 12, partialErr.Total)
+
+		assert.Equal(t, "valid-group-id", res.Id)
+		assert.Equal(t, syntheticMembers("new", 3000)[:750], res.GroupMembers)
+	})
+
+	t.Run("the created group is returned if the first chunk of additions fails", func(t *testing.T) {
+
+		tenant := newGroupsTestTenant(t, 0, 250)
+		tenant.failAt = 0
+
+		client, srv := testClient(tenant.handler)
+		defer srv.Close()
+
+		client.Group.MembersChunkSize = 250
+
+		res, _, err := client.Group.Create(context.TODO(), "", &groups.Group{
+			DisplayName:  "Test Group",
+			GroupMembers: syntheticMembers("new", 600),
+		})
+
+		var partialErr GroupPartiallyUpdatedError
+		assert.True(t, errors.As(err, &partialErr))
+		assert.Equal(t, 1, partialErr.Applied)
+		assert.Equal(t, 3, partialErr.Total)
+
+		assert.Equal(t, "valid-group-id", res.Id)
+		assert.Equal(t, syntheticMembers("new", 600)[:250], res.GroupMembers)
+	})
+}
+
+func Test_ChunkMemberOperations(t *testing.T) {
+
+	members := func(values ...string) []any {
+		res := []any{}
+		for _, value := range values {
+			res = append(res, groups.GroupMember{Value: value})
+		}
+		return res
+	}
+
+	displayName := generic.PatchRequest{Op: "replace", Path: "displayName", Value: "Test Group"}
+	removeMember := func(value string) generic.PatchRequest {
+		return generic.PatchRequest{Op: "remove", Path: fmt.Sprintf(`members[value eq "%s"]`, value)}
+	}
+
+	tests := []struct {
+		name     string
+		args     []generic.PatchRequest
+		expected [][]generic.PatchRequest
+	}{
+		{
+			name:     "no operations",
+			args:     []generic.PatchRequest{},
+			expected: [][]generic.PatchRequest{},
+		},
+		{
+			name: "operations within the chunk size",
+			args: []generic.PatchRequest{displayName, {Op: "add", Path: "members", Value: members("1")}},
+			expected: [][]generic.PatchRequest{
+				{displayName, {Op: "add", Path: "members", Value: members("1")}},
+			},
+		},
+		{
+			name: "additions are split",
+			args: []generic.PatchRequest{displayName, {Op: "add", Path: "members", Value: members("1", "2", "3", "4", "5")}},
+			expected: [][]generic.PatchRequest{
+				{displayName, {Op: "add", Path: "members", Value: members("1")}},
+				{{Op: "add", Path: "members", Value: members("2", "3")}},
+				{{Op: "add", Path: "members", Value: members("4", "5")}},
+			},
+		},
+		{
+			name: "a replacement is continued by additions",
+			args: []generic.PatchRequest{{Op: "replace", Path: "members", Value: members("1", "2", "3")}},
+			expected: [][]generic.PatchRequest{
+				{{Op: "replace", Path: "members", Value: members("1", "2")}},
+				{{Op: "add", Path: "members", Value: members("3")}},
+			},
+		},
+		{
+			name: "removals of single members are split",
+			args: []generic.PatchRequest{removeMember("1"), removeMember("2"), removeMember("3")},
+			expected: [][]generic.PatchRequest{
+				{removeMember("1"), removeMember("2")},
+				{removeMember("3")},
+			},
+		},
+		{
+			name: "a replacement by no members counts as a single member",
+			args: []generic.PatchRequest{{Op: "replace", Path: "members", Value: []groups.GroupMember{}}, displayName, displayName},
+			expected: [][]generic.PatchRequest{
+				{{Op: "replace", Path: "members", Value: []groups.GroupMember{}}, displayName},
+				{displayName},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, chunkMemberOperations(test.args, 2))
+		})
+	}
 }
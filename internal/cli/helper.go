@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
@@ -111,59 +112,67 @@ func compare(cS map[string]any, rB map[string]any) (bool, error) {
 func compareAttributes(key string, csValue map[string]any, rbValue map[string]any) (bool, string) {
 	for ckey, cval := range csValue {
 
+		attributePath := key + "." + ckey
+
 		rval, ok := rbValue[ckey]
 
 		if !ok {
-			err := fmt.Sprintf("mismatch between response and request for attribute %s.%s, attribute not found in response", key, ckey)
+			err := fmt.Sprintf("mismatch between response and request for attribute %s, attribute not found in response", attributePath)
 			return false, err
 		}
 
-		var result bool
-		var err string
+		if result, err := compareAttributeValue(attributePath, cval, rval); !result {
+			return false, err
+		}
+	}
 
-		// parse through each attribute and retrieve the respective value according to the data type of the attribute
-		switch rval.(type) {
+	return true, ""
+}
 
-		case string:
-			rRes := rval.(string)
-			cRes := cval.(string)
-			if result = (rRes == cRes); !result {
-				err = fmt.Sprintf("mismatch between response and request in attribute %s.%s, request sent: \"%s\" but response received: \"%s\"", key, ckey, cRes, rRes)
-				break
-			}
+// compareAttributeValue compares the value of an attribute according to the data type of the response
+func compareAttributeValue(attributePath string, cval any, rval any) (bool, string) {
 
-		case float64:
-			rRes := rval.(float64)
-			cRes := cval.(float64)
-			if result = (rRes == cRes); !result {
-				err = fmt.Sprintf("mismatch between response and request in attribute %s.%s, request sent: \"%.2f\" but response received: \"%.2f\"", key, ckey, cRes, rRes)
-				break
-			}
+	if reflect.TypeOf(cval) != reflect.TypeOf(rval) {
+		return false, fmt.Sprintf("mismatch between response and request in attribute %s, request sent a value of type %T but response received a value of type %T", attributePath, cval, rval)
+	}
 
-		case bool:
-			rRes := rval.(bool)
-			cRes := cval.(bool)
-			if result = (rRes == cRes); !result {
-				err = fmt.Sprintf("mismatch between response and request in attribute %s.%s, request sent: \"%t\" but response received: \"%t\"", key, ckey, cRes, rRes)
-				break
-			}
+	switch rRes := rval.(type) {
 
-		// for nested structures, call the function recursively
-		// API allows only one level of nesting
-		case map[string]any:
-			rRes := rval.(map[string]any)
-			cRes := cval.(map[string]any)
-			result, err = compareAttributes(ckey, cRes, rRes)
-			if !result {
-				err = err[:51] + key + "." + err[51:]
-				break
-			}
+	case string:
+		if cRes := cval.(string); rRes != cRes {
+			return false, fmt.Sprintf("mismatch between response and request in attribute %s, request sent: \"%s\" but response received: \"%s\"", attributePath, cRes, rRes)
+		}
 
-			// TODO handling reference datatcRespe
+	case float64:
+		if cRes := cval.(float64); rRes != cRes {
+			return false, fmt.Sprintf("mismatch between response and request in attribute %s, request sent: \"%.2f\" but response received: \"%.2f\"", attributePath, cRes, rRes)
 		}
 
-		if !result {
-			return false, err
+	case bool:
+		if cRes := cval.(bool); rRes != cRes {
+			return false, fmt.Sprintf("mismatch between response and request in attribute %s, request sent: \"%t\" but response received: \"%t\"", attributePath, cRes, rRes)
+		}
+
+	// the sub-attributes of complex attributes, the API allows only one level of nesting
+	case map[string]any:
+		return compareAttributes(attributePath, cval.(map[string]any), rRes)
+
+	// multivalued attributes, the values are returned in any order
+	case []any:
+		cRes := cval.([]any)
+		if len(cRes) != len(rRes) {
+			return false, fmt.Sprintf("mismatch between response and request in attribute %s, request sent %d values but response received %d values", attributePath, len(cRes), len(rRes))
+		}
+
+		for _, cItem := range cRes {
+			found := slices.ContainsFunc(rRes, func(rItem any) bool {
+				result, _ := compareAttributeValue(attributePath, cItem, rItem)
+				return result
+			})
+
+			if !found {
+				return false, fmt.Sprintf("mismatch between response and request in attribute %s, value \"%v\" not found in response", attributePath, cItem)
+			}
 		}
 	}
 
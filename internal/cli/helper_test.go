@@ -300,6 +300,71 @@ func Test_CompareAttributes(t *testing.T) {
 			},
 			errMessage: "mismatch between response and request in attribute schema_id.schema_attr_1.schema_attr_1a, request sent: \"new_test\" but response received: \"test\"",
 		},
+		{
+			description: "error path - nested attribute not found in response",
+			key:         "schema_id",
+			resMap: map[string]any{
+				"schema_attr_1": map[string]any{
+					"schema_attr_1a": "test",
+				},
+			},
+			customSchemasMap: map[string]any{
+				"schema_attr_1": map[string]any{
+					"schema_attr_1a": "test",
+					"schema_attr_1b": "test",
+				},
+			},
+			errMessage: "mismatch between response and request for attribute schema_id.schema_attr_1.schema_attr_1b, attribute not found in response",
+		},
+		{
+			description: "happy path - multivalued attributes returned in a different order",
+			key:         "schema_id",
+			resMap: map[string]any{
+				"schema_attr_1": []any{"b", "a"},
+				"schema_attr_2": []any{
+					map[string]any{
+						"schema_attr_2a": "second",
+					},
+					map[string]any{
+						"schema_attr_2a": "first",
+					},
+				},
+			},
+			customSchemasMap: map[string]any{
+				"schema_attr_1": []any{"a", "b"},
+				"schema_attr_2": []any{
+					map[string]any{
+						"schema_attr_2a": "first",
+					},
+					map[string]any{
+						"schema_attr_2a": "second",
+					},
+				},
+			},
+			errMessage: "",
+		},
+		{
+			description: "error path - mismatch in multivalued attribute",
+			key:         "schema_id",
+			resMap: map[string]any{
+				"schema_attr_1": []any{"a", "c"},
+			},
+			customSchemasMap: map[string]any{
+				"schema_attr_1": []any{"a", "b"},
+			},
+			errMessage: "mismatch between response and request in attribute schema_id.schema_attr_1, value \"b\" not found in response",
+		},
+		{
+			description: "error path - mismatch in data type",
+			key:         "schema_id",
+			resMap: map[string]any{
+				"schema_attr_1": "true",
+			},
+			customSchemasMap: map[string]any{
+				"schema_attr_1": true,
+			},
+			errMessage: "mismatch between response and request in attribute schema_id.schema_attr_1, request sent a value of type bool but response received a value of type string",
+		},
 	}
 
 	for _, test := range tests {
@@ -1,5 +1,10 @@
 package cli
 
+import "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/discovery"
+
+// DefaultCertificateExpiryWarningDays is the number of days before their expiry from which certificates are warned about, if not configured otherwise
+const DefaultCertificateExpiryWarningDays = 30
+
 func NewSciClient(cliClient *Client) *SciClient {
 	return &SciClient{
 		Client:            cliClient,
@@ -9,6 +14,9 @@ func NewSciClient(cliClient *Client) *SciClient {
 		Schema:            NewSchemaCli(cliClient),
 		Group:             NewGroupCli(cliClient),
 		CorporateIdP:      NewCorporateIdPCli(cliClient),
+		Bulk:              NewBulkCli(cliClient),
+		Discovery:         NewDiscoveryCli(cliClient),
+		Tenant:            NewTenantCli(cliClient),
 	}
 }
 
@@ -20,4 +28,14 @@ type SciClient struct {
 	Schema            SchemasCli
 	Group             GroupsCli
 	CorporateIdP      CorporateIdPsCli
+	Bulk              BulkCli
+	Discovery         DiscoveryCli
+	Tenant            TenantCli
+
+	// CertificateExpiryWarningDays is the number of days before their expiry from which planned certificates are warned about, 0 disables the warnings
+	CertificateExpiryWarningDays int
+
+	// capabilities of the tenant, set by Discover
+	ServiceProviderConfig *discovery.ServiceProviderConfig
+	ResourceTypes         []discovery.ResourceType
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/schemas"
 )
 
@@ -51,6 +52,52 @@ func (s *SchemasCli) Create(ctx context.Context, args *schemas.Schema) (schemas.
 	return unMarshalResponse[schemas.Schema](res, false)
 }
 
+// Update replaces the name, description and attributes of the schema
+// the changes are sent with PATCH if the tenant supports it, otherwise or if the endpoint rejects the method, the schema is replaced with PUT
+func (s *SchemasCli) Update(ctx context.Context, args *schemas.Schema) (schemas.Schema, string, error) {
+
+	url := fmt.Sprintf("%s%s", s.getUrl(), args.Id)
+
+	if s.cliClient.patchSupported(ctx) {
+		reqBody := schemas.PatchRequestBody{
+			Schemas: []string{ScimUpdateSchemas},
+			Operations: []generic.PatchRequest{
+				{
+					Op:    "replace",
+					Path:  "name",
+					Value: args.Name,
+				},
+				{
+					Op:    "replace",
+					Path:  "description",
+					Value: args.Description,
+				},
+				{
+					Op:    "replace",
+					Path:  "attributes",
+					Value: args.Attributes,
+				},
+			},
+		}
+
+		_, _, err := s.cliClient.Execute(ctx, "PATCH", url, nil, reqBody, "", ScimRequestHeader, nil)
+		if err == nil {
+			return s.GetBySchemaId(ctx, args.Id)
+		}
+
+		if !isMethodNotSupported(err) {
+			return schemas.Schema{}, "", err
+		}
+	}
+
+	res, _, err := s.cliClient.Execute(ctx, "PUT", url, nil, args, "", ScimRequestHeader, nil)
+	if err != nil {
+		return schemas.Schema{}, "", err
+	}
+
+	return unMarshalResponse[schemas.Schema](res, false)
+}
+
 func (s *SchemasCli) Delete(ctx context.Context, schemaId string) error {
 
 	_, _, err := s.cliClient.Execute(ctx, "DELETE", fmt.Sprintf("%s%s", s.getUrl(), schemaId), nil, nil, "", ScimRequestHeader, nil)
@@ -177,6 +177,119 @@ func TestSchemas_GetBySchemaId(t *testing.T) {
 	})
 }
 
+func TestSchemas_Update(t *testing.T) {
+
+	schemasResponse, _ = json.Marshal(schemasBody)
+	schemaPath := fmt.Sprintf("%s%s", schemasPath, "valid-schema-id")
+
+	t.Run("validate the API request", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(schemasResponse)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[schemas.Schema](t, r, schemaPath, "PUT", schemasBody)
+		}))
+
+		defer srv.Close()
+
+		res, _, err := client.Schema.Update(context.TODO(), &schemasBody)
+
+		assert.NoError(t, err)
+		assert.Equal(t, schemasBody, res)
+	})
+
+	t.Run("validate the API request with patch supported", func(t *testing.T) {
+
+		methods := []string{}
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			assert.Equal(t, schemaPath, r.URL.Path)
+
+			if r.Method == "PATCH" {
+				var body schemas.PatchRequestBody
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, []string{ScimUpdateSchemas}, body.Schemas)
+				assert.Len(t, body.Operations, 3)
+				assert.Equal(t, "attributes", body.Operations[2].Path)
+			}
+
+			_, err := w.Write(schemasResponse)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		res, _, err := client.Schema.Update(context.TODO(), &schemasBody)
+
+		assert.NoError(t, err)
+		assert.Equal(t, schemasBody, res)
+		assert.Equal(t, []string{"PATCH", "GET"}, methods)
+	})
+
+	t.Run("validate the API request with patch rejected by the endpoint", func(t *testing.T) {
+
+		methods := []string{}
+
+		resErr, _ := json.Marshal(ScimResponseError{
+			Detail: "method not allowed",
+			Status: "405",
+		})
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+
+			if r.Method == "PATCH" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				_, err := w.Write(resErr)
+				assert.NoError(t, err, "Failed to write response")
+				return
+			}
+
+			_, err := w.Write(schemasResponse)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[schemas.Schema](t, r, schemaPath, "PUT", schemasBody)
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		_, _, err := client.Schema.Update(context.TODO(), &schemasBody)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"PATCH", "PUT"}, methods)
+	})
+
+	t.Run("validate the API request - error", func(t *testing.T) {
+
+		resErr, _ := json.Marshal(ScimResponseError{
+			Detail: "update failed",
+			Status: "400",
+		})
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write(resErr)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[schemas.Schema](t, r, schemaPath, "PUT", schemasBody)
+		}))
+
+		defer srv.Close()
+
+		res, _, err := client.Schema.Update(context.TODO(), &schemasBody)
+
+		assert.Zero(t, res)
+		assert.Error(t, err)
+		assert.Equal(t, "SCIM error 400 \nupdate failed", err.Error())
+	})
+}
+
 func TestSchemas_Delete(t *testing.T) {
 
 	t.Run("validate the API request", func(t *testing.T) {
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/tenant"
+)
+
+type TenantCli struct {
+	cliClient *Client
+}
+
+func NewTenantCli(cliClient *Client) TenantCli {
+	return TenantCli{cliClient: cliClient}
+}
+
+// GetSamlMetadata reads the SAML metadata of the tenant acting as identity provider
+func (t *TenantCli) GetSamlMetadata(ctx context.Context) (string, error) {
+
+	metadata, err := t.getDocument(ctx, "saml2/metadata")
+	if err != nil {
+		return "", err
+	}
+
+	return string(metadata), nil
+}
+
+// GetOidcConfiguration reads the OpenID Provider Metadata of the tenant and the JSON Web Key Set it references
+// the JSON Web Key Set is returned compacted, it is only read from the tenant, as the request is sent with the credentials of the tenant
+func (t *TenantCli) GetOidcConfiguration(ctx context.Context) (tenant.OIDCConfiguration, error) {
+
+	res, _, err := t.cliClient.Execute(ctx, "GET", ".well-known/openid-configuration", nil, nil, "", RequestHeader, nil)
+	if err != nil {
+		return tenant.OIDCConfiguration{}, err
+	}
+
+	config, _, err := unMarshalResponse[tenant.OIDCConfiguration](res, false)
+	if err != nil {
+		return tenant.OIDCConfiguration{}, err
+	}
+
+	if len(config.JwksUri) > 0 {
+		jwksUri, err := url.Parse(config.JwksUri)
+		if err != nil {
+			return tenant.OIDCConfiguration{}, fmt.Errorf("the jwks_uri %s is invalid : %s", config.JwksUri, err)
+		}
+
+		if jwksUri.IsAbs() && (jwksUri.Scheme != t.cliClient.ServerURL.Scheme || jwksUri.Host != t.cliClient.ServerURL.Host) {
+			return tenant.OIDCConfiguration{}, fmt.Errorf("the jwks_uri %s is not an endpoint of the tenant %s", config.JwksUri, t.cliClient.ServerURL.Host)
+		}
+
+		jwks, err := t.getDocument(ctx, config.JwksUri)
+		if err != nil {
+			return tenant.OIDCConfiguration{}, err
+		}
+
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, jwks); err != nil {
+			return tenant.OIDCConfiguration{}, fmt.Errorf("the JSON Web Key Set of %s is invalid : %s", config.JwksUri, err)
+		}
+		config.Jwks = compacted.String()
+	}
+
+	return config, nil
+}
+
+// getDocument reads a document of the tenant which is not returned as JSON object, such as the SAML metadata
+func (t *TenantCli) getDocument(ctx context.Context, endpoint string) ([]byte, error) {
+
+	res, err := t.cliClient.DoRequest(ctx, "GET", endpoint, nil, nil, "", RequestHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error %d \n%s", res.StatusCode, string(body))
+	}
+
+	return body, nil
+}
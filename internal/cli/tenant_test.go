@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/tenant"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var samlMetadataPath = "/saml2/metadata"
+var oidcConfigurationPath = "/.well-known/openid-configuration"
+var jwksPath = "/oauth2/certs"
+
+var tenantSamlMetadata = `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://tenant.accounts.ondemand.com"/>`
+
+func TestTenant_GetSamlMetadata(t *testing.T) {
+
+	t.Run("validate the API request", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(tenantSamlMetadata))
+			assert.NoError(t, err, "Failed to write response")
+
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assertCall[any](t, r, samlMetadataPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		client.AuthorizationToken = "Bearer token"
+		res, err := client.Tenant.GetSamlMetadata(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, tenantSamlMetadata, res)
+	})
+
+	t.Run("validate the API request with error", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte("not found"))
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[any](t, r, samlMetadataPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		_, err := client.Tenant.GetSamlMetadata(context.TODO())
+
+		assert.Error(t, err)
+		assert.Equal(t, "error 404 \nnot found", err.Error())
+	})
+}
+
+func TestTenant_GetOidcConfiguration(t *testing.T) {
+
+	configurationBody := func(issuer string) tenant.OIDCConfiguration {
+		return tenant.OIDCConfiguration{
+			Issuer:                            issuer,
+			AuthorizationEndpoint:             issuer + "/oauth2/authorize",
+			TokenEndpoint:                     issuer + "/oauth2/token",
+			UserInfoEndpoint:                  issuer + "/oauth2/userinfo",
+			EndSessionEndpoint:                issuer + "/oauth2/logout",
+			JwksUri:                           issuer + jwksPath,
+			ScopesSupported:                   []string{"openid", "email", "profile"},
+			ResponseTypesSupported:            []string{"code", "id_token"},
+			GrantTypesSupported:               []string{"authorization_code", "client_credentials"},
+			SubjectTypesSupported:             []string{"public"},
+			IdTokenSigningAlgValuesSupported:  []string{"RS256"},
+			TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "private_key_jwt"},
+			ClaimsSupported:                   []string{"sub", "email"},
+		}
+	}
+
+	t.Run("validate the API request", func(t *testing.T) {
+
+		var issuer string
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			var res []byte
+			switch r.URL.Path {
+			case oidcConfigurationPath:
+				res, _ = json.Marshal(configurationBody(issuer))
+			case jwksPath:
+				res = []byte(`{ "keys": [ { "kty": "RSA", "kid": "key-1" } ] }`)
+			default:
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			}
+
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assert.Equal(t, "GET", r.Method)
+		}))
+
+		defer srv.Close()
+
+		issuer = srv.URL
+		client.AuthorizationToken = "Bearer token"
+		res, err := client.Tenant.GetOidcConfiguration(context.TODO())
+
+		expected := configurationBody(issuer)
+		expected.Jwks = `{"keys":[{"kty":"RSA","kid":"key-1"}]}`
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("validate the API request with invalid JSON Web Key Set", func(t *testing.T) {
+
+		var issuer string
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			var res []byte
+			switch r.URL.Path {
+			case oidcConfigurationPath:
+				res, _ = json.Marshal(configurationBody(issuer))
+			case jwksPath:
+				res = []byte(`keys`)
+			}
+
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		issuer = srv.URL
+		_, err := client.Tenant.GetOidcConfiguration(context.TODO())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "the JSON Web Key Set of "+issuer+jwksPath+" is invalid")
+	})
+
+	t.Run("validate the API request with a JSON Web Key Set of another host", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			// the credentials of the tenant must not be sent to the other host
+			if r.URL.Path != oidcConfigurationPath {
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			}
+
+			res, _ := json.Marshal(configurationBody("https://attacker.example.com"))
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		client.AuthorizationToken = "Bearer token"
+		_, err := client.Tenant.GetOidcConfiguration(context.TODO())
+
+		assert.Error(t, err)
+		assert.Equal(t, "the jwks_uri https://attacker.example.com/oauth2/certs is not an endpoint of the tenant "+client.ServerURL.Host, err.Error())
+	})
+
+	t.Run("validate the API request with error", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, err := w.Write([]byte("unauthorized"))
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[any](t, r, oidcConfigurationPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		_, err := client.Tenant.GetOidcConfiguration(context.TODO())
+
+		assert.Error(t, err)
+		assert.Equal(t, "error 401 \nunauthorized", err.Error())
+	})
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"strconv"
 
 	"fmt"
 
@@ -9,6 +10,13 @@ import (
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
 )
 
+const SapUserExtensionSchema = "urn:ietf:params:scim:schemas:extension:sap:2.0:User"
+
+const (
+	PasswordStatusEnabled = "enabled"
+	PasswordStatusInitial = "initial"
+)
+
 type UsersCli struct {
 	cliClient *Client
 }
@@ -48,9 +56,81 @@ func (u *UsersCli) Get(ctx context.Context) (users.UsersResponse, map[int]string
 	return usersList, customSchemas, err
 }
 
+// List retrieves all the users matching the SCIM filter
+// the users are read page by page, with at most pageSize users per request
+// cursor based pagination is used instead of index based pagination if required by the tenant
+func (u *UsersCli) List(ctx context.Context, filter string, pageSize int) (users.UsersResponse, map[int]string, error) {
+
+	usersList := users.UsersResponse{}
+	customSchemas := map[int]string{}
+
+	query := map[string]string{
+		"count": strconv.Itoa(u.cliClient.pageSize(ctx, pageSize)),
+	}
+
+	if len(filter) > 0 {
+		if !u.cliClient.filterSupported(ctx) {
+			return users.UsersResponse{}, map[int]string{}, fmt.Errorf("filters are not supported by the tenant")
+		}
+		query["filter"] = filter
+	}
+
+	cursorPaging := u.cliClient.cursorPaging(ctx)
+	if cursorPaging {
+		// an empty cursor requests the first page
+		query["cursor"] = ""
+	} else {
+		query["startIndex"] = "1"
+	}
+
+	for {
+		res, _, err := u.cliClient.Execute(ctx, "GET", u.getUrl(), query, nil, "", ScimRequestHeader, nil)
+		if err != nil {
+			return users.UsersResponse{}, map[int]string{}, err
+		}
+
+		resBody, _ := res.(map[string]any)
+
+		// the attribute Resources is omitted from the response if no users match the filter
+		resMap, _ := resBody["Resources"].([]any)
+
+		for _, r := range resMap {
+
+			var user users.User
+			user, customSchemas[len(usersList.Resources)], err = unMarshalResponse[users.User](r, true)
+			if err != nil {
+				return users.UsersResponse{}, map[int]string{}, err
+			}
+			usersList.Resources = append(usersList.Resources, user)
+		}
+
+		totalResults, _ := resBody["totalResults"].(float64)
+		usersList.TotalResult = int(totalResults)
+
+		if len(resMap) == 0 {
+			break
+		}
+
+		if cursorPaging {
+			nextCursor, _ := resBody["nextCursor"].(string)
+			if len(nextCursor) == 0 {
+				break
+			}
+			query["cursor"] = nextCursor
+		} else {
+			if len(usersList.Resources) >= usersList.TotalResult {
+				break
+			}
+			query["startIndex"] = strconv.Itoa(len(usersList.Resources) + 1)
+		}
+	}
+
+	return usersList, customSchemas, nil
+}
+
 func (u *UsersCli) GetByUserId(ctx context.Context, userId string, validateCustomSchemas bool, customSchemas string) (users.User, string, error) {
 
-	res, _, err := u.cliClient.Execute(ctx, "GET", fmt.Sprintf("%s%s", u.getUrl(), userId), nil, nil, "", ScimRequestHeader, nil)
+	res, headers, err := u.cliClient.Execute(ctx, "GET", fmt.Sprintf("%s%s", u.getUrl(), userId), nil, nil, "", ScimRequestHeader, []string{ETagHeader})
 
 	if err != nil {
 		return users.User{}, "", err
@@ -62,12 +142,15 @@ func (u *UsersCli) GetByUserId(ctx context.Context, userId string, validateCusto
 		}
 	}
 
-	return unMarshalResponse[users.User](res, true)
+	user, cS, err := unMarshalResponse[users.User](res, true)
+	user.Meta.Version = versionFrom(user.Meta, headers)
+
+	return user, cS, err
 }
 
 func (u *UsersCli) Create(ctx context.Context, customSchemas string, args *users.User) (users.User, string, error) {
 
-	res, _, err := u.cliClient.Execute(ctx, "POST", u.getUrl(), nil, args, customSchemas, ScimRequestHeader, nil)
+	res, headers, err := u.cliClient.Execute(ctx, "POST", u.getUrl(), nil, args, customSchemas, ScimRequestHeader, []string{ETagHeader})
 	if err != nil {
 		return users.User{}, "", err
 	}
@@ -78,17 +161,22 @@ func (u *UsersCli) Create(ctx context.Context, customSchemas string, args *users
 		}
 	}
 
-	return unMarshalResponse[users.User](res, false)
+	user, cS, err := unMarshalResponse[users.User](res, false)
+	user.Meta.Version = versionFrom(user.Meta, headers)
+
+	return user, cS, err
 }
 
-func (u *UsersCli) Update(ctx context.Context, id string, args []generic.PatchRequest, customSchemas string) (users.User, string, error) {
+// Update patches the user and reads the updated user
+// if the version of the user is given and the tenant supports ETags, the update fails if the user has been modified since that version
+func (u *UsersCli) Update(ctx context.Context, id string, args []generic.PatchRequest, customSchemas string, version string) (users.User, string, error) {
 
 	reqBody := users.PatchRequestBody{
 		Schemas:    []string{ScimUpdateSchemas},
 		Operations: args,
 	}
 
-	_, _, err := u.cliClient.Execute(ctx, "PATCH", fmt.Sprintf("%s%s", u.getUrl(), id), nil, reqBody, "", ScimRequestHeader, nil)
+	_, _, err := u.cliClient.executeWithHeaders(ctx, "PATCH", fmt.Sprintf("%s%s", u.getUrl(), id), nil, reqBody, "", ScimRequestHeader, u.cliClient.ifMatch(ctx, version), nil)
 
 	if err != nil {
 		return users.User{}, "", err
@@ -102,9 +190,51 @@ func (u *UsersCli) Update(ctx context.Context, id string, args []generic.PatchRe
 	return res, cS, nil
 }
 
-func (u *UsersCli) Delete(ctx context.Context, userId string) error {
+// SetPassword replaces the password of an existing user.
+// If forceChange is true, the password status is set to initial, so that the user must change the password at the next logon.
+func (u *UsersCli) SetPassword(ctx context.Context, userId string, password string, forceChange bool) error {
+
+	passwordStatus := PasswordStatusEnabled
+	if forceChange {
+		passwordStatus = PasswordStatusInitial
+	}
+
+	reqBody := users.PatchRequestBody{
+		Schemas: []string{ScimUpdateSchemas},
+		Operations: []generic.PatchRequest{
+			{
+				Op:    "replace",
+				Path:  "password",
+				Value: password,
+			},
+			{
+				Op:    "replace",
+				Path:  SapUserExtensionSchema + ":passwordDetails",
+				Value: users.PasswordDetails{Status: passwordStatus},
+			},
+		},
+	}
+
+	_, _, err := u.cliClient.Execute(ctx, "PATCH", fmt.Sprintf("%s%s", u.getUrl(), userId), nil, reqBody, "", ScimRequestHeader, nil)
+
+	return err
+}
+
+// Delete deletes the user
+// if the version of the user is given and the tenant supports ETags, the deletion fails if the user has been modified since that version
+func (u *UsersCli) Delete(ctx context.Context, userId string, version string) error {
 
-	_, _, err := u.cliClient.Execute(ctx, "DELETE", fmt.Sprintf("%s%s", u.getUrl(), userId), nil, nil, "", ScimRequestHeader, nil)
+	_, _, err := u.cliClient.executeWithHeaders(ctx, "DELETE", fmt.Sprintf("%s%s", u.getUrl(), userId), nil, nil, "", ScimRequestHeader, u.cliClient.ifMatch(ctx, version), nil)
 
 	return err
 }
+
+// versionFrom returns the version of a resource, which is carried by the ETag response header if the tenant supports ETags
+func versionFrom(meta users.Meta, headers map[string]string) string {
+
+	if etag := headers[ETagHeader]; len(etag) > 0 {
+		return etag
+	}
+
+	return meta.Version
+}
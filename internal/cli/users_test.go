@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/discovery"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
 
@@ -176,6 +177,145 @@ func TestUsers_Get(t *testing.T) {
 	})
 }
 
+func TestUsers_List(t *testing.T) {
+
+	t.Run("validate the API request with pagination", func(t *testing.T) {
+
+		requests := 0
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			assert.Equal(t, "userName sw \"user_\"", r.URL.Query().Get("filter"))
+			assert.Equal(t, "2", r.URL.Query().Get("count"))
+			assert.Equal(t, fmt.Sprintf("%d", 2*requests-1), r.URL.Query().Get("startIndex"))
+
+			page := []users.User{usersBody, usersBody}
+			if requests == 2 {
+				page = []users.User{usersBody}
+			}
+
+			res, _ := json.Marshal(users.UsersResponse{
+				Resources:   page,
+				TotalResult: 3,
+			})
+
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[users.User](t, r, usersPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		res, _, err := client.User.List(context.TODO(), "userName sw \"user_\"", 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, requests)
+		assert.Len(t, res.Resources, 3)
+	})
+
+	t.Run("validate the API request with cursor pagination", func(t *testing.T) {
+
+		config := serviceProviderConfigBody
+		config.Pagination = &discovery.Pagination{
+			Cursor:      true,
+			MaxPageSize: 1,
+		}
+
+		cursors := []string{}
+
+		client, srv := testClient(discoveryTestHandler(t, config, func(w http.ResponseWriter, r *http.Request) {
+			cursors = append(cursors, r.URL.Query().Get("cursor"))
+
+			assert.Equal(t, "1", r.URL.Query().Get("count"))
+			assert.False(t, r.URL.Query().Has("startIndex"))
+
+			res := users.UsersResponse{
+				Resources:   []users.User{usersBody},
+				TotalResult: 2,
+			}
+			if len(cursors) == 1 {
+				res.NextCursor = "next-page"
+			}
+
+			encoded, _ := json.Marshal(res)
+			_, err := w.Write(encoded)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[users.User](t, r, usersPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		res, _, err := client.User.List(context.TODO(), "", 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"", "next-page"}, cursors)
+		assert.Len(t, res.Resources, 2)
+	})
+
+	t.Run("validate the API request with filters not supported", func(t *testing.T) {
+
+		config := serviceProviderConfigBody
+		config.Filter = discovery.Filter{Supported: false}
+
+		client, srv := testClient(discoveryTestHandler(t, config, func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "no users must be requested")
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		_, _, err := client.User.List(context.TODO(), "userName sw \"user_\"", 100)
+
+		assert.EqualError(t, err, "filters are not supported by the tenant")
+	})
+
+	t.Run("validate the API request with no matching users", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"totalResults":0}`))
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[users.User](t, r, usersPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		res, _, err := client.User.List(context.TODO(), "", 100)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Resources)
+	})
+
+	t.Run("validate the API request with error", func(t *testing.T) {
+
+		resErr, _ := json.Marshal(ScimResponseError{
+			Detail: "invalid filter",
+			Status: "400",
+		})
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write(resErr)
+			assert.NoError(t, err, "Failed to write response")
+
+			assertCall[users.User](t, r, usersPath, "GET", nil)
+		}))
+
+		defer srv.Close()
+
+		_, _, err := client.User.List(context.TODO(), "invalid", 100)
+
+		assert.Error(t, err)
+		assert.Equal(t, "SCIM error 400 \ninvalid filter", err.Error())
+	})
+}
+
 func TestUsers_GetByUserId(t *testing.T) {
 
 	usersResponse, _ = json.Marshal(usersBody)
@@ -219,6 +359,36 @@ func TestUsers_GetByUserId(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "SCIM error 400 \nget failed", err.Error())
 	})
+	t.Run("validate the version is read from the ETag header", func(t *testing.T) {
+
+		user := usersBody
+		user.Meta = users.Meta{Version: "1"}
+		userResponse, _ := json.Marshal(user)
+
+		etag := `W/"2"`
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(etag) > 0 {
+				w.Header().Set(ETagHeader, etag)
+			}
+			_, err := w.Write(userResponse)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		res, _, err := client.User.GetByUserId(context.TODO(), "valid-user-id", false, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, `W/"2"`, res.Meta.Version)
+
+		// the version of the response body is used if the ETag header is missing
+		etag = ""
+		res, _, err = client.User.GetByUserId(context.TODO(), "valid-user-id", false, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1", res.Meta.Version)
+	})
 }
 
 func TestUsers_Update(t *testing.T) {
@@ -325,7 +495,7 @@ func TestUsers_Update(t *testing.T) {
 
 		defer srv.Close()
 
-		_, _, err := client.User.Update(context.TODO(), "valid-user-id", patchRequests, "")
+		_, _, err := client.User.Update(context.TODO(), "valid-user-id", patchRequests, "", "")
 
 		assert.NoError(t, err)
 	})
@@ -347,7 +517,7 @@ func TestUsers_Update(t *testing.T) {
 
 		defer srv.Close()
 
-		_, _, err := client.User.Update(context.TODO(), "valid-user-id", patchRequests, string(customSchemas))
+		_, _, err := client.User.Update(context.TODO(), "valid-user-id", patchRequests, string(customSchemas), "")
 
 		assert.NoError(t, err)
 	})
@@ -367,7 +537,7 @@ func TestUsers_Update(t *testing.T) {
 
 		defer srv.Close()
 
-		res, _, err := client.User.Update(context.TODO(), "valid-user-id", patchRequests, "")
+		res, _, err := client.User.Update(context.TODO(), "valid-user-id", patchRequests, "", "")
 
 		assert.Zero(t, res)
 		assert.Error(t, err)
@@ -383,7 +553,7 @@ func TestUsers_Update(t *testing.T) {
 
 		defer srv.Close()
 
-		res, _, err := client.User.Update(context.TODO(), "valid-user-id", patchRequests, string(customSchemas))
+		res, _, err := client.User.Update(context.TODO(), "valid-user-id", patchRequests, string(customSchemas), "")
 
 		assert.Zero(t, res)
 		assert.Error(t, err)
@@ -391,6 +561,62 @@ func TestUsers_Update(t *testing.T) {
 	})
 }
 
+func TestUsers_SetPassword(t *testing.T) {
+
+	t.Run("validate the API request", func(t *testing.T) {
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertCall[users.PatchRequestBody](t, r, fmt.Sprintf("%s%s", usersPath, "valid-user-id"), "PATCH", users.PatchRequestBody{
+				Schemas: []string{ScimUpdateSchemas},
+				Operations: []generic.PatchRequest{
+					{
+						Op:    "replace",
+						Path:  "password",
+						Value: "new-password",
+					},
+					{
+						Op:   "replace",
+						Path: "urn:ietf:params:scim:schemas:extension:sap:2.0:User:passwordDetails",
+						Value: map[string]any{
+							"status": "initial",
+						},
+					},
+				},
+			})
+		}))
+
+		defer srv.Close()
+
+		err := client.User.SetPassword(context.TODO(), "valid-user-id", "new-password", true)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("validate the API request with a password policy violation", func(t *testing.T) {
+
+		resErr, _ := json.Marshal(ScimResponseError{
+			Detail:   "Password does not meet the password policy requirements",
+			Status:   "400",
+			ScimType: "invalidValue",
+		})
+
+		client, srv := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write(resErr)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		err := client.User.SetPassword(context.TODO(), "valid-user-id", "weak", false)
+
+		var scimErr ScimResponseError
+		assert.ErrorAs(t, err, &scimErr)
+		assert.Equal(t, "invalidValue", scimErr.ScimType)
+		assert.Equal(t, "SCIM error 400 \nPassword does not meet the password policy requirements", err.Error())
+	})
+}
+
 func TestUsers_Delete(t *testing.T) {
 
 	t.Run("validate the API request", func(t *testing.T) {
@@ -401,7 +627,7 @@ func TestUsers_Delete(t *testing.T) {
 
 		defer srv.Close()
 
-		err := client.User.Delete(context.TODO(), "valid-user-id")
+		err := client.User.Delete(context.TODO(), "valid-user-id", "")
 
 		assert.NoError(t, err)
 	})
@@ -423,11 +649,34 @@ func TestUsers_Delete(t *testing.T) {
 
 		defer srv.Close()
 
-		err := client.User.Delete(context.TODO(), "valid-user-id")
+		err := client.User.Delete(context.TODO(), "valid-user-id", "")
 
 		assert.Error(t, err)
 		assert.Equal(t, "SCIM error 400 \ndelete failed", err.Error())
 	})
+	t.Run("validate the API request with version - precondition failed", func(t *testing.T) {
+
+		resErr, _ := json.Marshal(ScimResponseError{
+			Detail: "version mismatch",
+			Status: "412",
+		})
+
+		client, srv := testClient(discoveryTestHandler(t, serviceProviderConfigBody, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, `W/"1"`, r.Header.Get("If-Match"))
+
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, err := w.Write(resErr)
+			assert.NoError(t, err, "Failed to write response")
+		}))
+
+		defer srv.Close()
+
+		assert.NoError(t, client.Discover(context.TODO()))
+
+		err := client.User.Delete(context.TODO(), "valid-user-id", `W/"1"`)
+
+		assert.True(t, IsPreconditionFailed(err))
+	})
 }
 
 func responseWithCustomSchemas(userRes []byte, customSchemas []byte) []byte {
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+)
+
+// ParseCertificate parses an X.509 certificate in PEM format
+// as the tenant accepts certificates without PEM boundary markers, the base64 encoded content is accepted as well
+func ParseCertificate(value string) (*x509.Certificate, error) {
+
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("the certificate is invalid : %s", err)
+		}
+		return certificate, nil
+	}
+
+	return parseBase64Certificate(value)
+}
+
+// CertificateDetailsOf returns the subject, fingerprints and validity of the certificate
+// the details are empty if the certificate cannot be parsed
+func CertificateDetailsOf(value string) corporateidps.CertificateDetails {
+
+	certificate, err := ParseCertificate(value)
+	if err != nil {
+		return corporateidps.CertificateDetails{}
+	}
+
+	return certificateDetails(certificate)
+}
+
+func certificateDetails(certificate *x509.Certificate) corporateidps.CertificateDetails {
+
+	sha1Digest := sha1.Sum(certificate.Raw)
+	sha256Digest := sha256.Sum256(certificate.Raw)
+
+	subjectDn := certificate.Subject.String()
+	sha1Fingerprint := fingerprint(sha1Digest[:])
+	sha256Fingerprint := fingerprint(sha256Digest[:])
+	notBefore := certificate.NotBefore.UTC().Format(time.RFC3339)
+	notAfter := certificate.NotAfter.UTC().Format(time.RFC3339)
+
+	return corporateidps.CertificateDetails{
+		SubjectDn:         &subjectDn,
+		Sha1Fingerprint:   &sha1Fingerprint,
+		Sha256Fingerprint: &sha256Fingerprint,
+		NotBefore:         &notBefore,
+		NotAfter:          &notAfter,
+	}
+}
+
+// fingerprint formats the digest of a certificate as colon separated hex, as shown by openssl
+func fingerprint(digest []byte) string {
+
+	parts := make([]string, len(digest))
+	for i, b := range digest {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// parseBase64Certificate parses the base64 encoded DER content of a certificate, which may be wrapped over several lines
+func parseBase64Certificate(content string) (*x509.Certificate, error) {
+
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(content), ""))
+	if err != nil {
+		return nil, fmt.Errorf("the certificate is not base64 encoded : %s", err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("the certificate is invalid : %s", err)
+	}
+
+	return certificate, nil
+}
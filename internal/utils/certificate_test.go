@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the certificate of the signed metadata, fingerprints calculated with openssl
+var certificatePem, _ = os.ReadFile("testdata/certificate.pem")
+
+func TestCertificateDetailsOf(t *testing.T) {
+
+	t.Run("PEM certificate", func(t *testing.T) {
+
+		details := CertificateDetailsOf(string(certificatePem))
+
+		assert.Equal(t, "CN=sp.example.com,O=Example,C=DE", *details.SubjectDn)
+		assert.Equal(t, "9D:8F:D0:57:DE:E8:55:78:32:37:21:41:EA:3A:38:99:99:61:18:39", *details.Sha1Fingerprint)
+		assert.Equal(t, "57:38:CA:9B:FE:60:0F:87:C1:36:39:53:4A:87:CD:27:62:03:CB:CF:74:BF:52:0B:B7:41:C5:18:D4:AD:CC:14", *details.Sha256Fingerprint)
+		assert.Equal(t, "2026-10-18T18:09:17Z", *details.NotBefore)
+		assert.Equal(t, "2126-09-24T18:09:17Z", *details.NotAfter)
+	})
+
+	t.Run("certificate without PEM boundary markers", func(t *testing.T) {
+
+		content := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(string(certificatePem)), "-----BEGIN CERTIFICATE-----"), "-----END CERTIFICATE-----")
+
+		assert.Equal(t, CertificateDetailsOf(string(certificatePem)), CertificateDetailsOf(content))
+	})
+
+	t.Run("invalid certificate", func(t *testing.T) {
+
+		details := CertificateDetailsOf("-----BEGIN CERTIFICATE-----\nredacted\n-----END CERTIFICATE-----")
+
+		assert.Nil(t, details.SubjectDn)
+		assert.Nil(t, details.Sha256Fingerprint)
+		assert.Nil(t, details.NotAfter)
+	})
+}
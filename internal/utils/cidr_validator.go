@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"context"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// CIDR validator, checks that the attribute is an IP network range in CIDR notation
+// the value is parsed the same way as the network ranges are evaluated, so that IPv6 ranges are accepted as well
+type cidrValidator struct {
+}
+
+func (v cidrValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v cidrValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a valid IP network range in CIDR notation"
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue
+
+	if _, _, err := net.ParseCIDR(value.ValueString()); err == nil {
+		return
+	}
+
+	response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+		request.Path,
+		v.Description(ctx),
+		value.String(),
+	))
+}
+
+func ValidCIDR() validator.String {
+	return cidrValidator{}
+}
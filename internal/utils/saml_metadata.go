@@ -0,0 +1,433 @@
+package utils
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+const (
+	samlMetadataNamespace = "urn:oasis:names:tc:SAML:2.0:metadata"
+	xmlDsigNamespace      = "http://www.w3.org/2000/09/xmldsig#"
+)
+
+// SamlMetadata holds the configuration of a SAML service provider derived from its metadata
+type SamlMetadata struct {
+	EntityId                 string
+	AcsEndpoints             []applications.Saml2AcsEndpoint
+	SloEndpoints             []applications.Saml2SLOEndpoint
+	CertificatesForSigning   []corporateidps.SigningCertificateData
+	CertificateForEncryption *applications.EncryptionCertificateData
+	NameIdFormats            []string
+	samlEntity
+}
+
+// SamlIdpMetadata holds the configuration of a SAML identity provider derived from its metadata
+type SamlIdpMetadata struct {
+	EntityId               string
+	SsoEndpoints           []corporateidps.SAML2SSOEndpoint
+	SloEndpoints           []corporateidps.SAML2SLOEndpoint
+	CertificatesForSigning []corporateidps.SigningCertificateData
+	NameIdFormats          []string
+	samlEntity
+}
+
+// samlEntity holds the descriptors and the certificates of parsed metadata, to check their signature and validity
+// the checks depend on the time and on the trusted certificate, hence they are not part of parsing
+type samlEntity struct {
+	entityDescriptor *etree.Element
+	roleDescriptor   *etree.Element
+	certificates     []*x509.Certificate
+}
+
+// ParseSamlMetadata parses the EntityDescriptor of a SAML service provider
+// neither the signature nor the expiry of the metadata and its certificates are checked, see VerifySignature and CheckValidity
+func ParseSamlMetadata(document string) (SamlMetadata, error) {
+
+	entityDescriptor, err := parseEntityDescriptor(document)
+	if err != nil {
+		return SamlMetadata{}, err
+	}
+
+	return samlMetadataFrom(entityDescriptor)
+}
+
+// VerifySignature verifies the enveloped signature of the EntityDescriptor with the trusted certificate, see samlEntity.verifiedEntityDescriptor
+// the returned metadata is derived from the verified EntityDescriptor only, so that unsigned content next to the signed element is left out
+func (m SamlMetadata) VerifySignature(trustedCertificate *x509.Certificate, now time.Time) (SamlMetadata, error) {
+
+	entityDescriptor, err := m.verifiedEntityDescriptor(trustedCertificate, now)
+	if err != nil {
+		return SamlMetadata{}, err
+	}
+
+	return samlMetadataFrom(entityDescriptor)
+}
+
+func samlMetadataFrom(entityDescriptor *etree.Element) (SamlMetadata, error) {
+
+	spDescriptor, err := roleDescriptorOf(entityDescriptor, "SPSSODescriptor")
+	if err != nil {
+		return SamlMetadata{}, err
+	}
+
+	metadata := SamlMetadata{
+		AcsEndpoints:           []applications.Saml2AcsEndpoint{},
+		SloEndpoints:           []applications.Saml2SLOEndpoint{},
+		CertificatesForSigning: []corporateidps.SigningCertificateData{},
+		NameIdFormats:          []string{},
+		samlEntity:             samlEntity{entityDescriptor: entityDescriptor, roleDescriptor: spDescriptor},
+	}
+	metadata.EntityId = entityDescriptor.SelectAttrValue("entityID", "")
+
+	for _, keyDescriptor := range childElements(spDescriptor, samlMetadataNamespace, "KeyDescriptor") {
+
+		certificate, err := metadata.keyDescriptorCertificate(keyDescriptor)
+		if err != nil {
+			return SamlMetadata{}, err
+		}
+
+		// a key descriptor without use is used for signing as well as for encryption
+		use := keyDescriptor.SelectAttrValue("use", "")
+
+		if use == "" || use == "signing" {
+			metadata.CertificatesForSigning = append(metadata.CertificatesForSigning, corporateidps.SigningCertificateData{
+				Base64Certificate:  certificate.Base64Certificate,
+				Dn:                 certificate.Dn,
+				IsDefault:          len(metadata.CertificatesForSigning) == 0,
+				ValidFrom:          certificate.ValidFrom,
+				ValidTo:            certificate.ValidTo,
+				CertificateDetails: certificate.CertificateDetails,
+			})
+		}
+
+		if (use == "" || use == "encryption") && metadata.CertificateForEncryption == nil {
+			metadata.CertificateForEncryption = &certificate
+		}
+	}
+
+	defaultAcs := -1
+	for _, service := range childElements(spDescriptor, samlMetadataNamespace, "AssertionConsumerService") {
+
+		endpoint := applications.Saml2AcsEndpoint{
+			BindingName: service.SelectAttrValue("Binding", ""),
+			Location:    service.SelectAttrValue("Location", ""),
+		}
+
+		index := service.SelectAttrValue("index", "")
+		value, err := strconv.ParseInt(index, 10, 32)
+		if err != nil {
+			return SamlMetadata{}, fmt.Errorf("the index %q of the AssertionConsumerService %s is invalid", index, endpoint.Location)
+		}
+		endpoint.Index = int32(value)
+
+		if isDefault := service.SelectAttrValue("isDefault", ""); defaultAcs < 0 && (isDefault == "true" || isDefault == "1") {
+			defaultAcs = len(metadata.AcsEndpoints)
+		}
+
+		metadata.AcsEndpoints = append(metadata.AcsEndpoints, endpoint)
+	}
+
+	// without an explicit default, the first endpoint is the default one
+	if defaultAcs < 0 && len(metadata.AcsEndpoints) > 0 {
+		defaultAcs = 0
+	}
+	if defaultAcs >= 0 {
+		metadata.AcsEndpoints[defaultAcs].IsDefault = true
+	}
+
+	for _, service := range childElements(spDescriptor, samlMetadataNamespace, "SingleLogoutService") {
+		metadata.SloEndpoints = append(metadata.SloEndpoints, applications.Saml2SLOEndpoint{
+			BindingName:      service.SelectAttrValue("Binding", ""),
+			Location:         service.SelectAttrValue("Location", ""),
+			ResponseLocation: service.SelectAttrValue("ResponseLocation", ""),
+		})
+	}
+
+	for _, nameIdFormat := range childElements(spDescriptor, samlMetadataNamespace, "NameIDFormat") {
+		metadata.NameIdFormats = append(metadata.NameIdFormats, strings.TrimSpace(nameIdFormat.Text()))
+	}
+
+	return metadata, nil
+}
+
+// ParseSamlIdpMetadata parses the EntityDescriptor of a SAML identity provider
+// the metadata of identity providers does not flag default endpoints, the first endpoint with the most preferred binding is the default one
+// like for ParseSamlMetadata, neither the signature nor the expiry are checked
+func ParseSamlIdpMetadata(document string) (SamlIdpMetadata, error) {
+
+	entityDescriptor, err := parseEntityDescriptor(document)
+	if err != nil {
+		return SamlIdpMetadata{}, err
+	}
+
+	return samlIdpMetadataFrom(entityDescriptor)
+}
+
+// VerifySignature verifies the enveloped signature of the EntityDescriptor with the trusted certificate, see samlEntity.verifiedEntityDescriptor
+// like for SamlMetadata, the returned metadata is derived from the verified EntityDescriptor only
+func (m SamlIdpMetadata) VerifySignature(trustedCertificate *x509.Certificate, now time.Time) (SamlIdpMetadata, error) {
+
+	entityDescriptor, err := m.verifiedEntityDescriptor(trustedCertificate, now)
+	if err != nil {
+		return SamlIdpMetadata{}, err
+	}
+
+	return samlIdpMetadataFrom(entityDescriptor)
+}
+
+func samlIdpMetadataFrom(entityDescriptor *etree.Element) (SamlIdpMetadata, error) {
+
+	idpDescriptor, err := roleDescriptorOf(entityDescriptor, "IDPSSODescriptor")
+	if err != nil {
+		return SamlIdpMetadata{}, err
+	}
+
+	metadata := SamlIdpMetadata{
+		SsoEndpoints:           []corporateidps.SAML2SSOEndpoint{},
+		SloEndpoints:           []corporateidps.SAML2SLOEndpoint{},
+		CertificatesForSigning: []corporateidps.SigningCertificateData{},
+		NameIdFormats:          []string{},
+		samlEntity:             samlEntity{entityDescriptor: entityDescriptor, roleDescriptor: idpDescriptor},
+	}
+	metadata.EntityId = entityDescriptor.SelectAttrValue("entityID", "")
+
+	for _, keyDescriptor := range childElements(idpDescriptor, samlMetadataNamespace, "KeyDescriptor") {
+
+		// certificates used only for encryption are not needed to trust the identity provider
+		if keyDescriptor.SelectAttrValue("use", "") == "encryption" {
+			continue
+		}
+
+		certificate, err := metadata.keyDescriptorCertificate(keyDescriptor)
+		if err != nil {
+			return SamlIdpMetadata{}, err
+		}
+
+		metadata.CertificatesForSigning = append(metadata.CertificatesForSigning, corporateidps.SigningCertificateData{
+			Base64Certificate:  certificate.Base64Certificate,
+			Dn:                 certificate.Dn,
+			IsDefault:          len(metadata.CertificatesForSigning) == 0,
+			ValidFrom:          certificate.ValidFrom,
+			ValidTo:            certificate.ValidTo,
+			CertificateDetails: certificate.CertificateDetails,
+		})
+	}
+
+	ssoBindings := []string{}
+	for _, service := range childElements(idpDescriptor, samlMetadataNamespace, "SingleSignOnService") {
+		endpoint := corporateidps.SAML2SSOEndpoint{
+			BindingName: service.SelectAttrValue("Binding", ""),
+			Location:    service.SelectAttrValue("Location", ""),
+		}
+
+		metadata.SsoEndpoints = append(metadata.SsoEndpoints, endpoint)
+		ssoBindings = append(ssoBindings, endpoint.BindingName)
+	}
+	if i := defaultBindingIndex(ssoBindings); i >= 0 {
+		metadata.SsoEndpoints[i].IsDefault = true
+	}
+
+	sloBindings := []string{}
+	for _, service := range childElements(idpDescriptor, samlMetadataNamespace, "SingleLogoutService") {
+		endpoint := corporateidps.SAML2SLOEndpoint{
+			BindingName:      service.SelectAttrValue("Binding", ""),
+			Location:         service.SelectAttrValue("Location", ""),
+			ResponseLocation: service.SelectAttrValue("ResponseLocation", ""),
+		}
+
+		metadata.SloEndpoints = append(metadata.SloEndpoints, endpoint)
+		sloBindings = append(sloBindings, endpoint.BindingName)
+	}
+	if i := defaultBindingIndex(sloBindings); i >= 0 {
+		metadata.SloEndpoints[i].IsDefault = true
+	}
+
+	for _, nameIdFormat := range childElements(idpDescriptor, samlMetadataNamespace, "NameIDFormat") {
+		metadata.NameIdFormats = append(metadata.NameIdFormats, strings.TrimSpace(nameIdFormat.Text()))
+	}
+
+	return metadata, nil
+}
+
+// the bindings preferred for the default endpoint of an identity provider, in the order of preference
+var preferredBindings = []string{
+	"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST",
+	"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect",
+}
+
+// defaultBindingIndex returns the index of the first endpoint with the most preferred binding
+// if no endpoint has a preferred binding the first endpoint is the default one, -1 is returned if there are no endpoints
+func defaultBindingIndex(bindings []string) int {
+	for _, preferred := range preferredBindings {
+		if i := slices.Index(bindings, preferred); i >= 0 {
+			return i
+		}
+	}
+	if len(bindings) > 0 {
+		return 0
+	}
+	return -1
+}
+
+// Signed reports whether the EntityDescriptor contains a signature
+func (e samlEntity) Signed() bool {
+	return len(childElements(e.entityDescriptor, xmlDsigNamespace, "Signature")) > 0
+}
+
+// verifiedEntityDescriptor verifies the enveloped signature of the EntityDescriptor with the trusted certificate and returns the verified element
+// the metadata must be signed with the trusted certificate, which must be valid at the given time
+// a certificate contained in the signature is only accepted if it is the trusted certificate
+func (e samlEntity) verifiedEntityDescriptor(trustedCertificate *x509.Certificate, now time.Time) (*etree.Element, error) {
+
+	validationContext := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{
+		Roots: []*x509.Certificate{trustedCertificate},
+	})
+	validationContext.Clock = dsig.NewFakeClockAt(now)
+
+	verified, err := validationContext.Validate(e.entityDescriptor)
+	if err != nil {
+		if errors.Is(err, dsig.ErrMissingSignature) {
+			return nil, fmt.Errorf("the metadata is not signed")
+		}
+		return nil, fmt.Errorf("the signature of the metadata is invalid : %s", err)
+	}
+
+	if !isElement(verified, samlMetadataNamespace, "EntityDescriptor") {
+		return nil, fmt.Errorf("the signature of the metadata does not cover the EntityDescriptor")
+	}
+
+	return verified, nil
+}
+
+// CheckValidity checks that the descriptors have not expired and that the certificates of the metadata are valid at the given time
+func (e samlEntity) CheckValidity(now time.Time) error {
+
+	for _, descriptor := range []*etree.Element{e.entityDescriptor, e.roleDescriptor} {
+		if err := checkValidUntil(descriptor, now); err != nil {
+			return err
+		}
+	}
+
+	for _, certificate := range e.certificates {
+		if err := checkCertificateValidity(certificate, now); err != nil {
+			return fmt.Errorf("the certificate %s is invalid : %s", certificate.Subject, err)
+		}
+	}
+
+	return nil
+}
+
+// parseEntityDescriptor parses the metadata and returns its EntityDescriptor
+func parseEntityDescriptor(document string) (*etree.Element, error) {
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(document); err != nil {
+		return nil, fmt.Errorf("the metadata is not a valid XML document : %s", err)
+	}
+
+	entityDescriptor := doc.Root()
+	if entityDescriptor == nil || !isElement(entityDescriptor, samlMetadataNamespace, "EntityDescriptor") {
+		return nil, fmt.Errorf("the metadata must contain an EntityDescriptor as root element")
+	}
+
+	return entityDescriptor, nil
+}
+
+// roleDescriptorOf returns the first role descriptor of the EntityDescriptor with the given name
+func roleDescriptorOf(entityDescriptor *etree.Element, roleDescriptor string) (*etree.Element, error) {
+
+	descriptors := childElements(entityDescriptor, samlMetadataNamespace, roleDescriptor)
+	if len(descriptors) == 0 {
+		return nil, fmt.Errorf("the metadata does not contain an %s", roleDescriptor)
+	}
+
+	return descriptors[0], nil
+}
+
+func isElement(element *etree.Element, namespace string, tag string) bool {
+	return element.Tag == tag && element.NamespaceURI() == namespace
+}
+
+// childElements returns the child elements with the given namespace and tag
+func childElements(element *etree.Element, namespace string, tag string) []*etree.Element {
+	children := []*etree.Element{}
+	for _, child := range element.ChildElements() {
+		if isElement(child, namespace, tag) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// checkValidUntil checks that the descriptor has not expired
+func checkValidUntil(descriptor *etree.Element, now time.Time) error {
+
+	validUntil := descriptor.SelectAttr("validUntil")
+	if validUntil == nil {
+		return nil
+	}
+
+	expiry, err := time.Parse(time.RFC3339, validUntil.Value)
+	if err != nil {
+		return fmt.Errorf("the validUntil %q of the %s is invalid", validUntil.Value, descriptor.Tag)
+	}
+	if now.After(expiry) {
+		return fmt.Errorf("the %s expired at %s", descriptor.Tag, validUntil.Value)
+	}
+
+	return nil
+}
+
+// keyDescriptorCertificate returns the X.509 certificate of the key descriptor in the format of the tenant
+// the certificate is kept to check its validity
+func (e *samlEntity) keyDescriptorCertificate(keyDescriptor *etree.Element) (applications.EncryptionCertificateData, error) {
+
+	var content *etree.Element
+	for _, keyInfo := range childElements(keyDescriptor, xmlDsigNamespace, "KeyInfo") {
+		for _, x509Data := range childElements(keyInfo, xmlDsigNamespace, "X509Data") {
+			if certificates := childElements(x509Data, xmlDsigNamespace, "X509Certificate"); content == nil && len(certificates) > 0 {
+				content = certificates[0]
+			}
+		}
+	}
+	if content == nil {
+		return applications.EncryptionCertificateData{}, fmt.Errorf("the KeyDescriptor does not contain an X.509 certificate")
+	}
+
+	certificate, err := parseBase64Certificate(content.Text())
+	if err != nil {
+		return applications.EncryptionCertificateData{}, err
+	}
+	e.certificates = append(e.certificates, certificate)
+
+	encoded := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+
+	return applications.EncryptionCertificateData{
+		Dn:                 certificate.Subject.String(),
+		Base64Certificate:  strings.TrimSuffix(string(encoded), "\n"),
+		ValidFrom:          certificate.NotBefore.UTC().Format(time.RFC3339),
+		ValidTo:            certificate.NotAfter.UTC().Format(time.RFC3339),
+		CertificateDetails: certificateDetails(certificate),
+	}, nil
+}
+
+func checkCertificateValidity(certificate *x509.Certificate, now time.Time) error {
+	if now.Before(certificate.NotBefore) {
+		return fmt.Errorf("the certificate is not valid before %s", certificate.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.After(certificate.NotAfter) {
+		return fmt.Errorf("the certificate expired at %s", certificate.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
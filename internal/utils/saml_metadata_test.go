@@ -0,0 +1,403 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+)
+
+// the metadata has been signed with openssl, its certificate is valid from 2026-10-18T18:09:17Z to 2126-09-24T18:09:17Z
+var signedMetadata, _ = os.ReadFile("testdata/saml_metadata_signed.xml")
+
+// the metadata of an identity provider with the certificate of the signed metadata
+var idpMetadata, _ = os.ReadFile("testdata/saml_idp_metadata.xml")
+
+var (
+	metadataSignature = regexp.MustCompile(`<ds:Signature .*</ds:Signature>`)
+	signatureValue    = regexp.MustCompile(`(?s)<ds:SignatureValue>.*</ds:SignatureValue>`)
+)
+
+func TestParseSamlMetadata(t *testing.T) {
+
+	unsignedMetadata := metadataSignature.ReplaceAllString(string(signedMetadata), "")
+
+	t.Run("signed metadata", func(t *testing.T) {
+
+		metadata, err := ParseSamlMetadata(string(signedMetadata))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://sp.example.com/metadata", metadata.EntityId)
+		assert.Equal(t, []applications.Saml2AcsEndpoint{
+			{
+				BindingName: "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST",
+				Location:    "https://sp.example.com/saml/acs",
+				Index:       0,
+			},
+			{
+				BindingName: "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect",
+				Location:    "https://sp.example.com/saml/acs/redirect",
+				Index:       1,
+				IsDefault:   true,
+			},
+		}, metadata.AcsEndpoints)
+		assert.Equal(t, []applications.Saml2SLOEndpoint{
+			{
+				BindingName:      "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST",
+				Location:         "https://sp.example.com/saml/logout",
+				ResponseLocation: "https://sp.example.com/saml/logout/response",
+			},
+		}, metadata.SloEndpoints)
+		assert.Equal(t, []string{"urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"}, metadata.NameIdFormats)
+
+		assert.Len(t, metadata.CertificatesForSigning, 1)
+		assert.Equal(t, "CN=sp.example.com,O=Example,C=DE", metadata.CertificatesForSigning[0].Dn)
+		assert.True(t, metadata.CertificatesForSigning[0].IsDefault)
+		assert.Equal(t, "2026-10-18T18:09:17Z", metadata.CertificatesForSigning[0].ValidFrom)
+		assert.Equal(t, "2126-09-24T18:09:17Z", metadata.CertificatesForSigning[0].ValidTo)
+		assert.True(t, strings.HasPrefix(metadata.CertificatesForSigning[0].Base64Certificate, "-----BEGIN CERTIFICATE-----\n"))
+		assert.True(t, strings.HasSuffix(metadata.CertificatesForSigning[0].Base64Certificate, "\n-----END CERTIFICATE-----"))
+		assert.Equal(t, "57:38:CA:9B:FE:60:0F:87:C1:36:39:53:4A:87:CD:27:62:03:CB:CF:74:BF:52:0B:B7:41:C5:18:D4:AD:CC:14", *metadata.CertificatesForSigning[0].Sha256Fingerprint)
+		assert.Equal(t, "2126-09-24T18:09:17Z", *metadata.CertificatesForSigning[0].NotAfter)
+
+		assert.Equal(t, &applications.EncryptionCertificateData{
+			Dn:                 metadata.CertificatesForSigning[0].Dn,
+			Base64Certificate:  metadata.CertificatesForSigning[0].Base64Certificate,
+			ValidFrom:          metadata.CertificatesForSigning[0].ValidFrom,
+			ValidTo:            metadata.CertificatesForSigning[0].ValidTo,
+			CertificateDetails: metadata.CertificatesForSigning[0].CertificateDetails,
+		}, metadata.CertificateForEncryption)
+	})
+
+	t.Run("unsigned metadata", func(t *testing.T) {
+
+		// a key descriptor without use is used for signing and encryption, the first endpoint without an explicit default is the default one
+		document := strings.Replace(unsignedMetadata, `<md:KeyDescriptor use="signing">`, `<md:KeyDescriptor>`, 1)
+		document = strings.Replace(document, ` isDefault="true"`, "", 1)
+
+		metadata, err := ParseSamlMetadata(document)
+
+		assert.NoError(t, err)
+		assert.Len(t, metadata.CertificatesForSigning, 1)
+		assert.NotNil(t, metadata.CertificateForEncryption)
+		assert.True(t, metadata.AcsEndpoints[0].IsDefault)
+		assert.False(t, metadata.AcsEndpoints[1].IsDefault)
+	})
+
+	tests := []struct {
+		name     string
+		document string
+		expected string
+	}{
+		{
+			name:     "invalid index",
+			document: strings.Replace(unsignedMetadata, `index="1"`, `index="first"`, 1),
+			expected: `the index "first" of the AssertionConsumerService https://sp.example.com/saml/acs/redirect is invalid`,
+		},
+		{
+			name:     "invalid certificate",
+			document: strings.Replace(unsignedMetadata, "<ds:X509Certificate>MIID", "<ds:X509Certificate>MIIE", 1),
+			expected: "the certificate is invalid : x509: malformed certificate",
+		},
+		{
+			name:     "no service provider",
+			document: `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com"><md:IDPSSODescriptor></md:IDPSSODescriptor></md:EntityDescriptor>`,
+			expected: "the metadata does not contain an SPSSODescriptor",
+		},
+		{
+			name:     "no entity descriptor",
+			document: `<EntityDescriptor entityID="https://sp.example.com"></EntityDescriptor>`,
+			expected: "the metadata must contain an EntityDescriptor as root element",
+		},
+		{
+			name:     "invalid XML",
+			document: `<md:EntityDescriptor`,
+			expected: "the metadata is not a valid XML document : XML syntax error on line 1: unexpected EOF",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseSamlMetadata(test.document)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestSamlMetadataVerifySignature(t *testing.T) {
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	trustedCertificate, err := ParseCertificate(string(certificatePem))
+	assert.NoError(t, err)
+
+	t.Run("signed with the trusted certificate", func(t *testing.T) {
+
+		metadata, err := ParseSamlMetadata(string(signedMetadata))
+		assert.NoError(t, err)
+
+		verified, err := metadata.VerifySignature(trustedCertificate, now)
+		assert.NoError(t, err)
+		assert.Equal(t, metadata.EntityId, verified.EntityId)
+		assert.Equal(t, metadata.AcsEndpoints, verified.AcsEndpoints)
+		assert.Equal(t, metadata.CertificatesForSigning, verified.CertificatesForSigning)
+		assert.Equal(t, metadata.NameIdFormats, verified.NameIdFormats)
+
+		// the values are derived from the element returned by the verification, not from the parsed document
+		assert.NotSame(t, metadata.entityDescriptor, verified.entityDescriptor)
+	})
+
+	tests := []struct {
+		name        string
+		document    string
+		certificate *x509.Certificate
+		now         time.Time
+		expected    string
+	}{
+		{
+			name:        "not signed",
+			document:    metadataSignature.ReplaceAllString(string(signedMetadata), ""),
+			certificate: trustedCertificate,
+			now:         now,
+			expected:    "the metadata is not signed",
+		},
+		{
+			name:        "modified after signing",
+			document:    strings.Replace(string(signedMetadata), "https://sp.example.com/saml/acs/redirect", "https://attacker.example.com/saml/acs", 1),
+			certificate: trustedCertificate,
+			now:         now,
+			expected:    "the signature of the metadata is invalid : Signature could not be verified",
+		},
+		{
+			name:        "invalid signature value",
+			document:    signatureValue.ReplaceAllString(string(signedMetadata), "<ds:SignatureValue>"+strings.Repeat("A", 344)+"</ds:SignatureValue>"),
+			certificate: trustedCertificate,
+			now:         now,
+			expected:    "the signature of the metadata is invalid : crypto/rsa: verification error",
+		},
+		{
+			name:        "reference to another element",
+			document:    strings.Replace(string(signedMetadata), `URI="#_6c3a4f8b9e"`, `URI="#_other"`, 1),
+			certificate: trustedCertificate,
+			now:         now,
+			expected:    "the metadata is not signed",
+		},
+		{
+			name:        "signed with another certificate",
+			document:    string(signedMetadata),
+			certificate: untrustedCertificate(t),
+			now:         now,
+			expected:    "the signature of the metadata is invalid : Could not verify certificate against trusted certs",
+		},
+		{
+			name:        "trusted certificate not yet valid",
+			document:    string(signedMetadata),
+			certificate: trustedCertificate,
+			now:         time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:    "the signature of the metadata is invalid : Cert is not valid at this time",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata, err := ParseSamlMetadata(test.document)
+			assert.NoError(t, err)
+
+			_, err = metadata.VerifySignature(test.certificate, test.now)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestSamlMetadataCheckValidity(t *testing.T) {
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("valid metadata", func(t *testing.T) {
+
+		metadata, err := ParseSamlMetadata(string(signedMetadata))
+		assert.NoError(t, err)
+
+		assert.NoError(t, metadata.CheckValidity(now))
+	})
+
+	tests := []struct {
+		name     string
+		document string
+		now      time.Time
+		expected string
+	}{
+		{
+			name:     "expired certificate",
+			document: string(signedMetadata),
+			now:      time.Date(2127, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: "the certificate CN=sp.example.com,O=Example,C=DE is invalid : the certificate expired at 2126-09-24T18:09:17Z",
+		},
+		{
+			name:     "expired metadata",
+			document: strings.Replace(string(signedMetadata), `entityID=`, `validUntil="2029-12-31T23:59:59Z" entityID=`, 1),
+			now:      now,
+			expected: "the EntityDescriptor expired at 2029-12-31T23:59:59Z",
+		},
+		{
+			name:     "expired service provider",
+			document: strings.Replace(string(signedMetadata), `<md:SPSSODescriptor `, `<md:SPSSODescriptor validUntil="2029-12-31T23:59:59Z" `, 1),
+			now:      now,
+			expected: "the SPSSODescriptor expired at 2029-12-31T23:59:59Z",
+		},
+		{
+			name:     "invalid validUntil",
+			document: strings.Replace(string(signedMetadata), `entityID=`, `validUntil="tomorrow" entityID=`, 1),
+			now:      now,
+			expected: `the validUntil "tomorrow" of the EntityDescriptor is invalid`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata, err := ParseSamlMetadata(test.document)
+			assert.NoError(t, err)
+
+			assert.EqualError(t, metadata.CheckValidity(test.now), test.expected)
+		})
+	}
+}
+
+// untrustedCertificate returns a self-signed certificate which has not signed the metadata
+func untrustedCertificate(t *testing.T) *x509.Certificate {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "attacker.example.com"},
+		NotBefore:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2126, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return certificate
+}
+
+func TestParseSamlIdpMetadata(t *testing.T) {
+
+	t.Run("identity provider metadata", func(t *testing.T) {
+
+		metadata, err := ParseSamlIdpMetadata(string(idpMetadata))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://idp.example.com/adfs/services/trust", metadata.EntityId)
+
+		// the HTTP-POST binding is preferred as default, regardless of the order of the endpoints
+		assert.Equal(t, []corporateidps.SAML2SSOEndpoint{
+			{
+				BindingName: "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect",
+				Location:    "https://idp.example.com/adfs/ls/",
+			},
+			{
+				BindingName: "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST",
+				Location:    "https://idp.example.com/adfs/ls/post",
+				IsDefault:   true,
+			},
+		}, metadata.SsoEndpoints)
+		assert.Equal(t, []corporateidps.SAML2SLOEndpoint{
+			{
+				BindingName: "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect",
+				Location:    "https://idp.example.com/adfs/ls/",
+			},
+			{
+				BindingName:      "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST",
+				Location:         "https://idp.example.com/adfs/ls/",
+				ResponseLocation: "https://idp.example.com/adfs/ls/response",
+				IsDefault:        true,
+			},
+		}, metadata.SloEndpoints)
+		assert.Equal(t, []string{"urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress", "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"}, metadata.NameIdFormats)
+
+		// the encryption certificate is ignored
+		assert.Len(t, metadata.CertificatesForSigning, 1)
+		assert.Equal(t, "CN=sp.example.com,O=Example,C=DE", metadata.CertificatesForSigning[0].Dn)
+		assert.True(t, metadata.CertificatesForSigning[0].IsDefault)
+		assert.Equal(t, "2126-09-24T18:09:17Z", metadata.CertificatesForSigning[0].ValidTo)
+	})
+
+	t.Run("endpoints without preferred binding", func(t *testing.T) {
+
+		document := strings.ReplaceAll(string(idpMetadata), "bindings:HTTP-POST", "bindings:SOAP")
+		document = strings.ReplaceAll(document, "bindings:HTTP-Redirect", "bindings:URI")
+
+		metadata, err := ParseSamlIdpMetadata(document)
+
+		assert.NoError(t, err)
+		assert.True(t, metadata.SsoEndpoints[0].IsDefault)
+		assert.False(t, metadata.SsoEndpoints[1].IsDefault)
+	})
+
+	t.Run("expired certificate", func(t *testing.T) {
+
+		metadata, err := ParseSamlIdpMetadata(string(idpMetadata))
+		assert.NoError(t, err)
+
+		assert.EqualError(t, metadata.CheckValidity(time.Date(2127, 1, 1, 0, 0, 0, 0, time.UTC)), "the certificate CN=sp.example.com,O=Example,C=DE is invalid : the certificate expired at 2126-09-24T18:09:17Z")
+	})
+
+	t.Run("signed identity provider metadata", func(t *testing.T) {
+
+		document := etree.NewDocument()
+		assert.NoError(t, document.ReadFromString(strings.Replace(string(idpMetadata), "entityID=", `ID="_idp" entityID=`, 1)))
+
+		keyStore := dsig.RandomKeyStoreForTest()
+		signed, err := dsig.NewDefaultSigningContext(keyStore).SignEnveloped(document.Root())
+		assert.NoError(t, err)
+
+		document.SetRoot(signed)
+		signedXml, err := document.WriteToString()
+		assert.NoError(t, err)
+
+		_, rawCertificate, err := keyStore.GetKeyPair()
+		assert.NoError(t, err)
+		trustedCertificate, err := x509.ParseCertificate(rawCertificate)
+		assert.NoError(t, err)
+
+		metadata, err := ParseSamlIdpMetadata(signedXml)
+		assert.NoError(t, err)
+
+		verified, err := metadata.VerifySignature(trustedCertificate, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, metadata.SsoEndpoints, verified.SsoEndpoints)
+		assert.Equal(t, metadata.SloEndpoints, verified.SloEndpoints)
+		assert.Equal(t, metadata.CertificatesForSigning, verified.CertificatesForSigning)
+		assert.NotSame(t, metadata.entityDescriptor, verified.entityDescriptor)
+
+		// an endpoint modified after signing is not accepted
+		tampered, err := ParseSamlIdpMetadata(strings.Replace(signedXml, "https://idp.example.com/adfs/ls/post", "https://attacker.example.com/sso", 1))
+		assert.NoError(t, err)
+
+		_, err = tampered.VerifySignature(trustedCertificate, time.Now())
+		assert.EqualError(t, err, "the signature of the metadata is invalid : Signature could not be verified")
+	})
+
+	t.Run("service provider metadata", func(t *testing.T) {
+
+		_, err := ParseSamlIdpMetadata(string(signedMetadata))
+
+		assert.EqualError(t, err, "the metadata does not contain an IDPSSODescriptor")
+	})
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// SAML metadata validator, checks that the attribute is valid metadata of a SAML service provider or identity provider
+// the signature and the expiry are not checked, as validators run on every plan, they are checked when the metadata is planned
+// if file is set, the attribute is the path of a file containing the metadata
+type samlMetadataValidator struct {
+	role string
+	file bool
+}
+
+func (v samlMetadataValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v samlMetadataValidator) MarkdownDescription(_ context.Context) string {
+	if v.file {
+		return fmt.Sprintf("value must be the path of a file with a valid SAML %s EntityDescriptor", v.role)
+	}
+	return fmt.Sprintf("value must be a valid SAML %s EntityDescriptor", v.role)
+}
+
+func (v samlMetadataValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	document := request.ConfigValue.ValueString()
+	if v.file {
+		content, err := os.ReadFile(document)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				request.Path,
+				"Invalid SAML Metadata",
+				fmt.Sprintf("the metadata file cannot be read : %s", err),
+			)
+			return
+		}
+		document = string(content)
+	}
+
+	var err error
+	if v.role == "identity provider" {
+		_, err = ParseSamlIdpMetadata(document)
+	} else {
+		_, err = ParseSamlMetadata(document)
+	}
+
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid SAML Metadata",
+			err.Error(),
+		)
+	}
+}
+
+func ValidSamlMetadata() validator.String {
+	return samlMetadataValidator{role: "service provider"}
+}
+
+func ValidSamlIdpMetadata() validator.String {
+	return samlMetadataValidator{role: "identity provider"}
+}
+
+func ValidSamlIdpMetadataFile() validator.String {
+	return samlMetadataValidator{role: "identity provider", file: true}
+}
@@ -34,7 +34,9 @@ func (d *applicationDataSource) Metadata(_ context.Context, req datasource.Metad
 
 func (d *applicationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: `Gets an application from the SAP Cloud Identity services.`,
+		MarkdownDescription: `Gets an application from the SAP Cloud Identity services.
+
+For a child application, the effective configuration is shown: the assertion attributes and advanced assertion attributes inherited from the parent application are merged with those of the application and marked as ` + "`inherited`" + `.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Id of the application",
@@ -62,6 +64,83 @@ func (d *applicationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 					utils.ValidUUID(),
 				},
 			},
+			"override_inherited": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The properties of the authentication schema for which the values inherited from the parent application are disabled.",
+				Computed:            true,
+			},
+			"oidc_client": schema.SingleNestedAttribute{
+				MarkdownDescription: "The OpenID Connect client registration of the application and the endpoints of the tenant derived from the tenant URL. Only set for applications with the sso_type `openIdConnect` or `saml2oidc`.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "The client ID of the application.",
+						Computed:            true,
+					},
+					"issuer": schema.StringAttribute{
+						MarkdownDescription: "The issuer of the tokens of the tenant.",
+						Computed:            true,
+					},
+					"authorization_endpoint": schema.StringAttribute{
+						MarkdownDescription: "The endpoint to which authorization requests are sent.",
+						Computed:            true,
+					},
+					"token_endpoint": schema.StringAttribute{
+						MarkdownDescription: "The endpoint called to request tokens.",
+						Computed:            true,
+					},
+					"jwks_uri": schema.StringAttribute{
+						MarkdownDescription: "The endpoint called to request the JSON Web Keys of the tenant.",
+						Computed:            true,
+					},
+					"logout_endpoint": schema.StringAttribute{
+						MarkdownDescription: "The endpoint called to end the session of the user.",
+						Computed:            true,
+					},
+				},
+			},
+			"saml2_idp": schema.SingleNestedAttribute{
+				MarkdownDescription: "The SAML 2.0 identity provider endpoints of the tenant derived from the tenant URL. Only set for applications with the sso_type `saml2` or `saml2oidc`. The entity ID is the default one of the tenant, a customized entity ID is shown by the **sci_tenant_saml_metadata** data source.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"entity_id": schema.StringAttribute{
+						MarkdownDescription: "The entity ID of the tenant.",
+						Computed:            true,
+					},
+					"sso_url": schema.StringAttribute{
+						MarkdownDescription: "The single sign-on URL of the tenant.",
+						Computed:            true,
+					},
+					"slo_url": schema.StringAttribute{
+						MarkdownDescription: "The single logout URL of the tenant.",
+						Computed:            true,
+					},
+				},
+			},
+			"api_certificates": schema.ListNestedAttribute{
+				MarkdownDescription: "The certificates the application authenticates with at the APIs of the tenant. The certificates are managed in the tenant, they are read to show their details.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the certificate.",
+							Computed:            true,
+						},
+						"dn": schema.StringAttribute{
+							MarkdownDescription: "The distinguished name of the certificate.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the certificate.",
+							Computed:            true,
+						},
+						"base64_certificate": schema.StringAttribute{
+							MarkdownDescription: "The content of the Base64 certificate.",
+							Computed:            true,
+						},
+					}),
+				},
+			},
 			"multi_tenant_app": schema.BoolAttribute{
 				MarkdownDescription: "Only for Internal Use",
 				Computed:            true,
@@ -287,6 +366,14 @@ func (d *applicationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 								MarkdownDescription: "The URL with service provider metadata. The metadata URL must not contain a query parameter.",
 								Computed:            true,
 							},
+							"metadata_xml": schema.StringAttribute{
+								MarkdownDescription: "The SAML service provider metadata the configuration has been derived from. The metadata is not stored by the tenant, hence it is not returned.",
+								Computed:            true,
+							},
+							"metadata_signing_certificate": schema.StringAttribute{
+								MarkdownDescription: "The certificate the signature of the SAML service provider metadata has been verified with. The certificate is not stored by the tenant, hence it is not returned.",
+								Computed:            true,
+							},
 							"acs_endpoints": schema.ListNestedAttribute{
 								MarkdownDescription: "Configure the allowed domains for browser flows.",
 								Computed:            true,
@@ -335,7 +422,7 @@ func (d *applicationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 								MarkdownDescription: "Base64-encoded certificates used by the service provider to sign digitally, SAML protocol messages sent to Identity Authentication. A maximum of 2 certificates are allowed.",
 								Computed:            true,
 								NestedObject: schema.NestedAttributeObject{
-									Attributes: map[string]schema.Attribute{
+									Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
 										"base64_certificate": schema.StringAttribute{
 											MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 											Computed:            true,
@@ -356,13 +443,13 @@ func (d *applicationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 											MarkdownDescription: "Set the date uptil which the certificate is valid.",
 											Computed:            true,
 										},
-									},
+									}),
 								},
 							},
 							"encryption_certificate": schema.SingleNestedAttribute{
 								MarkdownDescription: "The certificate used for encryption of SAML2 requests and responses.",
 								Computed:            true,
-								Attributes: map[string]schema.Attribute{
+								Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
 									"base64_certificate": schema.StringAttribute{
 										MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 										Computed:            true,
@@ -379,7 +466,7 @@ func (d *applicationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 										MarkdownDescription: "Set the date uptil which the certificate is valid.",
 										Computed:            true,
 									},
-								},
+								}),
 							},
 							"response_elements_to_encrypt": schema.StringAttribute{
 								MarkdownDescription: "Specify which SAML response elements should be encrypted. " + utils.ValidValuesString(responseElementsToEncrypt),
@@ -413,6 +500,20 @@ func (d *applicationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 								MarkdownDescription: "Configure the algorithm for signing outgoing messages. " + utils.ValidValuesString(digestAlgorithmValues),
 								Computed:            true,
 							},
+							"proxy_authn_request": schema.SingleNestedAttribute{
+								MarkdownDescription: "Configure the SAML 2.0 authentication requests which are sent to the corporate identity provider when the tenant acts as a proxy.",
+								Computed:            true,
+								Attributes: map[string]schema.Attribute{
+									"authn_context": schema.StringAttribute{
+										MarkdownDescription: "The authentication context class reference which is requested from the corporate identity provider.",
+										Computed:            true,
+									},
+									"issuer_name_suffix": schema.StringAttribute{
+										MarkdownDescription: "The suffix appended to the issuer name of the tenant in the authentication requests, so that the corporate identity provider can tell the applications apart.",
+										Computed:            true,
+									},
+								},
+							},
 						},
 					},
 					"sap_managed_attributes": schema.SingleNestedAttribute{
@@ -491,6 +592,7 @@ func (d *applicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	state, _ := applicationValueFrom(ctx, res)
+	state.OidcClient, state.Saml2Idp, _ = applicationEndpointsValueFrom(ctx, d.cli.ServerURL, res.AuthenticationSchema.SsoType, applicationClientId(res))
 	diags = resp.State.Set(ctx, &state)
 
 	resp.Diagnostics.Append(diags...)
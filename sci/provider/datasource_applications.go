@@ -57,7 +57,9 @@ var authenticationSchemaObjType = map[string]attr.Type{
 
 var appSaml2ConfigObjType = types.ObjectType{
 	AttrTypes: map[string]attr.Type{
-		"saml_metadata_url": types.StringType,
+		"saml_metadata_url":            types.StringType,
+		"metadata_xml":                 types.StringType,
+		"metadata_signing_certificate": types.StringType,
 		"acs_endpoints": types.ListType{
 			ElemType: acsEndpointsObjType,
 		},
@@ -76,6 +78,9 @@ var appSaml2ConfigObjType = types.ObjectType{
 		"sign_assertions":              types.BoolType,
 		"sign_auth_responses":          types.BoolType,
 		"digest_algorithm":             types.StringType,
+		"proxy_authn_request": types.ObjectType{
+			AttrTypes: proxyAuthnRequestObjType,
+		},
 	},
 }
 
@@ -102,6 +107,11 @@ var saml2EncryptionCertificateObjType = types.ObjectType{
 		"dn":                 types.StringType,
 		"valid_from":         types.StringType,
 		"valid_to":           types.StringType,
+		"subject_dn":         types.StringType,
+		"sha1_fingerprint":   types.StringType,
+		"sha256_fingerprint": types.StringType,
+		"not_before":         types.StringType,
+		"not_after":          types.StringType,
 	},
 }
 
@@ -181,6 +191,11 @@ var tokenPolicyObjType = map[string]attr.Type{
 	"access_token_format":             types.StringType,
 }
 
+var proxyAuthnRequestObjType = map[string]attr.Type{
+	"authn_context":      types.StringType,
+	"issuer_name_suffix": types.StringType,
+}
+
 var proxyConfigObjType = map[string]attr.Type{
 	"acrs": types.SetType{
 		ElemType: types.StringType,
@@ -219,6 +234,16 @@ var appObjType = types.ObjectType{
 		"authentication_schema": types.ObjectType{
 			AttrTypes: authenticationSchemaObjType,
 		},
+		"override_inherited": types.SetType{ElemType: types.StringType},
+		"oidc_client": types.ObjectType{
+			AttrTypes: applicationOidcClientObjType,
+		},
+		"saml2_idp": types.ObjectType{
+			AttrTypes: applicationSaml2IdpObjType,
+		},
+		"api_certificates": types.ListType{
+			ElemType: applicationApiCertificateObjType,
+		},
 		"meta": types.ObjectType{
 			AttrTypes: metaDataObjType,
 		},
@@ -270,6 +295,83 @@ func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 								utils.ValidUUID(),
 							},
 						},
+						"override_inherited": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The properties of the authentication schema for which the values inherited from the parent application are disabled.",
+							Computed:            true,
+						},
+						"oidc_client": schema.SingleNestedAttribute{
+							MarkdownDescription: "The OpenID Connect client registration of the application and the endpoints of the tenant derived from the tenant URL. Only set for applications with the sso_type `openIdConnect` or `saml2oidc`.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"client_id": schema.StringAttribute{
+									MarkdownDescription: "The client ID of the application.",
+									Computed:            true,
+								},
+								"issuer": schema.StringAttribute{
+									MarkdownDescription: "The issuer of the tokens of the tenant.",
+									Computed:            true,
+								},
+								"authorization_endpoint": schema.StringAttribute{
+									MarkdownDescription: "The endpoint to which authorization requests are sent.",
+									Computed:            true,
+								},
+								"token_endpoint": schema.StringAttribute{
+									MarkdownDescription: "The endpoint called to request tokens.",
+									Computed:            true,
+								},
+								"jwks_uri": schema.StringAttribute{
+									MarkdownDescription: "The endpoint called to request the JSON Web Keys of the tenant.",
+									Computed:            true,
+								},
+								"logout_endpoint": schema.StringAttribute{
+									MarkdownDescription: "The endpoint called to end the session of the user.",
+									Computed:            true,
+								},
+							},
+						},
+						"saml2_idp": schema.SingleNestedAttribute{
+							MarkdownDescription: "The SAML 2.0 identity provider endpoints of the tenant derived from the tenant URL. Only set for applications with the sso_type `saml2` or `saml2oidc`. The entity ID is the default one of the tenant, a customized entity ID is shown by the **sci_tenant_saml_metadata** data source.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"entity_id": schema.StringAttribute{
+									MarkdownDescription: "The entity ID of the tenant.",
+									Computed:            true,
+								},
+								"sso_url": schema.StringAttribute{
+									MarkdownDescription: "The single sign-on URL of the tenant.",
+									Computed:            true,
+								},
+								"slo_url": schema.StringAttribute{
+									MarkdownDescription: "The single logout URL of the tenant.",
+									Computed:            true,
+								},
+							},
+						},
+						"api_certificates": schema.ListNestedAttribute{
+							MarkdownDescription: "The certificates the application authenticates with at the APIs of the tenant. The certificates are managed in the tenant, they are read to show their details.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										MarkdownDescription: "The ID of the certificate.",
+										Computed:            true,
+									},
+									"dn": schema.StringAttribute{
+										MarkdownDescription: "The distinguished name of the certificate.",
+										Computed:            true,
+									},
+									"description": schema.StringAttribute{
+										MarkdownDescription: "The description of the certificate.",
+										Computed:            true,
+									},
+									"base64_certificate": schema.StringAttribute{
+										MarkdownDescription: "The content of the Base64 certificate.",
+										Computed:            true,
+									},
+								}),
+							},
+						},
 						"multi_tenant_app": schema.BoolAttribute{
 							MarkdownDescription: "Only for Internal Use",
 							Computed:            true,
@@ -495,6 +597,14 @@ func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 											MarkdownDescription: "The URL with service provider metadata. The metadata URL must not contain a query parameter.",
 											Computed:            true,
 										},
+										"metadata_xml": schema.StringAttribute{
+											MarkdownDescription: "The SAML service provider metadata the configuration has been derived from. The metadata is not stored by the tenant, hence it is not returned.",
+											Computed:            true,
+										},
+										"metadata_signing_certificate": schema.StringAttribute{
+											MarkdownDescription: "The certificate the signature of the SAML service provider metadata has been verified with. The certificate is not stored by the tenant, hence it is not returned.",
+											Computed:            true,
+										},
 										"acs_endpoints": schema.ListNestedAttribute{
 											MarkdownDescription: "Configure the allowed domains for browser flows.",
 											Computed:            true,
@@ -543,7 +653,7 @@ func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 											MarkdownDescription: "Base64-encoded certificates used by the service provider to sign digitally, SAML protocol messages sent to Identity Authentication. A maximum of 2 certificates are allowed.",
 											Computed:            true,
 											NestedObject: schema.NestedAttributeObject{
-												Attributes: map[string]schema.Attribute{
+												Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
 													"base64_certificate": schema.StringAttribute{
 														MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 														Computed:            true,
@@ -564,13 +674,13 @@ func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 														MarkdownDescription: "Set the date uptil which the certificate is valid.",
 														Computed:            true,
 													},
-												},
+												}),
 											},
 										},
 										"encryption_certificate": schema.SingleNestedAttribute{
 											MarkdownDescription: "The certificate used for encryption of SAML2 requests and responses.",
 											Computed:            true,
-											Attributes: map[string]schema.Attribute{
+											Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
 												"base64_certificate": schema.StringAttribute{
 													MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 													Computed:            true,
@@ -587,7 +697,7 @@ func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 													MarkdownDescription: "Set the date uptil which the certificate is valid.",
 													Computed:            true,
 												},
-											},
+											}),
 										},
 										"response_elements_to_encrypt": schema.StringAttribute{
 											MarkdownDescription: "Specify which SAML response elements should be encrypted. " + utils.ValidValuesString(responseElementsToEncrypt),
@@ -621,6 +731,20 @@ func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 											MarkdownDescription: "Configure the algorithm for signing outgoing messages. " + utils.ValidValuesString(digestAlgorithmValues),
 											Computed:            true,
 										},
+										"proxy_authn_request": schema.SingleNestedAttribute{
+											MarkdownDescription: "Configure the SAML 2.0 authentication requests which are sent to the corporate identity provider when the tenant acts as a proxy.",
+											Computed:            true,
+											Attributes: map[string]schema.Attribute{
+												"authn_context": schema.StringAttribute{
+													MarkdownDescription: "The authentication context class reference which is requested from the corporate identity provider.",
+													Computed:            true,
+												},
+												"issuer_name_suffix": schema.StringAttribute{
+													MarkdownDescription: "The suffix appended to the issuer name of the tenant in the authentication requests, so that the corporate identity provider can tell the applications apart.",
+													Computed:            true,
+												},
+											},
+										},
 									},
 								},
 								"sap_managed_attributes": schema.SingleNestedAttribute{
@@ -697,7 +821,7 @@ func (d *applicationsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	resApps := applicationsValueFrom(ctx, res)
+	resApps := applicationsValueFrom(ctx, d.cli.ServerURL, res)
 
 	config.Values, diags = types.ListValueFrom(ctx, appObjType, resApps)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newAuthenticationRuleEvaluationDataSource() datasource.DataSource {
+	return &authenticationRuleEvaluationDataSource{}
+}
+
+type authenticationRuleEvaluationDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *authenticationRuleEvaluationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *authenticationRuleEvaluationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authentication_rule_evaluation"
+}
+
+func (d *authenticationRuleEvaluationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Evaluates the conditional authentication rules of an application from the SAP Cloud Identity Services tenant for a sample user, and shows which rule would match.
+
+The rules are evaluated in order, the user is authenticated by the identity provider of the first matching rule. If no rule matches, the default authenticating identity provider of the application is used. A condition which refers to an attribute not given for the user is not fulfilled.`,
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Unique ID of the application.",
+				Validators: []validator.String{
+					utils.ValidUUID(),
+				},
+			},
+			"user_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The type of the user." + utils.ValidValuesString(usersTypeValues),
+				Validators: []validator.String{
+					stringvalidator.OneOf(usersTypeValues...),
+				},
+			},
+			"user_group_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "IDs of the groups the user is a member of.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(utils.ValidUUID()),
+				},
+			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The email of the user.",
+			},
+			"ip_address": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The IP address the user authenticates from.",
+			},
+			"matched_rule": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The index of the first rule which matches the user, starting at `0`. Null if no rule matches.",
+			},
+			"identity_provider_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identity provider which authenticates the user, either of the matched rule or the default authenticating identity provider of the application.",
+			},
+			"rules": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The result of each rule of the application, in the order of evaluation.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"identity_provider_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The identity provider of the rule.",
+						},
+						"matches": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether all conditions of the rule are fulfilled by the user.",
+						},
+						"failed_conditions": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "The conditions of the rule which are not fulfilled by the user, e.g. `user_group` or `ip_network_range`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *authenticationRuleEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config authenticationRuleEvaluationData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user := authenticationRuleSample{
+		UserType:  config.UserType.ValueString(),
+		Email:     config.Email.ValueString(),
+		IpAddress: config.IpAddress.ValueString(),
+	}
+
+	if !config.UserGroupIds.IsNull() {
+		diags = config.UserGroupIds.ElementsAs(ctx, &user.UserGroupIds, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	res, _, err := d.cli.Application.GetByAppId(ctx, config.ApplicationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving application", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags := authenticationRuleEvaluationValueFrom(ctx, res, user, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
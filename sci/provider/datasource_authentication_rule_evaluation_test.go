@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceAuthenticationRuleEvaluation(t *testing.T) {
+	t.Parallel()
+
+	mockUuid := "af2f7963-358d-4336-bc51-57099394dee7"
+
+	app := applications.Application{
+		Id: mockUuid,
+		AuthenticationSchema: &applications.AuthenticationSchema{
+			DefaultAuthenticatingIdpId: "idp-default",
+			ConditionalAuthentication: []applications.AuthenicationRule{
+				{IdentityProviderId: "idp-partners", UserType: "partner", UserEmailDomain: "*.partner.com"},
+				{IdentityProviderId: "idp-office", UserGroup: mockUuid, IpNetworkRange: "10.0.0.0/8"},
+				{IdentityProviderId: "idp-employees", UserEmailDomain: "example.com"},
+			},
+		},
+	}
+
+	t.Run("error path - application_id needs to be a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceAuthenticationRuleEvaluation("testEvaluation", "not-a-valid-uuid", "employee"),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: not-a-valid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - user_type needs to be a valid value", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceAuthenticationRuleEvaluation("testEvaluation", mockUuid, "invalid"),
+					ExpectError: regexp.MustCompile(`Attribute user_type value must be one of`),
+				},
+			},
+		})
+	})
+
+	t.Run("value - first matching rule", func(t *testing.T) {
+
+		evaluation, diags := authenticationRuleEvaluationValueFrom(context.TODO(), app, authenticationRuleSample{
+			UserType:     "employee",
+			UserGroupIds: []string{mockUuid},
+			Email:        "jane.doe@example.com",
+			IpAddress:    "10.1.2.3",
+		}, authenticationRuleEvaluationData{})
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, types.Int64Value(1), evaluation.MatchedRule)
+		assert.Equal(t, types.StringValue("idp-office"), evaluation.IdentityProviderId)
+
+		var results []authenticationRuleResultData
+		diags = evaluation.Rules.ElementsAs(context.TODO(), &results, false)
+		assert.False(t, diags.HasError())
+
+		// the later rule matches as well, but is not evaluated by the tenant
+		assert.Equal(t, []bool{false, true, true}, []bool{results[0].Matches.ValueBool(), results[1].Matches.ValueBool(), results[2].Matches.ValueBool()})
+
+		var failed []string
+		diags = results[0].FailedConditions.ElementsAs(context.TODO(), &failed, false)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, []string{"user_type", "user_email_domain"}, failed)
+	})
+
+	t.Run("value - wildcard email domain", func(t *testing.T) {
+
+		evaluation, diags := authenticationRuleEvaluationValueFrom(context.TODO(), app, authenticationRuleSample{
+			UserType: "Partner",
+			Email:    "john.doe@eu.partner.com",
+		}, authenticationRuleEvaluationData{})
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, types.Int64Value(0), evaluation.MatchedRule)
+		assert.Equal(t, types.StringValue("idp-partners"), evaluation.IdentityProviderId)
+	})
+
+	t.Run("value - default identity provider if no rule matches", func(t *testing.T) {
+
+		evaluation, diags := authenticationRuleEvaluationValueFrom(context.TODO(), app, authenticationRuleSample{
+			UserGroupIds: []string{mockUuid},
+			Email:        "john.doe@partner.com",
+			IpAddress:    "192.168.0.1",
+		}, authenticationRuleEvaluationData{})
+		assert.False(t, diags.HasError())
+
+		assert.True(t, evaluation.MatchedRule.IsNull())
+		assert.Equal(t, types.StringValue("idp-default"), evaluation.IdentityProviderId)
+	})
+
+	t.Run("value - conditions of a rule", func(t *testing.T) {
+
+		assert.True(t, emailDomainMatches("Example.com", "jane.doe@example.COM"))
+		assert.True(t, emailDomainMatches("*.example.com", "jane.doe@eu.example.com"))
+		assert.False(t, emailDomainMatches("*.example.com", "jane.doe@example.com"))
+		assert.False(t, emailDomainMatches("example.com", "example.com"))
+
+		assert.True(t, ipNetworkRangeContains("10.0.0.0/8", "10.255.0.1"))
+		assert.False(t, ipNetworkRangeContains("10.0.0.0/8", "11.0.0.1"))
+		assert.False(t, ipNetworkRangeContains("10.0.0.0/8", ""))
+	})
+}
+
+func DataSourceAuthenticationRuleEvaluation(datasourceName string, applicationId string, userType string) string {
+	return fmt.Sprintf(`
+	data "sci_authentication_rule_evaluation" "%s" {
+		application_id = "%s"
+		user_type = "%s"
+	}
+	`, datasourceName, applicationId, userType)
+}
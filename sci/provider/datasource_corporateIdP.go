@@ -115,6 +115,18 @@ func (d *corporateIdPDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 						MarkdownDescription: "The URL with identity provider metadata.",
 						Computed:            true,
 					},
+					"metadata_xml": schema.StringAttribute{
+						MarkdownDescription: "The SAML identity provider metadata the configuration has been derived from. The metadata is not stored by the tenant, hence it is not returned.",
+						Computed:            true,
+					},
+					"metadata_file": schema.StringAttribute{
+						MarkdownDescription: "The path of the file with the SAML identity provider metadata the configuration has been derived from. The path is not stored by the tenant, hence it is not returned.",
+						Computed:            true,
+					},
+					"metadata_signing_certificate": schema.StringAttribute{
+						MarkdownDescription: "The certificate the signature of the SAML identity provider metadata has been verified with. The certificate is not stored by the tenant, hence it is not returned.",
+						Computed:            true,
+					},
 					"assertion_attributes": schema.ListNestedAttribute{
 						MarkdownDescription: "Enrich the assertion attributes coming from the corporate IdP.",
 						Computed:            true,
@@ -135,7 +147,7 @@ func (d *corporateIdPDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 						MarkdownDescription: "Base64-encoded certificates used by the service provider to sign digitally, SAML protocol messages sent to Identity Authentication. A maximum of 2 certificates are allowed.",
 						Computed:            true,
 						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
+							Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
 								"base64_certificate": schema.StringAttribute{
 									MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 									Computed:            true,
@@ -156,7 +168,7 @@ func (d *corporateIdPDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 									MarkdownDescription: "Set the date uptil which the certificate is valid.",
 									Computed:            true,
 								},
-							},
+							}),
 						},
 					},
 					"sso_endpoints": schema.ListNestedAttribute{
@@ -304,6 +316,10 @@ func (d *corporateIdPDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 						MarkdownDescription: "Indicates if a client secret is configured or not.",
 						Computed:            true,
 					},
+					"resolve_discovery": schema.BoolAttribute{
+						MarkdownDescription: "Indicates if the discovery document is resolved at plan time. This value is not returned by the API.",
+						Computed:            true,
+					},
 				},
 			},
 		},
@@ -47,9 +47,35 @@ var saml2SigningCertificateObjType = types.ObjectType{
 		"default":            types.BoolType,
 		"valid_from":         types.StringType,
 		"valid_to":           types.StringType,
+		"subject_dn":         types.StringType,
+		"sha1_fingerprint":   types.StringType,
+		"sha256_fingerprint": types.StringType,
+		"not_before":         types.StringType,
+		"not_after":          types.StringType,
 	},
 }
 
+// certificateDetailsDataSourceSchema adds the details read from the X.509 certificate in base64_certificate to the attributes of a certificate
+func certificateDetailsDataSourceSchema(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+
+	details := map[string]string{
+		"subject_dn":         "The subject distinguished name read from the certificate.",
+		"sha1_fingerprint":   "The SHA-1 fingerprint of the certificate.",
+		"sha256_fingerprint": "The SHA-256 fingerprint of the certificate.",
+		"not_before":         "The date from which the certificate is valid, read from the certificate.",
+		"not_after":          "The date until which the certificate is valid, read from the certificate.",
+	}
+
+	for name, description := range details {
+		attributes[name] = schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+		}
+	}
+
+	return attributes
+}
+
 var saml2AssertionAttributeObjType = types.ObjectType{
 	AttrTypes: map[string]attr.Type{
 		"name":  types.StringType,
@@ -76,6 +102,9 @@ var IdPSaml2ConfigObjType = types.ObjectType{
 		"slo_endpoints": types.ListType{
 			ElemType: saml2SloEndpointObjType,
 		},
+		"metadata_xml":                 types.StringType,
+		"metadata_file":                types.StringType,
+		"metadata_signing_certificate": types.StringType,
 	},
 }
 
@@ -107,6 +136,7 @@ var oidcConfigObjType = types.ObjectType{
 		"logout_endpoint":             types.StringType,
 		"user_info_endpoint":          types.StringType,
 		"is_client_secret_configured": types.BoolType,
+		"resolve_discovery":           types.BoolType,
 	},
 }
 
@@ -236,6 +266,18 @@ func (d *corporateIdPsDataSource) Schema(_ context.Context, _ datasource.SchemaR
 									MarkdownDescription: "The URL with identity provider metadata.",
 									Computed:            true,
 								},
+								"metadata_xml": schema.StringAttribute{
+									MarkdownDescription: "The SAML identity provider metadata the configuration has been derived from. The metadata is not stored by the tenant, hence it is not returned.",
+									Computed:            true,
+								},
+								"metadata_file": schema.StringAttribute{
+									MarkdownDescription: "The path of the file with the SAML identity provider metadata the configuration has been derived from. The path is not stored by the tenant, hence it is not returned.",
+									Computed:            true,
+								},
+								"metadata_signing_certificate": schema.StringAttribute{
+									MarkdownDescription: "The certificate the signature of the SAML identity provider metadata has been verified with. The certificate is not stored by the tenant, hence it is not returned.",
+									Computed:            true,
+								},
 								"assertion_attributes": schema.ListNestedAttribute{
 									MarkdownDescription: "Enrich the assertion attributes coming from the corporate IdP.",
 									Computed:            true,
@@ -256,7 +298,7 @@ func (d *corporateIdPsDataSource) Schema(_ context.Context, _ datasource.SchemaR
 									MarkdownDescription: "Base64-encoded certificates used by the service provider to sign digitally, SAML protocol messages sent to Identity Authentication. A maximum of 2 certificates are allowed.",
 									Computed:            true,
 									NestedObject: schema.NestedAttributeObject{
-										Attributes: map[string]schema.Attribute{
+										Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
 											"base64_certificate": schema.StringAttribute{
 												MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 												Computed:            true,
@@ -277,7 +319,7 @@ func (d *corporateIdPsDataSource) Schema(_ context.Context, _ datasource.SchemaR
 												MarkdownDescription: "Set the date uptil which the certificate is valid.",
 												Computed:            true,
 											},
-										},
+										}),
 									},
 								},
 								"sso_endpoints": schema.ListNestedAttribute{
@@ -425,6 +467,10 @@ func (d *corporateIdPsDataSource) Schema(_ context.Context, _ datasource.SchemaR
 									MarkdownDescription: "Indicates if a client secret is configured or not.",
 									Computed:            true,
 								},
+								"resolve_discovery": schema.BoolAttribute{
+									MarkdownDescription: "Indicates if the discovery document is resolved at plan time. This value is not returned by the API.",
+									Computed:            true,
+								},
 							},
 						},
 					},
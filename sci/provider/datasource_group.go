@@ -69,6 +69,11 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					},
 				},
 			},
+			"custom_schemas": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Further enhance your group with custom schemas, as a JSON string with the custom schema IDs as keys.",
+			},
+			"meta": metaDataSourceSchema("group"),
 			"group_extension": schema.SingleNestedAttribute{
 				MarkdownDescription: "Configure attributes particular to the schema `" + defaultGroupSchemas[1].String() + "`.",
 				Computed:            true,
@@ -101,13 +106,13 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	res, _, err := d.cli.Group.GetByGroupId(ctx, config.Id.ValueString())
+	res, customSchemasRes, err := d.cli.Group.GetByGroupId(ctx, config.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving group", fmt.Sprintf("%s", err))
 		return
 	}
 
-	state, diags := groupValueFrom(ctx, res)
+	state, diags := groupValueFrom(ctx, res, customSchemasRes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -53,6 +53,11 @@ func (d *groupBaseDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 				Computed:            true,
 				MarkdownDescription: "Display Name of the group.",
 			},
+			"custom_schemas": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Further enhance your group with custom schemas, as a JSON string with the custom schema IDs as keys.",
+			},
+			"meta": metaDataSourceSchema("group"),
 			"group_extension": schema.SingleNestedAttribute{
 				MarkdownDescription: "Configure attributes particular to the schema `" + defaultGroupSchemas[1].String() + "`.",
 				Computed:            true,
@@ -85,13 +90,13 @@ func (d *groupBaseDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	res, _, err := d.cli.Group.GetByGroupId(ctx, config.Id.ValueString())
+	res, customSchemasRes, err := d.cli.Group.GetByGroupId(ctx, config.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving group", fmt.Sprintf("%s", err))
 		return
 	}
 
-	state, diags := groupBaseValueFrom(ctx, res)
+	state, diags := groupBaseValueFrom(ctx, res, customSchemasRes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
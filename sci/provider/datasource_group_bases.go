@@ -28,6 +28,10 @@ var groupBaseObjType = types.ObjectType{
 		"group_extension": types.ObjectType{
 			AttrTypes: groupExtensionObjType,
 		},
+		"custom_schemas": types.StringType,
+		"meta": types.ObjectType{
+			AttrTypes: scimMetaObjType,
+		},
 	},
 }
 
@@ -75,6 +79,11 @@ func (d *groupBasesDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 							Computed:            true,
 							MarkdownDescription: "Display Name of the group.",
 						},
+						"custom_schemas": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Further enhance your group with custom schemas, as a JSON string with the custom schema IDs as keys.",
+						},
+						"meta": metaDataSourceSchema("group"),
 						"group_extension": schema.SingleNestedAttribute{
 							MarkdownDescription: "Configure attributes particular to the schema `" + defaultGroupSchemas[1].String() + "`.",
 							Computed:            true,
@@ -105,13 +114,13 @@ func (d *groupBasesDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	res, _, err := d.cli.Group.Get(ctx)
+	res, customSchemasRes, err := d.cli.Group.Get(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving groups", fmt.Sprintf("%s", err))
 		return
 	}
 
-	groups, diags := groupBasesValueFrom(ctx, res)
+	groups, diags := groupBasesValueFrom(ctx, res, customSchemasRes)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newGroupEffectiveMembersDataSource() datasource.DataSource {
+	return &groupEffectiveMembersDataSource{}
+}
+
+type groupEffectiveMembersDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *groupEffectiveMembersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *groupEffectiveMembersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_effective_members"
+}
+
+func (d *groupEffectiveMembersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the users which are members of a group from the SAP Cloud Identity Services tenant, either directly or through nested groups.`,
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Unique ID of the group.",
+				Validators: []validator.String{
+					utils.ValidUUID(),
+				},
+			},
+			"max_depth": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum depth up to which nested groups are expanded. The direct members of the group are at depth 1. Defaults to `%d`.", defaultGroupMaxDepth),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"user_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "SCIM IDs of the users which are effective members of the group, sorted and without duplicates.",
+			},
+			"truncated": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether nested groups deeper than `max_depth` were not expanded.",
+			},
+		},
+	}
+}
+
+func (d *groupEffectiveMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config groupEffectiveMembersData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.MaxDepth.IsNull() {
+		config.MaxDepth = types.Int64Value(defaultGroupMaxDepth)
+	}
+
+	userIds, truncated, err := getGroupEffectiveMembers(ctx, newGroupWalker(d.cli), config.GroupId.ValueString(), int(config.MaxDepth.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving group members", fmt.Sprintf("%s", err))
+		return
+	}
+
+	config.UserIds, diags = types.ListValueFrom(ctx, types.StringType, userIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Truncated = types.BoolValue(truncated)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
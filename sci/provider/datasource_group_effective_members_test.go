@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceGroupEffectiveMembers(t *testing.T) {
+	t.Parallel()
+
+	mockUuid := "af2f7963-358d-4336-bc51-57099394dee7"
+
+	// parent -> child -> grandchild, with the users spread across the levels
+	tenantGroups := map[string]groups.Group{
+		"parent": {Id: "parent", GroupMembers: []groups.GroupMember{
+			{Value: "user-1", Type: "User"},
+			{Value: "child", Type: "Group"},
+			{Value: "user-2"},
+		}},
+		"child": {Id: "child", GroupMembers: []groups.GroupMember{
+			{Value: "user-1", Type: "User"},
+			{Value: "user-3", Type: "User"},
+			{Value: "grandchild", Type: "Group"},
+		}},
+		"grandchild": {Id: "grandchild", GroupMembers: []groups.GroupMember{
+			{Value: "user-4", Type: "User"},
+		}},
+	}
+
+	t.Run("error path - invalid group id", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceGroupEffectiveMembers("testMembers", "invalid-uuid", 10),
+					ExpectError: regexp.MustCompile(`Attribute group_id value must be a valid UUID, got: invalid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - invalid max depth", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceGroupEffectiveMembers("testMembers", mockUuid, 0),
+					ExpectError: regexp.MustCompile(`Attribute max_depth value must be between 1 and 100, got: 0`),
+				},
+			},
+		})
+	})
+
+	t.Run("expand - nested groups are flattened", func(t *testing.T) {
+
+		client, srv := groupsTestClient(tenantGroups)
+		defer srv.Close()
+
+		userIds, truncated, err := getGroupEffectiveMembers(context.TODO(), newGroupWalker(client), "parent", 10)
+
+		assert.NoError(t, err)
+		assert.False(t, truncated)
+		assert.Equal(t, []string{"user-1", "user-2", "user-3", "user-4"}, userIds)
+	})
+
+	t.Run("expand - nested groups are limited by the maximum depth", func(t *testing.T) {
+
+		client, srv := groupsTestClient(tenantGroups)
+		defer srv.Close()
+
+		userIds, truncated, err := getGroupEffectiveMembers(context.TODO(), newGroupWalker(client), "parent", 2)
+
+		assert.NoError(t, err)
+		assert.True(t, truncated)
+		assert.Equal(t, []string{"user-1", "user-2", "user-3"}, userIds)
+	})
+
+	t.Run("cycle - adding an ancestor is rejected", func(t *testing.T) {
+
+		client, srv := groupsTestClient(tenantGroups)
+		defer srv.Close()
+
+		cycle, err := findGroupCycle(context.TODO(), newGroupWalker(client), "grandchild", []groups.GroupMember{
+			{Value: "user-5"},
+			{Value: "parent"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"grandchild", "parent", "child", "grandchild"}, cycle)
+
+		cycle, err = findGroupCycle(context.TODO(), newGroupWalker(client), "child", []groups.GroupMember{{Value: "child", Type: "Group"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"child", "child"}, cycle)
+	})
+
+	t.Run("cycle - adding a descendant or a user is accepted", func(t *testing.T) {
+
+		client, srv := groupsTestClient(tenantGroups)
+		defer srv.Close()
+
+		cycle, err := findGroupCycle(context.TODO(), newGroupWalker(client), "parent", []groups.GroupMember{
+			{Value: "grandchild", Type: "Group"},
+			{Value: "user-5"},
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, cycle)
+
+		diags := validateGroupCycles(context.TODO(), client, "grandchild", []groups.GroupMember{{Value: "parent"}}, path.Root("member_ids"))
+		assert.True(t, diags.HasError())
+	})
+}
+
+// groupsTestClient serves the given groups, any other ID is answered with 404
+func groupsTestClient(tenantGroups map[string]groups.Group) (*cli.SciClient, *httptest.Server) {
+	return usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		group, found := tenantGroups[strings.TrimPrefix(r.URL.Path, "/scim/Groups/")]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":"404","detail":"group not found"}`))
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(group)
+	}))
+}
+
+func DataSourceGroupEffectiveMembers(datasourceName string, groupId string, maxDepth int) string {
+	return fmt.Sprintf(`
+	data "sci_group_effective_members" "%s" {
+		group_id = "%s"
+		max_depth = %d
+	}
+	`, datasourceName, groupId, maxDepth)
+}
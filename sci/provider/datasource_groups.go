@@ -42,6 +42,10 @@ var groupObjType = types.ObjectType{
 		"group_extension": types.ObjectType{
 			AttrTypes: groupExtensionObjType,
 		},
+		"custom_schemas": types.StringType,
+		"meta": types.ObjectType{
+			AttrTypes: scimMetaObjType,
+		},
 	},
 }
 
@@ -101,6 +105,11 @@ func (d *groupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 								},
 							},
 						},
+						"custom_schemas": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Further enhance your group with custom schemas, as a JSON string with the custom schema IDs as keys.",
+						},
+						"meta": metaDataSourceSchema("group"),
 						"group_extension": schema.SingleNestedAttribute{
 							MarkdownDescription: "Configure attributes particular to the schema `" + defaultGroupSchemas[1].String() + "`.",
 							Computed:            true,
@@ -132,13 +141,13 @@ func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	res, _, err := d.cli.Group.Get(ctx)
+	res, customSchemasRes, err := d.cli.Group.Get(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving groups", fmt.Sprintf("%s", err))
 		return
 	}
 
-	resGroups, diags := groupsValueFrom(ctx, res)
+	resGroups, diags := groupsValueFrom(ctx, res, customSchemasRes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newInactiveUsersDataSource() datasource.DataSource {
+	return &inactiveUsersDataSource{}
+}
+
+type inactiveUsersDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *inactiveUsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *inactiveUsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inactive_users"
+}
+
+func (d *inactiveUsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the users of the SAP Cloud Identity Services tenant that have not been modified since a given point in time. Users without a modification time are not returned.`,
+		Attributes: map[string]schema.Attribute{
+			"last_modified_before": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Only users last modified before this point in time are returned. The value must be a date-time string in the UTC format YYYY-MM-DDTHH:MM:SSZ.",
+				Validators: []validator.String{
+					utils.ValidDateTime(),
+				},
+			},
+			"values": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The users last modified before `last_modified_before`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the user.",
+						},
+						"user_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Unique user name of the user.",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name to be displayed for the user.",
+						},
+						"active": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Determines whether the user is active or not.",
+						},
+						"meta": metaDataSourceSchema("user"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *inactiveUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config inactiveUsersData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	before, err := time.Parse(time.RFC3339, config.LastModifiedBefore.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid last_modified_before", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// the users are filtered by the provider, as filtering on meta.lastModified is not supported by every tenant
+	res, _, err := d.cli.User.List(ctx, "", usersPageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving users", fmt.Sprintf("%s", err))
+		return
+	}
+
+	inactiveUsers, diags := inactiveUsersValueFrom(ctx, res.Resources, before)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Values, diags = types.ListValueFrom(ctx, inactiveUserObjType, inactiveUsers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceInactiveUsers(t *testing.T) {
+	t.Parallel()
+
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tenantUsers := []users.User{
+		{Id: "stale", UserName: "stale", Active: true, Meta: users.Meta{LastModified: "2023-06-30T12:00:00Z"}},
+		{Id: "recent", UserName: "recent", Active: true, Meta: users.Meta{LastModified: "2025-06-30T12:00:00Z"}},
+		{Id: "deactivated", UserName: "deactivated", DisplayName: "Deactivated User", Meta: users.Meta{LastModified: "2024-12-31T23:59:59+00:00"}},
+		{Id: "unknown", UserName: "unknown"},
+	}
+
+	t.Run("error path - last_modified_before is mandatory", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceInactiveUsers("testInactiveUsers", ""),
+					ExpectError: regexp.MustCompile(`The argument "last_modified_before" is required`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - last_modified_before must be a valid date-time", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceInactiveUsers("testInactiveUsers", `last_modified_before = "2025-01-01"`),
+					ExpectError: regexp.MustCompile(`value must be a valid date-time string`),
+				},
+			},
+		})
+	})
+
+	t.Run("users last modified before", func(t *testing.T) {
+
+		inactiveUsers, diags := inactiveUsersValueFrom(context.TODO(), tenantUsers, before)
+		assert.False(t, diags.HasError())
+
+		assert.Len(t, inactiveUsers, 2)
+		assert.Equal(t, types.StringValue("stale"), inactiveUsers[0].Id)
+		assert.Equal(t, types.BoolValue(true), inactiveUsers[0].Active)
+		assert.True(t, inactiveUsers[0].DisplayName.IsNull())
+		assert.Equal(t, types.StringValue("deactivated"), inactiveUsers[1].Id)
+		assert.Equal(t, types.BoolValue(false), inactiveUsers[1].Active)
+		assert.Equal(t, types.StringValue("Deactivated User"), inactiveUsers[1].DisplayName)
+		assert.False(t, inactiveUsers[1].Meta.IsNull())
+	})
+
+	t.Run("error path - invalid last modification time", func(t *testing.T) {
+
+		tenantUsers := append(tenantUsers, users.User{Id: "invalid", Meta: users.Meta{LastModified: "yesterday"}})
+
+		_, diags := inactiveUsersValueFrom(context.TODO(), tenantUsers, before)
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Detail(), `user invalid: invalid last modification time "yesterday"`)
+	})
+}
+
+func DataSourceInactiveUsers(datasourceName string, arguments string) string {
+	return fmt.Sprintf(`
+	data "sci_inactive_users" "%s" {
+		%s
+	}
+	`, datasourceName, arguments)
+}
@@ -90,6 +90,7 @@ func (d *schemaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							Computed:            true,
 							MarkdownDescription: "Configure if the attribute must be case-sensitive or not.",
 						},
+						"sub_attributes": subAttributesDataSourceSchema(),
 					},
 				},
 			},
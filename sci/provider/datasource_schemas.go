@@ -26,6 +26,23 @@ type schemasData struct {
 	Values types.List `tfsdk:"values"`
 }
 
+var subAttributeObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":        types.StringType,
+		"type":        types.StringType,
+		"multivalued": types.BoolType,
+		"description": types.StringType,
+		"required":    types.BoolType,
+		"canonical_values": types.ListType{
+			ElemType: types.StringType,
+		},
+		"case_exact": types.BoolType,
+		"mutability": types.StringType,
+		"returned":   types.StringType,
+		"uniqueness": types.StringType,
+	},
+}
+
 var attributeObjType = types.ObjectType{
 	AttrTypes: map[string]attr.Type{
 		"name":        types.StringType,
@@ -40,6 +57,9 @@ var attributeObjType = types.ObjectType{
 		"mutability": types.StringType,
 		"returned":   types.StringType,
 		"uniqueness": types.StringType,
+		"sub_attributes": types.ListType{
+			ElemType: subAttributeObjType,
+		},
 	},
 }
 
@@ -131,6 +151,7 @@ func (d *schemasDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 										Computed:            true,
 										MarkdownDescription: "Configure if the attribute must be case-sensitive or not.",
 									},
+									"sub_attributes": subAttributesDataSourceSchema(),
 								},
 							},
 						},
@@ -182,3 +203,56 @@ func (d *schemasDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	diags = resp.State.Set(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 }
+
+// subAttributesDataSourceSchema describes the sub-attributes of the complex attributes of a schema
+func subAttributesDataSourceSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "The sub-attributes of an attribute of type `complex`.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The sub-attribute name.",
+				},
+				"type": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The sub-attribute data type",
+				},
+				"mutability": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Control the Read or Write access of the sub-attribute",
+				},
+				"returned": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Configure how the sub-attribute's value must be returned",
+				},
+				"uniqueness": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Define the context in which the sub-attribute must be unique.",
+				},
+				"canonical_values": schema.ListAttribute{
+					ElementType:         types.StringType,
+					Computed:            true,
+					MarkdownDescription: "A collection of suggested canonical values that may be used",
+				},
+				"multivalued": schema.BoolAttribute{
+					Computed:            true,
+					MarkdownDescription: "Configure if the sub-attribute can have more than one value.",
+				},
+				"description": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "A brief description for the sub-attribute",
+				},
+				"required": schema.BoolAttribute{
+					Computed:            true,
+					MarkdownDescription: "Configure if the sub-attribute must be mandatory or not.",
+				},
+				"case_exact": schema.BoolAttribute{
+					Computed:            true,
+					MarkdownDescription: "Configure if the sub-attribute must be case-sensitive or not.",
+				},
+			},
+		},
+	}
+}
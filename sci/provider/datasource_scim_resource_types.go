@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newScimResourceTypesDataSource() datasource.DataSource {
+	return &scimResourceTypesDataSource{}
+}
+
+type scimResourceTypesDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *scimResourceTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *scimResourceTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scim_resource_types"
+}
+
+func (d *scimResourceTypesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the list of SCIM resource types supported by the SAP Cloud Identity services tenant.`,
+		Attributes: map[string]schema.Attribute{
+			"values": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Unique id of the resource type",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the resource type",
+							Computed:            true,
+						},
+						"endpoint": schema.StringAttribute{
+							MarkdownDescription: "Endpoint of the resource type, relative to the SCIM base URL",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "A description for the resource type",
+							Computed:            true,
+						},
+						"schema": schema.StringAttribute{
+							MarkdownDescription: "Id of the core schema of the resource type",
+							Computed:            true,
+						},
+						"schema_extensions": schema.ListNestedAttribute{
+							MarkdownDescription: "Schemas extending the core schema of the resource type",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"schema": schema.StringAttribute{
+										MarkdownDescription: "Id of the schema extension",
+										Computed:            true,
+									},
+									"required": schema.BoolAttribute{
+										MarkdownDescription: "Whether the schema extension is required for the resource type",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *scimResourceTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config resourceTypesData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := d.cli.GetResourceTypes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving resource types", fmt.Sprintf("%s", err))
+		return
+	}
+
+	resResourceTypes, diags := resourceTypesValueFrom(ctx, res)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Values, diags = types.ListValueFrom(ctx, resourceTypeObjType, resResourceTypes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func newScimServiceProviderConfigDataSource() datasource.DataSource {
+	return &scimServiceProviderConfigDataSource{}
+}
+
+type scimServiceProviderConfigDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *scimServiceProviderConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *scimServiceProviderConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scim_service_provider_config"
+}
+
+func (d *scimServiceProviderConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the SCIM service provider configuration of the SAP Cloud Identity services tenant, describing the SCIM features supported by the tenant.`,
+		Attributes: map[string]schema.Attribute{
+			"documentation_uri": schema.StringAttribute{
+				MarkdownDescription: "URL of the help documentation of the service provider",
+				Computed:            true,
+			},
+			"patch_supported": schema.BoolAttribute{
+				MarkdownDescription: "Whether the PATCH operation is supported",
+				Computed:            true,
+			},
+			"change_password_supported": schema.BoolAttribute{
+				MarkdownDescription: "Whether the change of passwords is supported",
+				Computed:            true,
+			},
+			"sort_supported": schema.BoolAttribute{
+				MarkdownDescription: "Whether the sorting of results is supported",
+				Computed:            true,
+			},
+			"etag_supported": schema.BoolAttribute{
+				MarkdownDescription: "Whether ETags are supported for resource versioning",
+				Computed:            true,
+			},
+			"bulk": schema.SingleNestedAttribute{
+				MarkdownDescription: "Configuration of the bulk endpoint",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"supported": schema.BoolAttribute{
+						MarkdownDescription: "Whether the bulk endpoint is supported",
+						Computed:            true,
+					},
+					"max_operations": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of operations in a single bulk request",
+						Computed:            true,
+					},
+					"max_payload_size": schema.Int64Attribute{
+						MarkdownDescription: "Maximum size of a single bulk request, in bytes",
+						Computed:            true,
+					},
+				},
+			},
+			"filter": schema.SingleNestedAttribute{
+				MarkdownDescription: "Configuration of the filtering of resources",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"supported": schema.BoolAttribute{
+						MarkdownDescription: "Whether filters are supported",
+						Computed:            true,
+					},
+					"max_results": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of resources returned in a single response",
+						Computed:            true,
+					},
+				},
+			},
+			"pagination": schema.SingleNestedAttribute{
+				MarkdownDescription: "Configuration of the pagination of resources. The attribute is only set if the tenant advertises it.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"cursor": schema.BoolAttribute{
+						MarkdownDescription: "Whether cursor-based pagination is supported",
+						Computed:            true,
+					},
+					"index": schema.BoolAttribute{
+						MarkdownDescription: "Whether index-based pagination is supported",
+						Computed:            true,
+					},
+					"default_pagination_method": schema.StringAttribute{
+						MarkdownDescription: "Pagination method used if the request does not specify one",
+						Computed:            true,
+					},
+					"default_page_size": schema.Int64Attribute{
+						MarkdownDescription: "Number of resources returned in a page if the request does not specify a page size",
+						Computed:            true,
+					},
+					"max_page_size": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of resources returned in a page",
+						Computed:            true,
+					},
+					"cursor_timeout": schema.Int64Attribute{
+						MarkdownDescription: "Number of seconds for which a cursor remains valid",
+						Computed:            true,
+					},
+				},
+			},
+			"authentication_schemes": schema.ListNestedAttribute{
+				MarkdownDescription: "Authentication schemes supported by the service provider",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the authentication scheme",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the authentication scheme",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the authentication scheme",
+							Computed:            true,
+						},
+						"spec_uri": schema.StringAttribute{
+							MarkdownDescription: "URL of the specification of the authentication scheme",
+							Computed:            true,
+						},
+						"documentation_uri": schema.StringAttribute{
+							MarkdownDescription: "URL of the help documentation of the authentication scheme",
+							Computed:            true,
+						},
+						"primary": schema.BoolAttribute{
+							MarkdownDescription: "Whether the authentication scheme is the preferred one",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *scimServiceProviderConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config serviceProviderConfigData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := d.cli.GetServiceProviderConfig(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving service provider configuration", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags := serviceProviderConfigValueFrom(ctx, res)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/bulk"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/discovery"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceScimServiceProviderConfig(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("value - capabilities of the tenant", func(t *testing.T) {
+
+		config, diags := serviceProviderConfigValueFrom(context.TODO(), discovery.ServiceProviderConfig{
+			Patch:  discovery.Supported{Supported: true},
+			Bulk:   bulk.Limits{Supported: true, MaxOperations: 1000, MaxPayloadSize: 1048576},
+			Filter: discovery.Filter{Supported: true, MaxResults: 100},
+			Pagination: &discovery.Pagination{
+				Cursor:                  true,
+				Index:                   true,
+				DefaultPaginationMethod: discovery.PaginationMethodIndex,
+				MaxPageSize:             100,
+			},
+			AuthenticationSchemes: []discovery.AuthenticationScheme{
+				{Type: "httpbasic", Name: "HTTP Basic", Primary: true},
+				{Type: "oauthbearertoken", Name: "OAuth Bearer Token"},
+			},
+		})
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.BoolValue(true), config.PatchSupported)
+		assert.Equal(t, types.BoolValue(false), config.EtagSupported)
+		assert.Equal(t, types.Int64Value(1000), config.Bulk.Attributes()["max_operations"])
+		assert.Equal(t, types.Int64Value(100), config.Filter.Attributes()["max_results"])
+		assert.Equal(t, types.StringValue("index"), config.Pagination.Attributes()["default_pagination_method"])
+		assert.Len(t, config.AuthenticationSchemes.Elements(), 2)
+	})
+
+	t.Run("value - pagination not advertised", func(t *testing.T) {
+
+		config, diags := serviceProviderConfigValueFrom(context.TODO(), discovery.ServiceProviderConfig{})
+
+		assert.False(t, diags.HasError())
+		assert.True(t, config.Pagination.IsNull())
+		assert.Empty(t, config.AuthenticationSchemes.Elements())
+	})
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newStaleGroupsDataSource() datasource.DataSource {
+	return &staleGroupsDataSource{}
+}
+
+type staleGroupsDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *staleGroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *staleGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stale_groups"
+}
+
+func (d *staleGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the groups of the SAP Cloud Identity Services tenant that have not been modified since a given point in time. Groups without a modification time are not returned.`,
+		Attributes: map[string]schema.Attribute{
+			"last_modified_before": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Only groups last modified before this point in time are returned. The value must be a date-time string in the UTC format YYYY-MM-DDTHH:MM:SSZ.",
+				Validators: []validator.String{
+					utils.ValidDateTime(),
+				},
+			},
+			"values": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The groups last modified before `last_modified_before`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the group.",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Display name of the group.",
+						},
+						"members": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of direct members of the group.",
+						},
+						"meta": metaDataSourceSchema("group"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *staleGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config staleGroupsData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	before, err := time.Parse(time.RFC3339, config.LastModifiedBefore.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid last_modified_before", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// the groups are filtered by the provider, as filtering on meta.lastModified is not supported by every tenant
+	res, err := d.cli.Group.List(ctx, "", groupsPageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving groups", fmt.Sprintf("%s", err))
+		return
+	}
+
+	staleGroups, diags := staleGroupsValueFrom(ctx, res.Resources, before)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Values, diags = types.ListValueFrom(ctx, staleGroupObjType, staleGroups)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceStaleGroups(t *testing.T) {
+	t.Parallel()
+
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tenantGroups := []groups.Group{
+		{Id: "stale", DisplayName: "Stale", Meta: users.Meta{LastModified: "2023-06-30T12:00:00Z"}},
+		{Id: "recent", DisplayName: "Recent", Meta: users.Meta{LastModified: "2025-06-30T12:00:00Z"}},
+		{Id: "team", DisplayName: "Team", GroupMembers: []groups.GroupMember{{Value: "user-id", Type: "User"}}, Meta: users.Meta{LastModified: "2024-02-01T08:00:00Z"}},
+		{Id: "unknown", DisplayName: "Unknown"},
+	}
+
+	t.Run("error path - last_modified_before must be a valid date-time", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceStaleGroups("testStaleGroups", `last_modified_before = "last year"`),
+					ExpectError: regexp.MustCompile(`value must be a valid date-time string`),
+				},
+			},
+		})
+	})
+
+	t.Run("groups last modified before", func(t *testing.T) {
+
+		staleGroups, diags := staleGroupsValueFrom(context.TODO(), tenantGroups, before)
+		assert.False(t, diags.HasError())
+
+		assert.Len(t, staleGroups, 2)
+		assert.Equal(t, types.StringValue("stale"), staleGroups[0].Id)
+		assert.Equal(t, types.Int64Value(0), staleGroups[0].Members)
+		assert.Equal(t, types.StringValue("team"), staleGroups[1].Id)
+		assert.Equal(t, types.Int64Value(1), staleGroups[1].Members)
+	})
+
+	t.Run("no stale groups", func(t *testing.T) {
+
+		staleGroups, diags := staleGroupsValueFrom(context.TODO(), tenantGroups, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.False(t, diags.HasError())
+		assert.Empty(t, staleGroups)
+	})
+}
+
+func DataSourceStaleGroups(datasourceName string, arguments string) string {
+	return fmt.Sprintf(`
+	data "sci_stale_groups" "%s" {
+		%s
+	}
+	`, datasourceName, arguments)
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newTenantOidcConfigurationDataSource() datasource.DataSource {
+	return &tenantOidcConfigurationDataSource{}
+}
+
+type tenantOidcConfigurationDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *tenantOidcConfigurationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *tenantOidcConfigurationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_oidc_configuration"
+}
+
+func (d *tenantOidcConfigurationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the OpenID configuration of the SAP Cloud Identity Services tenant and its JSON Web Key Set, which relying parties use to establish trust with the tenant.`,
+		Attributes: map[string]schema.Attribute{
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "The issuer of the tokens of the tenant.",
+				Computed:            true,
+			},
+			"authorization_endpoint": schema.StringAttribute{
+				MarkdownDescription: "The endpoint to which authorization requests are sent.",
+				Computed:            true,
+			},
+			"token_endpoint": schema.StringAttribute{
+				MarkdownDescription: "The endpoint called to request tokens.",
+				Computed:            true,
+			},
+			"user_info_endpoint": schema.StringAttribute{
+				MarkdownDescription: "The endpoint called to get information about a user.",
+				Computed:            true,
+			},
+			"logout_endpoint": schema.StringAttribute{
+				MarkdownDescription: "The endpoint called to log out the current user session.",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "The endpoint called to request the JSON Web Keys of the tenant.",
+				Computed:            true,
+			},
+			"jwks": schema.StringAttribute{
+				MarkdownDescription: "The JSON Web Key Set of the tenant, used to validate the tokens it issues. The key set is only read if the `jwks_uri` is an endpoint of the tenant, as it is requested with the credentials of the provider.",
+				Computed:            true,
+			},
+			"scopes_supported": schema.ListAttribute{
+				MarkdownDescription: "The scopes supported by the tenant.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"response_types_supported": schema.ListAttribute{
+				MarkdownDescription: "The response types supported by the tenant.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"grant_types_supported": schema.ListAttribute{
+				MarkdownDescription: "The grant types supported by the tenant.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"subject_types_supported": schema.ListAttribute{
+				MarkdownDescription: "The subject identifier types supported by the tenant.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"id_token_signing_alg_values_supported": schema.ListAttribute{
+				MarkdownDescription: "The algorithms used by the tenant to sign ID tokens.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"token_endpoint_auth_methods_supported": schema.ListAttribute{
+				MarkdownDescription: "The client authentication methods supported by the token endpoint.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"claims_supported": schema.ListAttribute{
+				MarkdownDescription: "The claims the tenant may supply values for.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *tenantOidcConfigurationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config tenantOidcConfigurationData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := d.cli.Tenant.GetOidcConfiguration(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving OpenID configuration of the tenant", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags := tenantOidcConfigurationValueFrom(ctx, res)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/tenant"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceTenantOidcConfiguration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+
+		requireCassette(t, "fixtures/datasource_tenant_oidc_configuration")
+
+		rec, user := setupVCR(t, "fixtures/datasource_tenant_oidc_configuration")
+		defer stopQuietly(rec)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + DataSourceTenantOidcConfiguration("testTenant"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.sci_tenant_oidc_configuration.testTenant", "issuer", "https://iasprovidertestblr.accounts400.ondemand.com"),
+						resource.TestCheckResourceAttr("data.sci_tenant_oidc_configuration.testTenant", "authorization_endpoint", "https://iasprovidertestblr.accounts400.ondemand.com/oauth2/authorize"),
+						resource.TestCheckResourceAttr("data.sci_tenant_oidc_configuration.testTenant", "token_endpoint", "https://iasprovidertestblr.accounts400.ondemand.com/oauth2/token"),
+						resource.TestCheckResourceAttr("data.sci_tenant_oidc_configuration.testTenant", "jwks_uri", "https://iasprovidertestblr.accounts400.ondemand.com/oauth2/certs"),
+						resource.TestCheckResourceAttrSet("data.sci_tenant_oidc_configuration.testTenant", "jwks"),
+						resource.TestCheckTypeSetElemAttr("data.sci_tenant_oidc_configuration.testTenant", "scopes_supported.*", "openid"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("value - configuration of the tenant", func(t *testing.T) {
+
+		data, diags := tenantOidcConfigurationValueFrom(context.TODO(), tenant.OIDCConfiguration{
+			Issuer:                            "https://tenant.accounts.ondemand.com",
+			AuthorizationEndpoint:             "https://tenant.accounts.ondemand.com/oauth2/authorize",
+			TokenEndpoint:                     "https://tenant.accounts.ondemand.com/oauth2/token",
+			UserInfoEndpoint:                  "https://tenant.accounts.ondemand.com/oauth2/userinfo",
+			EndSessionEndpoint:                "https://tenant.accounts.ondemand.com/oauth2/logout",
+			JwksUri:                           "https://tenant.accounts.ondemand.com/oauth2/certs",
+			ScopesSupported:                   []string{"openid", "email"},
+			TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "private_key_jwt"},
+			Jwks:                              `{"keys":[{"kty":"RSA","kid":"key-1"}]}`,
+		})
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.StringValue("https://tenant.accounts.ondemand.com"), data.Issuer)
+		assert.Equal(t, types.StringValue("https://tenant.accounts.ondemand.com/oauth2/logout"), data.LogoutEndpoint)
+		assert.Equal(t, types.StringValue(`{"keys":[{"kty":"RSA","kid":"key-1"}]}`), data.Jwks)
+		assert.Len(t, data.ScopesSupported.Elements(), 2)
+		assert.Len(t, data.TokenEndpointAuthMethodsSupported.Elements(), 2)
+		assert.Empty(t, data.ClaimsSupported.Elements())
+	})
+
+	t.Run("value - endpoints not advertised", func(t *testing.T) {
+
+		data, diags := tenantOidcConfigurationValueFrom(context.TODO(), tenant.OIDCConfiguration{
+			Issuer: "https://tenant.accounts.ondemand.com",
+		})
+
+		assert.False(t, diags.HasError())
+		assert.True(t, data.LogoutEndpoint.IsNull())
+		assert.True(t, data.JwksUri.IsNull())
+		assert.True(t, data.Jwks.IsNull())
+	})
+}
+
+func DataSourceTenantOidcConfiguration(datasourceName string) string {
+	return fmt.Sprintf(`
+		data "sci_tenant_oidc_configuration" "%s" {}
+	`, datasourceName)
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newTenantSamlMetadataDataSource() datasource.DataSource {
+	return &tenantSamlMetadataDataSource{}
+}
+
+type tenantSamlMetadataDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *tenantSamlMetadataDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *tenantSamlMetadataDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_saml_metadata"
+}
+
+func (d *tenantSamlMetadataDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the SAML metadata of the SAP Cloud Identity Services tenant acting as identity provider, which service providers use to establish trust with the tenant.`,
+		Attributes: map[string]schema.Attribute{
+			"metadata_xml": schema.StringAttribute{
+				MarkdownDescription: "The SAML metadata of the tenant as XML document.",
+				Computed:            true,
+			},
+			"entity_id": schema.StringAttribute{
+				MarkdownDescription: "The entity ID of the tenant.",
+				Computed:            true,
+			},
+			"sso_endpoints": schema.ListNestedAttribute{
+				MarkdownDescription: "The single sign-on endpoints of the tenant.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"binding_name": schema.StringAttribute{
+							MarkdownDescription: "The SAML binding of the endpoint.",
+							Computed:            true,
+						},
+						"location": schema.StringAttribute{
+							MarkdownDescription: "The URL of the endpoint.",
+							Computed:            true,
+						},
+						"default": schema.BoolAttribute{
+							MarkdownDescription: "Indicates if the endpoint is the default one.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"slo_endpoints": schema.ListNestedAttribute{
+				MarkdownDescription: "The single logout endpoints of the tenant.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"binding_name": schema.StringAttribute{
+							MarkdownDescription: "The SAML binding of the endpoint.",
+							Computed:            true,
+						},
+						"location": schema.StringAttribute{
+							MarkdownDescription: "The URL of the endpoint.",
+							Computed:            true,
+						},
+						"response_location": schema.StringAttribute{
+							MarkdownDescription: "The URL to which logout responses are sent.",
+							Computed:            true,
+						},
+						"default": schema.BoolAttribute{
+							MarkdownDescription: "Indicates if the endpoint is the default one.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"signing_certificates": schema.ListNestedAttribute{
+				MarkdownDescription: "The certificates used by the tenant to sign SAML messages.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: certificateDetailsDataSourceSchema(map[string]schema.Attribute{
+						"base64_certificate": schema.StringAttribute{
+							MarkdownDescription: "The content of the Base64 certificate.",
+							Computed:            true,
+						},
+						"dn": schema.StringAttribute{
+							MarkdownDescription: "The subject of the certificate.",
+							Computed:            true,
+						},
+						"default": schema.BoolAttribute{
+							MarkdownDescription: "Indicates if the certificate is the default one.",
+							Computed:            true,
+						},
+						"valid_from": schema.StringAttribute{
+							MarkdownDescription: "The date from which the certificate is valid.",
+							Computed:            true,
+						},
+						"valid_to": schema.StringAttribute{
+							MarkdownDescription: "The date until which the certificate is valid.",
+							Computed:            true,
+						},
+					}),
+				},
+			},
+			"name_id_formats": schema.ListAttribute{
+				MarkdownDescription: "The name ID formats supported by the tenant.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *tenantSamlMetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config tenantSamlMetadataData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadataXml, err := d.cli.Tenant.GetSamlMetadata(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving SAML metadata of the tenant", fmt.Sprintf("%s", err))
+		return
+	}
+
+	metadata, err := utils.ParseSamlIdpMetadata(metadataXml)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid SAML metadata of the tenant", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags := tenantSamlMetadataValueFrom(ctx, metadataXml, metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
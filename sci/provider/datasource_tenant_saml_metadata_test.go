@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceTenantSamlMetadata(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+
+		requireCassette(t, "fixtures/datasource_tenant_saml_metadata")
+
+		rec, user := setupVCR(t, "fixtures/datasource_tenant_saml_metadata")
+		defer stopQuietly(rec)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + DataSourceTenantSamlMetadata("testTenant"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrSet("data.sci_tenant_saml_metadata.testTenant", "metadata_xml"),
+						resource.TestCheckResourceAttr("data.sci_tenant_saml_metadata.testTenant", "entity_id", "iasprovidertestblr.accounts400.ondemand.com"),
+						resource.TestCheckResourceAttrSet("data.sci_tenant_saml_metadata.testTenant", "sso_endpoints.0.location"),
+						resource.TestCheckResourceAttrSet("data.sci_tenant_saml_metadata.testTenant", "signing_certificates.0.base64_certificate"),
+						resource.TestCheckResourceAttrSet("data.sci_tenant_saml_metadata.testTenant", "signing_certificates.0.valid_to"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("value - metadata of the tenant", func(t *testing.T) {
+
+		ctx := context.TODO()
+		metadataXml := samlIdpMetadataXml(t)
+
+		metadata, err := utils.ParseSamlIdpMetadata(metadataXml)
+		assert.NoError(t, err)
+
+		data, diags := tenantSamlMetadataValueFrom(ctx, metadataXml, metadata)
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, types.StringValue(metadataXml), data.MetadataXml)
+		assert.Equal(t, types.StringValue("https://idp.example.com/metadata"), data.EntityId)
+
+		// the endpoints are not restricted to the bindings supported by corporate IdPs
+		var ssoEndpoints []corporateidps.SAML2SSOEndpoint
+		data.SsoEndpoints.ElementsAs(ctx, &ssoEndpoints, false)
+		assert.Len(t, ssoEndpoints, 3)
+		assert.Equal(t, "urn:oasis:names:tc:SAML:2.0:bindings:PAOS", ssoEndpoints[2].BindingName)
+
+		var sloEndpoints []corporateidps.SAML2SLOEndpoint
+		data.SloEndpoints.ElementsAs(ctx, &sloEndpoints, false)
+		assert.Equal(t, []corporateidps.SAML2SLOEndpoint{
+			{
+				BindingName: "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST",
+				Location:    "https://idp.example.com/saml/slo",
+				IsDefault:   true,
+			},
+		}, sloEndpoints)
+
+		var signingCertificates []corporateidps.SigningCertificateData
+		data.SigningCertificates.ElementsAs(ctx, &signingCertificates, false)
+		assert.Len(t, signingCertificates, 1)
+		assert.Equal(t, "CN=sp.example.com", signingCertificates[0].Dn)
+
+		assert.Empty(t, data.NameIdFormats.Elements())
+	})
+}
+
+func DataSourceTenantSamlMetadata(datasourceName string) string {
+	return fmt.Sprintf(`
+		data "sci_tenant_saml_metadata" "%s" {}
+	`, datasourceName)
+}
@@ -153,6 +153,7 @@ func (d *userDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 					},
 				},
 			},
+			"meta": metaDataSourceSchema("user"),
 		},
 	}
 }
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newUserMembershipsDataSource() datasource.DataSource {
+	return &userMembershipsDataSource{}
+}
+
+type userMembershipsDataSource struct {
+	cli *cli.SciClient
+}
+
+func (d *userMembershipsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (d *userMembershipsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_memberships"
+}
+
+func (d *userMembershipsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Gets the groups a user is a member of from the SAP Cloud Identity Services tenant, either directly or through nested groups.`,
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Unique ID of the user. Either `user_id` or `user_name` must be specified.",
+				Validators: []validator.String{
+					utils.ValidUUID(),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("user_name")),
+				},
+			},
+			"user_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Unique user name of the user. Either `user_id` or `user_name` must be specified.",
+			},
+			"include_nested": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Set to `true` to include the groups the user is a member of through nested groups. Resolving the nested memberships reads all groups of the tenant. Defaults to `false`.",
+			},
+			"max_depth": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum depth up to which nested memberships are resolved. The direct memberships are at depth 1. Defaults to `%d`.", defaultGroupMaxDepth),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"groups": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The groups the user is a member of.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Unique ID of the group.",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Display name of the group.",
+						},
+						"direct": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the user is a direct member of the group.",
+						},
+						"depth": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Depth of the membership, 1 for direct memberships.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *userMembershipsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var config userMembershipsData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.IncludeNested.IsNull() {
+		config.IncludeNested = types.BoolValue(false)
+	}
+
+	if config.MaxDepth.IsNull() {
+		config.MaxDepth = types.Int64Value(defaultGroupMaxDepth)
+	}
+
+	user, err := getUserByIdOrName(ctx, d.cli, config.UserId.ValueString(), config.UserName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving user", fmt.Sprintf("%s", err))
+		return
+	}
+
+	var allGroups []groups.Group
+	if config.IncludeNested.ValueBool() {
+		res, err := d.cli.Group.List(ctx, "", groupsPageSize)
+		if err != nil {
+			resp.Diagnostics.AddError("Error retrieving groups", fmt.Sprintf("%s", err))
+			return
+		}
+		allGroups = res.Resources
+	}
+
+	memberships := getUserMemberships(user, allGroups, config.IncludeNested.ValueBool(), int(config.MaxDepth.ValueInt64()))
+
+	config.UserId = types.StringValue(user.Id)
+	config.UserName = types.StringValue(user.UserName)
+
+	config.Groups, diags = types.ListValueFrom(ctx, userMembershipObjType, memberships)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
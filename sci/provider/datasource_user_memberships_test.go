@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceUserMemberships(t *testing.T) {
+	t.Parallel()
+
+	user := users.User{
+		Id: "user-id",
+		Groups: []users.Group{
+			{Value: "team", Display: "Team", Type: "direct"},
+		},
+	}
+
+	// team -> department -> company, the user is a member of the team
+	tenantGroups := []groups.Group{
+		{Id: "team", DisplayName: "Team", GroupMembers: []groups.GroupMember{{Value: "user-id", Type: "User"}}},
+		{Id: "department", DisplayName: "Department", GroupMembers: []groups.GroupMember{{Value: "team", Type: "Group"}}},
+		{Id: "company", DisplayName: "Company", GroupMembers: []groups.GroupMember{{Value: "department", Type: "Group"}, {Value: "team", Type: "Group"}}},
+		{Id: "other", DisplayName: "Other", GroupMembers: []groups.GroupMember{{Value: "other-user-id", Type: "User"}}},
+	}
+
+	t.Run("error path - user_id and user_name are exclusive", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceUserMemberships("testMemberships", `user_id = "af2f7963-358d-4336-bc51-57099394dee7"`+"\n"+`user_name = "jdoe"`),
+					ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - user_id or user_name is mandatory", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      DataSourceUserMemberships("testMemberships", `include_nested = true`),
+					ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+				},
+			},
+		})
+	})
+
+	t.Run("memberships - direct groups only", func(t *testing.T) {
+
+		memberships := getUserMemberships(user, nil, false, defaultGroupMaxDepth)
+
+		assert.Equal(t, []userMembershipData{
+			{
+				GroupId:     types.StringValue("team"),
+				DisplayName: types.StringValue("Team"),
+				Direct:      types.BoolValue(true),
+				Depth:       types.Int64Value(1),
+			},
+		}, memberships)
+	})
+
+	t.Run("memberships - nested groups", func(t *testing.T) {
+
+		memberships := getUserMemberships(user, tenantGroups, true, defaultGroupMaxDepth)
+
+		assert.Len(t, memberships, 3)
+		assert.Equal(t, types.StringValue("team"), memberships[0].GroupId)
+		assert.Equal(t, types.StringValue("company"), memberships[1].GroupId)
+		assert.Equal(t, types.Int64Value(2), memberships[1].Depth)
+		assert.Equal(t, types.BoolValue(false), memberships[1].Direct)
+		assert.Equal(t, types.StringValue("department"), memberships[2].GroupId)
+	})
+
+	t.Run("memberships - nested groups limited by the maximum depth", func(t *testing.T) {
+
+		user := users.User{Id: "other-user-id", Groups: []users.Group{{Value: "other"}}}
+		tenantGroups := append(tenantGroups, groups.Group{Id: "parent", GroupMembers: []groups.GroupMember{{Value: "other"}}})
+
+		memberships := getUserMemberships(user, tenantGroups, true, 1)
+
+		assert.Len(t, memberships, 1)
+		assert.Equal(t, types.StringValue("other"), memberships[0].GroupId)
+	})
+}
+
+func DataSourceUserMemberships(datasourceName string, arguments string) string {
+	return fmt.Sprintf(`
+	data "sci_user_memberships" "%s" {
+		%s
+	}
+	`, datasourceName, arguments)
+}
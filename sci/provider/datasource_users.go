@@ -37,6 +37,14 @@ var nameObjType = map[string]attr.Type{
 	"honorific_prefix": types.StringType,
 }
 
+var scimMetaObjType = map[string]attr.Type{
+	"resource_type": types.StringType,
+	"created":       types.StringType,
+	"last_modified": types.StringType,
+	"location":      types.StringType,
+	"version":       types.StringType,
+}
+
 var emailObjType = types.ObjectType{
 	AttrTypes: map[string]attr.Type{
 		"value":   types.StringType,
@@ -77,6 +85,9 @@ var userObjType = types.ObjectType{
 		"groups": types.ListType{
 			ElemType: groupListObjType,
 		},
+		"meta": types.ObjectType{
+			AttrTypes: scimMetaObjType,
+		},
 	},
 }
 
@@ -217,6 +228,7 @@ func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 								},
 							},
 						},
+						"meta": metaDataSourceSchema("user"),
 					},
 				},
 				Computed: true,
@@ -251,3 +263,33 @@ func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	diags = resp.State.Set(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 }
+
+// metaDataSourceSchema describes the SCIM meta attributes maintained by the tenant for a user or group
+func metaDataSourceSchema(resourceName string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Metadata of the " + resourceName + " maintained by the tenant.",
+		Computed:            true,
+		Attributes: map[string]schema.Attribute{
+			"resource_type": schema.StringAttribute{
+				MarkdownDescription: "The SCIM resource type of the " + resourceName + ".",
+				Computed:            true,
+			},
+			"created": schema.StringAttribute{
+				MarkdownDescription: "The date and time the " + resourceName + " was created, in RFC3339 format.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "The date and time the " + resourceName + " was last modified, in RFC3339 format.",
+				Computed:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "The URI of the " + resourceName + ".",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The version of the " + resourceName + ".",
+				Computed:            true,
+			},
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var samlMetadataObjType = map[string]attr.Type{
+	"entity_id": types.StringType,
+	"acs_endpoints": types.ListType{
+		ElemType: acsEndpointsObjType,
+	},
+	"slo_endpoints": types.ListType{
+		ElemType: appSaml2SloEndpointObjType,
+	},
+	"signing_certificates": types.ListType{
+		ElemType: saml2SigningCertificateObjType,
+	},
+	"encryption_certificate": saml2EncryptionCertificateObjType,
+	"default_name_id_format": types.StringType,
+}
+
+type samlMetadataData struct {
+	EntityId                 types.String `tfsdk:"entity_id"`
+	AcsEndpoints             types.List   `tfsdk:"acs_endpoints"`
+	SloEndpoints             types.List   `tfsdk:"slo_endpoints"`
+	CertificatesForSigning   types.List   `tfsdk:"signing_certificates"`
+	CertificateForEncryption types.Object `tfsdk:"encryption_certificate"`
+	DefaultNameIdFormat      types.String `tfsdk:"default_name_id_format"`
+}
+
+func newParseSamlMetadataFunction() function.Function {
+	return &parseSamlMetadataFunction{}
+}
+
+type parseSamlMetadataFunction struct{}
+
+func (f *parseSamlMetadataFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_saml_metadata"
+}
+
+func (f *parseSamlMetadataFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the SAML metadata of a service provider.",
+		MarkdownDescription: "Parses the metadata of a SAML service provider, an `EntityDescriptor` XML document, into the values that `sci_application` derives from the `metadata_xml` of its `saml2_config`. " +
+			"Endpoints with bindings that are not supported are ignored, the default Name ID format is the first supported format of the metadata. " +
+			"Neither the signature nor the expiry of the metadata are checked, as the function is evaluated on every plan. `sci_application` checks them when its `metadata_xml` is planned, the signature is verified with its `metadata_signing_certificate`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "metadata_xml",
+				MarkdownDescription: "The metadata of the SAML service provider.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: samlMetadataObjType,
+		},
+	}
+}
+
+func (f *parseSamlMetadataFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var metadataXml string
+	resp.Error = req.Arguments.Get(ctx, &metadataXml)
+	if resp.Error != nil {
+		return
+	}
+
+	metadata, err := utils.ParseSamlMetadata(metadataXml)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	saml2Config, diags := saml2ConfigFromMetadata(ctx, metadata)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	result := samlMetadataData{
+		EntityId:                 types.StringValue(metadata.EntityId),
+		AcsEndpoints:             saml2Config.AcsEndpoints,
+		SloEndpoints:             saml2Config.SloEndpoints,
+		CertificatesForSigning:   saml2Config.CertificatesForSigning,
+		CertificateForEncryption: saml2Config.CertificateForEncryption,
+		DefaultNameIdFormat:      saml2Config.DefaultNameIdFormat,
+	}
+
+	resp.Error = resp.Result.Set(ctx, &result)
+}
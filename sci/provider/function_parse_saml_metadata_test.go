@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCertificate returns a base64 encoded self-signed certificate of sp.example.com valid for a day
+func testCertificate(t *testing.T) string {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(certificate)
+}
+
+// samlMetadataXml returns the unsigned metadata of a service provider with a certificate valid for a day
+func samlMetadataXml(t *testing.T) string {
+	return fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://sp.example.com/metadata">
+  <md:SPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor>
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://sp.example.com/saml/logout"/>
+    <md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:PAOS" Location="https://sp.example.com/saml/paos"/>
+    <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:X509SubjectName</md:NameIDFormat>
+    <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress</md:NameIDFormat>
+    <md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://sp.example.com/saml/acs" index="0"/>
+  </md:SPSSODescriptor>
+</md:EntityDescriptor>`, testCertificate(t))
+}
+
+func TestFunctionParseSamlMetadata(t *testing.T) {
+
+	metadataXml := samlMetadataXml(t)
+
+	t.Run("happy path - derived configuration", func(t *testing.T) {
+
+		ctx := context.Background()
+
+		metadata, err := utils.ParseSamlMetadata(metadataXml)
+		assert.NoError(t, err)
+
+		config, diags := saml2ConfigFromMetadata(ctx, metadata)
+		assert.False(t, diags.HasError())
+
+		var acsEndpoints []AcsSsoEndpointData
+		config.AcsEndpoints.ElementsAs(ctx, &acsEndpoints, false)
+		assert.Equal(t, []AcsSsoEndpointData{
+			{
+				BindingName: types.StringValue("urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"),
+				Location:    types.StringValue("https://sp.example.com/saml/acs"),
+				Index:       types.Int32Value(0),
+				IsDefault:   types.BoolValue(true),
+			},
+		}, acsEndpoints)
+
+		// the PAOS binding is not supported, the endpoint is ignored
+		var sloEndpoints []AppSloEndpointData
+		config.SloEndpoints.ElementsAs(ctx, &sloEndpoints, false)
+		assert.Equal(t, []AppSloEndpointData{
+			{
+				BindingName:      types.StringValue("urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"),
+				Location:         types.StringValue("https://sp.example.com/saml/logout"),
+				ResponseLocation: types.StringNull(),
+			},
+		}, sloEndpoints)
+
+		var signingCertificates []corporateidps.SigningCertificateData
+		config.CertificatesForSigning.ElementsAs(ctx, &signingCertificates, false)
+		assert.Len(t, signingCertificates, 1)
+		assert.Equal(t, "CN=sp.example.com", signingCertificates[0].Dn)
+		assert.True(t, signingCertificates[0].IsDefault)
+
+		var encryptionCertificate applications.EncryptionCertificateData
+		config.CertificateForEncryption.As(ctx, &encryptionCertificate, basetypes.ObjectAsOptions{})
+		assert.Equal(t, signingCertificates[0].Base64Certificate, encryptionCertificate.Base64Certificate)
+
+		// the X509SubjectName Name ID format is not supported by the tenant
+		assert.Equal(t, types.StringValue("urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"), config.DefaultNameIdFormat)
+	})
+
+	t.Run("happy path - metadata without endpoints and certificates", func(t *testing.T) {
+
+		config, diags := saml2ConfigFromMetadata(context.Background(), utils.SamlMetadata{EntityId: "https://sp.example.com/metadata"})
+
+		assert.False(t, diags.HasError())
+		assert.True(t, config.AcsEndpoints.IsNull())
+		assert.True(t, config.SloEndpoints.IsNull())
+		assert.True(t, config.CertificatesForSigning.IsNull())
+		assert.True(t, config.CertificateForEncryption.IsNull())
+		assert.True(t, config.DefaultNameIdFormat.IsNull())
+	})
+
+	t.Run("happy path - run", func(t *testing.T) {
+
+		ctx := context.Background()
+		req := function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(metadataXml)}),
+		}
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.ObjectUnknown(samlMetadataObjType)),
+		}
+
+		newParseSamlMetadataFunction().Run(ctx, req, &resp)
+
+		assert.Nil(t, resp.Error)
+
+		var result samlMetadataData
+		resp.Result.Value().(types.Object).As(ctx, &result, basetypes.ObjectAsOptions{})
+		assert.Equal(t, "https://sp.example.com/metadata", result.EntityId.ValueString())
+		assert.Len(t, result.AcsEndpoints.Elements(), 1)
+		assert.Len(t, result.SloEndpoints.Elements(), 1)
+		assert.Len(t, result.CertificatesForSigning.Elements(), 1)
+		assert.False(t, result.CertificateForEncryption.IsNull())
+	})
+
+	t.Run("happy path - function call", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config: FunctionParseSamlMetadata(metadataXml),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckOutput("entity_id", "https://sp.example.com/metadata"),
+						resource.TestCheckOutput("acs_location", "https://sp.example.com/saml/acs"),
+						resource.TestCheckOutput("signing_certificate_dn", "CN=sp.example.com"),
+						resource.TestCheckOutput("default_name_id_format", "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - invalid metadata", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      FunctionParseSamlMetadata(strings.Replace(metadataXml, "md:SPSSODescriptor", "md:IDPSSODescriptor", 2)),
+					ExpectError: regexp.MustCompile(`the metadata does not contain an SPSSODescriptor`),
+				},
+			},
+		})
+	})
+}
+
+func FunctionParseSamlMetadata(metadataXml string) string {
+	return fmt.Sprintf(`
+	locals {
+		metadata = provider::sci::parse_saml_metadata(<<-EOT
+%s
+EOT
+		)
+	}
+	output "entity_id" {
+		value = local.metadata.entity_id
+	}
+	output "acs_location" {
+		value = local.metadata.acs_endpoints[0].location
+	}
+	output "signing_certificate_dn" {
+		value = local.metadata.signing_certificates[0].dn
+	}
+	output "default_name_id_format" {
+		value = local.metadata.default_name_id_format
+	}
+	`, metadataXml)
+}
@@ -17,8 +17,10 @@ import (
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -70,6 +72,9 @@ type SciProviderData struct {
 	ClientSecret           types.String `tfsdk:"client_secret"`
 	P12CertificateContent  types.String `tfsdk:"p12_certificate_content"`
 	P12CertificatePassword types.String `tfsdk:"p12_certificate_password"`
+	GroupMembersChunkSize  types.Int64  `tfsdk:"group_members_chunk_size"`
+
+	CertificateExpiryWarningDays types.Int64 `tfsdk:"certificate_expiry_warning_days"`
 }
 
 func (p *SciProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -142,6 +147,24 @@ func (p *SciProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 					stringvalidator.AlsoRequires(path.MatchRoot("p12_certificate_content")),
 				},
 			},
+
+			// Request limits
+			"group_members_chunk_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The maximum number of members added to, replaced in or removed from a group with a single request. Larger changes of the members are split into several requests, which are applied in order. Defaults to `%d`.", cli.DefaultMembersChunkSize),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			// Plan diagnostics
+			"certificate_expiry_warning_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The number of days before their expiry from which the certificates of applications and corporate IdPs are warned about when planning. Expired certificates are warned about as well. Set to `0` to disable the warnings. Defaults to `%d`.", cli.DefaultCertificateExpiryWarningDays),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
@@ -261,16 +284,39 @@ func (p *SciProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
+	// the discovery documents of the corporate IdPs are read with the transport of the provider
+	client.CorporateIdP.DiscoveryHttpClient.Transport = p.httpClient.Transport
+
+	if !config.GroupMembersChunkSize.IsNull() && !config.GroupMembersChunkSize.IsUnknown() {
+		client.Group.MembersChunkSize = int(config.GroupMembersChunkSize.ValueInt64())
+	}
+
+	client.CertificateExpiryWarningDays = cli.DefaultCertificateExpiryWarningDays
+	if !config.CertificateExpiryWarningDays.IsNull() && !config.CertificateExpiryWarningDays.IsUnknown() {
+		client.CertificateExpiryWarningDays = int(config.CertificateExpiryWarningDays.ValueInt64())
+	}
+
+	// the capabilities of the tenant determine the strategies used for filtering, paging, bulk operations and ETags
+	// they are only read by the calls depending on them, if they cannot be read, the behaviour of a tenant with default capabilities is assumed
+	client.DiscoverOnFirstUse()
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+func (p *SciProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newParseSamlMetadataFunction,
+	}
+}
+
 func (p *SciProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		newApplicationDataSource,
 		newApplicationsDataSource,
 		newApplicationSecretDataSource,
 		newApplicationSecretsDataSource,
+		newAuthenticationRuleEvaluationDataSource,
 		newUsersDataSource,
 		newUserDataSource,
 		newSchemasDataSource,
@@ -281,8 +327,16 @@ func (p *SciProvider) DataSources(_ context.Context) []func() datasource.DataSou
 		newGroupBasesDataSource,
 		newGroupAssignmentDataSource,
 		newGroupAssignmentsDataSource,
+		newGroupEffectiveMembersDataSource,
+		newUserMembershipsDataSource,
+		newInactiveUsersDataSource,
+		newStaleGroupsDataSource,
 		newCorporateIdPDataSource,
 		newCorporateIdPsDataSource,
+		newScimServiceProviderConfigDataSource,
+		newScimResourceTypesDataSource,
+		newTenantSamlMetadataDataSource,
+		newTenantOidcConfigurationDataSource,
 	}
 }
 
@@ -290,11 +344,17 @@ func (p *SciProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		newApplicationResource,
 		newApplicationSecretResource,
+		newApplicationAuthenticationRulesResource,
+		newApplicationAssertionAttributesResource,
 		newUserResource,
+		newUserPasswordResource,
+		newUsersBulkResource,
 		newSchemaResource,
 		newGroupResource,
 		newGroupBaseResource,
 		newGroupAssignmentResource,
+		newGroupMembersResource,
+		newUserGroupMembershipsResource,
 		newCorporateIdPResource,
 	}
 }
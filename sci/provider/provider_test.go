@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
@@ -95,6 +97,20 @@ func setupVCR(t *testing.T, cassetteName string) (*recorder.Recorder, User) {
 	return rec, testUser
 }
 
+// requireCassette skips the test if its cassette has not been recorded yet and the credentials to record it are missing
+// with SCI_USERNAME and SCI_PASSWORD set, the cassette is recorded on the first run
+func requireCassette(t *testing.T, cassetteName string) {
+	t.Helper()
+
+	if _, err := os.Stat(cassetteName + ".yaml"); err == nil {
+		return
+	}
+
+	if os.Getenv("SCI_USERNAME") == "" || os.Getenv("SCI_PASSWORD") == "" {
+		t.Skipf("the cassette '%s' has not been recorded yet, set SCI_USERNAME and SCI_PASSWORD to record it", cassetteName)
+	}
+}
+
 func requestMatcher(t *testing.T) cassette.MatcherFunc {
 	return func(r *http.Request, i cassette.Request) bool {
 		if r.Method != i.Method || r.URL.String() != i.URL {
@@ -205,10 +221,16 @@ func TestSciProvider_AllResources(t *testing.T) {
 	expectedResources := []string{
 		"sci_application",
 		"sci_application_secret",
+		"sci_application_authentication_rules",
+		"sci_application_assertion_attributes",
 		"sci_user",
+		"sci_user_password",
+		"sci_users_bulk",
 		"sci_group",
 		"sci_group_base",
 		"sci_group_assignment",
+		"sci_group_members",
+		"sci_user_group_memberships",
 		"sci_schema",
 		"sci_corporate_idp",
 	}
@@ -228,6 +250,7 @@ func TestSciProvider_AllDataSources(t *testing.T) {
 		"sci_applications",
 		"sci_application_secret",
 		"sci_application_secrets",
+		"sci_authentication_rule_evaluation",
 		"sci_user",
 		"sci_users",
 		"sci_group",
@@ -236,10 +259,18 @@ func TestSciProvider_AllDataSources(t *testing.T) {
 		"sci_group_bases",
 		"sci_group_assignment",
 		"sci_group_assignments",
+		"sci_group_effective_members",
+		"sci_user_memberships",
+		"sci_inactive_users",
+		"sci_stale_groups",
 		"sci_schema",
 		"sci_schemas",
 		"sci_corporate_idp",
 		"sci_corporate_idps",
+		"sci_scim_service_provider_config",
+		"sci_scim_resource_types",
+		"sci_tenant_saml_metadata",
+		"sci_tenant_oidc_configuration",
 	}
 	ctx := context.Background()
 	var registeredDataSources []string
@@ -251,6 +282,20 @@ func TestSciProvider_AllDataSources(t *testing.T) {
 	assert.ElementsMatch(t, expectedDataSources, registeredDataSources)
 }
 
+func TestSciProvider_AllFunctions(t *testing.T) {
+	expectedFunctions := []string{
+		"parse_saml_metadata",
+	}
+	ctx := context.Background()
+	var registeredFunctions []string
+	for _, functionFunc := range New().(provider.ProviderWithFunctions).Functions(ctx) {
+		var resp function.MetadataResponse
+		functionFunc().Metadata(ctx, function.MetadataRequest{}, &resp)
+		registeredFunctions = append(registeredFunctions, resp.Name)
+	}
+	assert.ElementsMatch(t, expectedFunctions, registeredFunctions)
+}
+
 func TestProviderConfig_MissingTenantURL(t *testing.T) {
 	config := `
 		provider "sci" {
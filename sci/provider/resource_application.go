@@ -3,8 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
@@ -30,6 +33,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 )
 
 const (
@@ -48,6 +52,34 @@ var (
 	saml2AppNameIdFormatValues          = []string{"urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified", "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress", "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent", "urn:oasis:names:tc:SAML:2.0:nameid-format:transient"}
 	responseElementsToEncrypt           = []string{"none", "wholeAssertion", "subjectNameId", "attributes", "subjectNameIdAndAttributes"}
 	typeOfAppValues                     = []string{"identityInstance", "subscription", "reuseInstance", "xsuaa"}
+	overrideInheritedValues             = []string{"assertion_attributes", "advanced_assertion_attributes"}
+	authnContextClassRefValues          = []string{
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:unspecified",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:InternetProtocol",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:InternetProtocolPassword",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:Kerberos",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:MobileOneFactorUnregistered",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:MobileTwoFactorUnregistered",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:MobileOneFactorContract",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:MobileTwoFactorContract",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:Password",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:PreviousSession",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:X509",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:PGP",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:SPKI",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:XMLDSig",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:Smartcard",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:SmartcardPKI",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:SoftwarePKI",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:Telephony",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:NomadTelephony",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:PersonalTelephony",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:AuthenticatedTelephony",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:SecureRemotePassword",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:TLSClient",
+		"urn:oasis:names:tc:SAML:2.0:ac:classes:TimeSyncToken",
+	}
 )
 
 func newApplicationResource() resource.Resource {
@@ -58,6 +90,8 @@ type applicationResource struct {
 	cli *cli.SciClient
 }
 
+var _ resource.ResourceWithModifyPlan = &applicationResource{}
+
 func (d *applicationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -109,6 +143,91 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 					utils.ValidUUID(),
 				},
 			},
+			"override_inherited": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The properties of the authentication schema for which the values inherited from the parent application are disabled, so that only the configured values apply. The inherited values of all other properties are not part of the configuration of the application and are shown by the **sci_application** data source. Only assertion attributes and advanced assertion attributes can be overridden, as the tenant does not disable the inherited values of other properties like the authentication rules. Must not be combined with the **sci_application_assertion_attributes** resource for the same application, as both manage the disabled inherited values." + utils.ValidValuesString(overrideInheritedValues),
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(overrideInheritedValues...)),
+					setvalidator.AlsoRequires(path.MatchRoot("parent_application_id")),
+				},
+			},
+			"oidc_client": schema.SingleNestedAttribute{
+				MarkdownDescription: "The OpenID Connect client registration of the application and the endpoints of the tenant derived from the tenant URL, e.g. to pass them on to the deployment of the application. Only set for applications with the sso_type `openIdConnect` or `saml2oidc`.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "The client ID of the application.",
+						Computed:            true,
+					},
+					"issuer": schema.StringAttribute{
+						MarkdownDescription: "The issuer of the tokens of the tenant.",
+						Computed:            true,
+					},
+					"authorization_endpoint": schema.StringAttribute{
+						MarkdownDescription: "The endpoint to which authorization requests are sent.",
+						Computed:            true,
+					},
+					"token_endpoint": schema.StringAttribute{
+						MarkdownDescription: "The endpoint called to request tokens.",
+						Computed:            true,
+					},
+					"jwks_uri": schema.StringAttribute{
+						MarkdownDescription: "The endpoint called to request the JSON Web Keys of the tenant.",
+						Computed:            true,
+					},
+					"logout_endpoint": schema.StringAttribute{
+						MarkdownDescription: "The endpoint called to end the session of the user.",
+						Computed:            true,
+					},
+				},
+			},
+			"saml2_idp": schema.SingleNestedAttribute{
+				MarkdownDescription: "The SAML 2.0 identity provider endpoints of the tenant derived from the tenant URL, e.g. to pass them on to the deployment of the application. Only set for applications with the sso_type `saml2` or `saml2oidc`. The entity ID is the default one of the tenant, a customized entity ID is shown by the **sci_tenant_saml_metadata** data source.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"entity_id": schema.StringAttribute{
+						MarkdownDescription: "The entity ID of the tenant.",
+						Computed:            true,
+					},
+					"sso_url": schema.StringAttribute{
+						MarkdownDescription: "The single sign-on URL of the tenant.",
+						Computed:            true,
+					},
+					"slo_url": schema.StringAttribute{
+						MarkdownDescription: "The single logout URL of the tenant.",
+						Computed:            true,
+					},
+				},
+			},
+			"api_certificates": schema.ListNestedAttribute{
+				MarkdownDescription: "The certificates the application authenticates with at the APIs of the tenant. The certificates are managed in the tenant, they are read to show their details, and plans carry a warning if a certificate expires within `certificate_expiry_warning_days`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: certificateDetailsResourceSchema(map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the certificate.",
+							Computed:            true,
+						},
+						"dn": schema.StringAttribute{
+							MarkdownDescription: "The distinguished name of the certificate.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the certificate.",
+							Computed:            true,
+						},
+						"base64_certificate": schema.StringAttribute{
+							MarkdownDescription: "The content of the Base64 certificate.",
+							Computed:            true,
+						},
+					}),
+				},
+			},
 			"multi_tenant_app": schema.BoolAttribute{
 				MarkdownDescription: "Only for Internal Use",
 				Optional:            true,
@@ -206,7 +325,7 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 						},
 					},
 					"assertion_attributes": schema.ListNestedAttribute{
-						MarkdownDescription: "User attributes to be sent to the application. The Source of these attributes is always the Identity Directory, thus only valid attribute values will be accepted.",
+						MarkdownDescription: "User attributes to be sent to the application. The Source of these attributes is always the Identity Directory, thus only valid attribute values will be accepted. The attributes can be managed separately with the `sci_application_assertion_attributes` resource instead.",
 						Optional:            true,
 						Computed:            true,
 						Validators: []validator.List{
@@ -253,7 +372,7 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 						},
 					},
 					"advanced_assertion_attributes": schema.ListNestedAttribute{
-						MarkdownDescription: "Identical to the assertion attributes, except that the assertion attributes can come from other Sources.",
+						MarkdownDescription: "Identical to the assertion attributes, except that the assertion attributes can come from other Sources. The attributes can be managed separately with the `sci_application_assertion_attributes` resource instead.",
 						Optional:            true,
 						Validators: []validator.List{
 							listvalidator.AlsoRequires(
@@ -308,7 +427,7 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 						},
 					},
 					"conditional_authentication": schema.ListNestedAttribute{
-						MarkdownDescription: "Define rules for authenticating identity provider according to email domain, user type, user group, and IP range. Each rule is evaluated by priority until the criteria of a rule are fulfilled.",
+						MarkdownDescription: "Define rules for authenticating identity provider according to email domain, user type, user group, and IP range. Each rule is evaluated by priority until the criteria of a rule are fulfilled. The rules can be managed separately with the `sci_application_authentication_rules` resource instead.",
 						Optional:            true,
 						Validators: []validator.List{
 							listvalidator.AlsoRequires(
@@ -571,9 +690,37 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 								MarkdownDescription: "The URL with service provider metadata. The metadata URL must not contain a query parameter.",
 								Optional:            true,
 							},
+							"metadata_xml": schema.StringAttribute{
+								MarkdownDescription: "The metadata of the service provider, a SAML `EntityDescriptor` XML document. The ACS and SLO endpoints, the signing and encryption certificates and the default Name ID format are derived from the metadata and shown in the plan. Endpoints with bindings that are not supported are ignored. When the metadata is planned, it must not have expired and its certificates must be valid. The signature of the metadata is only verified with the `metadata_signing_certificate`.",
+								Optional:            true,
+								Validators: []validator.String{
+									utils.ValidSamlMetadata(),
+									stringvalidator.ConflictsWith(
+										path.MatchRelative().AtParent().AtName("saml_metadata_url"),
+										path.MatchRelative().AtParent().AtName("acs_endpoints"),
+										path.MatchRelative().AtParent().AtName("slo_endpoints"),
+										path.MatchRelative().AtParent().AtName("signing_certificates"),
+										path.MatchRelative().AtParent().AtName("encryption_certificate"),
+									),
+								},
+							},
+							"metadata_signing_certificate": schema.StringAttribute{
+								MarkdownDescription: "The trusted certificate of the service provider, in PEM format, which `metadata_xml` must be signed with. The signature is verified when the metadata or the certificate are planned, the certificate must be valid then. The configuration derived from the metadata is only taken from the signed content. Without the certificate the metadata is accepted with a warning and its signature is not verified, as a certificate contained in the metadata does not prove who signed it. The certificate is not stored by the tenant.",
+								Optional:            true,
+								Validators: []validator.String{
+									utils.ValidCertificate(),
+									stringvalidator.AlsoRequires(
+										path.MatchRelative().AtParent().AtName("metadata_xml"),
+									),
+								},
+							},
 							"acs_endpoints": schema.ListNestedAttribute{
 								MarkdownDescription: "Configure the allowed domains for browser flows.",
 								Optional:            true,
+								Computed:            true,
+								PlanModifiers: []planmodifier.List{
+									samlMetadataValue(),
+								},
 								Validators: []validator.List{
 									listvalidator.AlsoRequires(
 										path.MatchRoot("authentication_schema").AtName("saml2_config").AtName("acs_endpoints").AtAnyListIndex().AtName("binding_name"),
@@ -613,6 +760,10 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 							"slo_endpoints": schema.ListNestedAttribute{
 								MarkdownDescription: "Configure the URLs of the service provider's single logout endpoints that will receive the logout response or request from Identity Authentication.",
 								Optional:            true,
+								Computed:            true,
+								PlanModifiers: []planmodifier.List{
+									samlMetadataValue(),
+								},
 								Validators: []validator.List{
 									listvalidator.AlsoRequires(
 										path.MatchRoot("authentication_schema").AtName("saml2_config").AtName("slo_endpoints").AtAnyListIndex().AtName("binding_name"),
@@ -643,6 +794,10 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 							"signing_certificates": schema.ListNestedAttribute{
 								MarkdownDescription: "Base64-encoded certificates used by the service provider to sign digitally, SAML protocol messages sent to Identity Authentication. A maximum of 2 certificates are allowed.",
 								Optional:            true,
+								Computed:            true,
+								PlanModifiers: []planmodifier.List{
+									samlMetadataValue(),
+								},
 								Validators: []validator.List{
 									listvalidator.SizeAtLeast(1),
 									listvalidator.SizeAtMost(2),
@@ -652,7 +807,7 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 									),
 								},
 								NestedObject: schema.NestedAttributeObject{
-									Attributes: map[string]schema.Attribute{
+									Attributes: certificateDetailsResourceSchema(map[string]schema.Attribute{
 										"base64_certificate": schema.StringAttribute{
 											MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 											Optional:            true,
@@ -689,18 +844,22 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 												stringplanmodifier.UseNonNullStateForUnknown(),
 											},
 										},
-									},
+									}),
 								},
 							},
 							"encryption_certificate": schema.SingleNestedAttribute{
 								MarkdownDescription: "The certificate used for encryption of SAML2 requests and responses.",
 								Optional:            true,
+								Computed:            true,
+								PlanModifiers: []planmodifier.Object{
+									samlMetadataValue(),
+								},
 								Validators: []validator.Object{
 									objectvalidator.AlsoRequires(
 										path.MatchRoot("authentication_schema").AtName("saml2_config").AtName("encryption_certificate").AtName("base64_certificate"),
 									),
 								},
-								Attributes: map[string]schema.Attribute{
+								Attributes: certificateDetailsResourceSchema(map[string]schema.Attribute{
 									"base64_certificate": schema.StringAttribute{
 										MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 										Optional:            true,
@@ -733,7 +892,7 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 											stringplanmodifier.UseNonNullStateForUnknown(),
 										},
 									},
-								},
+								}),
 							},
 							"response_elements_to_encrypt": schema.StringAttribute{
 								MarkdownDescription: "Specify which SAML response elements should be encrypted. " + utils.ValidValuesString(responseElementsToEncrypt),
@@ -751,6 +910,7 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 								Optional:            true,
 								Computed:            true,
 								PlanModifiers: []planmodifier.String{
+									samlMetadataValue(),
 									stringplanmodifier.UseNonNullStateForUnknown(),
 								},
 								Validators: []validator.String{
@@ -808,6 +968,30 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 									stringvalidator.OneOf(digestAlgorithmValues...),
 								},
 							},
+							"proxy_authn_request": schema.SingleNestedAttribute{
+								MarkdownDescription: "Configure the SAML 2.0 authentication requests which are sent to the corporate identity provider when the tenant acts as a proxy.",
+								Optional:            true,
+								Computed:            true,
+								PlanModifiers: []planmodifier.Object{
+									objectplanmodifier.UseNonNullStateForUnknown(),
+								},
+								Attributes: map[string]schema.Attribute{
+									"authn_context": schema.StringAttribute{
+										MarkdownDescription: "The authentication context class reference which is requested from the corporate identity provider." + utils.ValidValuesString(authnContextClassRefValues),
+										Optional:            true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(authnContextClassRefValues...),
+										},
+									},
+									"issuer_name_suffix": schema.StringAttribute{
+										MarkdownDescription: "The suffix appended to the issuer name of the tenant in the authentication requests, so that the corporate identity provider can tell the applications apart.",
+										Optional:            true,
+										Validators: []validator.String{
+											stringvalidator.LengthBetween(1, 255),
+										},
+									},
+								},
+							},
 						},
 					},
 					"sap_managed_attributes": schema.SingleNestedAttribute{
@@ -921,18 +1105,52 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	state, diags := applicationValueFrom(ctx, res)
+	// the application is tracked before it is updated, so that it is not lost if the update fails
+	diags = r.setState(ctx, res, plan, &resp.State)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	diags = stateModify(ctx, plan, &state)
-	resp.Diagnostics.Append(diags...)
+	// the inherited values can only be disabled once the application inherits them
+	if !plan.OverrideInherited.IsNull() {
 
-	diags = resp.State.Set(ctx, &state)
+		patchReqs, diags := getOverrideInheritedPatch(ctx, res, plan.OverrideInherited)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	resp.Diagnostics.Append(diags...)
+		if len(patchReqs) > 0 {
+			res, _, err = r.cli.Application.Update(ctx, patchReqs, res.Id)
+			if err != nil {
+				resp.Diagnostics.AddError("Error updating application", fmt.Sprintf("%s", err))
+				return
+			}
+
+			diags = r.setState(ctx, res, plan, &resp.State)
+			resp.Diagnostics.Append(diags...)
+		}
+	}
+}
+
+// setState maps the application to the state, along with the endpoints of the application
+func (r *applicationResource) setState(ctx context.Context, res applications.Application, plan applicationData, state *tfsdk.State) diag.Diagnostics {
+
+	updatedState, diags := applicationResourceValueFrom(ctx, res, plan)
+	if diags.HasError() {
+		return diags
+	}
+
+	var endpointDiags diag.Diagnostics
+	updatedState.OidcClient, updatedState.Saml2Idp, endpointDiags = applicationEndpointsValueFrom(ctx, r.cli.ServerURL, res.AuthenticationSchema.SsoType, applicationClientId(res))
+	diags.Append(endpointDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(state.Set(ctx, &updatedState)...)
+	return diags
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -951,16 +1169,7 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	state, diags := applicationValueFrom(ctx, res)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	diags = stateModify(ctx, config, &state)
-	resp.Diagnostics.Append(diags...)
-
-	diags = resp.State.Set(ctx, &state)
+	diags = r.setState(ctx, res, config, &resp.State)
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -993,22 +1202,31 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// the names of the inherited values to disable are taken from the current application
+	if !plan.OverrideInherited.Equal(state.OverrideInherited) {
+
+		current, _, err := r.cli.Application.GetByAppId(ctx, state.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error retrieving application", fmt.Sprintf("%s", err))
+			return
+		}
+
+		patchReqs, diags := getOverrideInheritedPatch(ctx, current, plan.OverrideInherited)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		args = append(args, patchReqs...)
+	}
+
 	res, _, err := r.cli.Application.Update(ctx, args, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating application", fmt.Sprintf("%s", err))
 		return
 	}
 
-	updatedState, diags := applicationValueFrom(ctx, res)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	diags = stateModify(ctx, plan, &updatedState)
-	resp.Diagnostics.Append(diags...)
-
-	diags = resp.State.Set(ctx, &updatedState)
+	diags = r.setState(ctx, res, plan, &resp.State)
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -1053,6 +1271,57 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 }
 
+func (r *applicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// the certificates are only checked when the application is created or updated, and requires a configured provider
+	if req.Plan.Raw.IsNull() || r.cli == nil {
+		return
+	}
+
+	// the endpoints only change with the sso type, otherwise they are kept instead of being shown as unknown on every update
+	if !req.State.Raw.IsNull() {
+
+		ssoTypePath := path.Root("authentication_schema").AtName("sso_type")
+
+		var plannedSsoType, currentSsoType types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, ssoTypePath, &plannedSsoType)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, ssoTypePath, &currentSsoType)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plannedSsoType.Equal(currentSsoType) {
+			for attribute, ssoTypes := range map[string][]string{"oidc_client": oidcSsoTypes, "saml2_idp": saml2SsoTypes} {
+
+				var endpoints types.Object
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &endpoints)...)
+
+				// endpoints missing from the state, e.g. of an earlier version of the provider, are left to be set by the update
+				if endpoints.IsNull() && slices.Contains(ssoTypes, currentSsoType.ValueString()) {
+					continue
+				}
+
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), endpoints)...)
+			}
+		}
+	}
+
+	saml2Path := path.Root("authentication_schema").AtName("saml2_config")
+	now := time.Now()
+
+	diags := certificateListExpiryWarnings(ctx, req.Plan, saml2Path.AtName("signing_certificates"), r.cli.CertificateExpiryWarningDays, now)
+	resp.Diagnostics.Append(diags...)
+
+	diags = certificateExpiryWarnings(ctx, req.Plan, saml2Path.AtName("encryption_certificate"), r.cli.CertificateExpiryWarningDays, now)
+	resp.Diagnostics.Append(diags...)
+
+	diags = certificateListExpiryWarnings(ctx, req.Plan, path.Root("api_certificates"), r.cli.CertificateExpiryWarningDays, now)
+	resp.Diagnostics.Append(diags...)
+
+	diags = checkAppSamlMetadata(ctx, req, saml2Path, now)
+	resp.Diagnostics.Append(diags...)
+}
+
 func (rs *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -1156,6 +1425,10 @@ func stateModify(ctx context.Context, plan applicationData, state *applicationDa
 				return diags
 			}
 
+			// the metadata is not stored by the tenant
+			stateSaml.MetadataXml = planSaml.MetadataXml
+			stateSaml.MetadataSigningCert = planSaml.MetadataSigningCert
+
 			if !planSaml.CertificatesForSigning.IsNull() && !planSaml.CertificatesForSigning.IsUnknown() {
 				var planCerts, stateCerts []signingCertificateData
 				diags = planSaml.CertificatesForSigning.ElementsAs(ctx, &planCerts, true)
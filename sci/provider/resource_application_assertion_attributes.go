@@ -0,0 +1,365 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newApplicationAssertionAttributesResource() resource.Resource {
+	return &applicationAssertionAttributesResource{}
+}
+
+type applicationAssertionAttributesResource struct {
+	cli *cli.SciClient
+}
+
+var _ resource.ResourceWithModifyPlan = &applicationAssertionAttributesResource{}
+
+func (r *applicationAssertionAttributesResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (r *applicationAssertionAttributesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_assertion_attributes"
+}
+
+func (r *applicationAssertionAttributesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manage the assertion attributes and advanced assertion attributes which an application in the SAP Cloud Identity Services tenant sends to the service provider.
+
+The user attributes and the expressions of the attributes are validated when planning. The user attributes must be attributes of the Identity Directory or be defined by a schema of the tenant, either by their name or qualified with the ID of the schema, e.g. ` + "`urn:sap:cloud:scim:schemas:extension:custom:2.0:Employee:costCenter`" + `. Unknown user attributes are reported as warnings.
+
+Expressions are texts with references written as ` + "`${<scope>.<attribute>}`" + `, e.g. ` + "`${user.firstName} ${user.lastName}`" + `. The scope ` + "`user`" + ` references the user attributes, the scope ` + "`corporateIdP`" + ` the attributes sent by the corporate identity provider.
+
+### Inherited Attributes
+The attributes which a child application inherits from its parent application are not managed by the resource, they are shown in ` + "`inherited_assertion_attributes`" + ` and ` + "`inherited_advanced_assertion_attributes`" + `. Inherited attributes can be excluded from the child application by their name with ` + "`disabled_inherited_assertion_attributes`" + ` and ` + "`disabled_inherited_advanced_assertion_attributes`" + `.
+
+### Conflict Warning
+The resource manages all attributes of the application and must not be combined with the **assertion_attributes** and **advanced_assertion_attributes** of the **sci_application** resource for the same application. Add ` + "`authentication_schema.assertion_attributes`" + ` and ` + "`authentication_schema.advanced_assertion_attributes`" + ` to the ` + "`ignore_changes`" + ` of the **sci_application** resource, so that it does not remove the attributes.
+
+The disabled inherited attributes are managed by the resource as well, so it must not be combined with the ` + "`override_inherited`" + ` of the **sci_application** resource for the same application.
+
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique ID of the resource, which is the ID of the application.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Unique ID of the application.",
+				Validators: []validator.String{
+					utils.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"assertion_attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "User attributes of the Identity Directory to be sent to the application.",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attribute_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the attribute sent to the application.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 255),
+							},
+						},
+						"attribute_value": schema.StringAttribute{
+							MarkdownDescription: "The user attribute whose value is sent.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 255),
+							},
+						},
+					},
+				},
+			},
+			"advanced_assertion_attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "Attributes to be sent to the application whose values come from the corporate identity provider or from an expression.",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source": schema.StringAttribute{
+							MarkdownDescription: "The source of the value of the attribute." + utils.ValidValuesString(sourceValues[1:]),
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(sourceValues[1:]...),
+							},
+						},
+						"attribute_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the attribute sent to the application.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 512),
+							},
+						},
+						"attribute_value": schema.StringAttribute{
+							MarkdownDescription: "The attribute of the corporate identity provider if the source is `Corporate Identity Provider`, otherwise the expression whose result is sent.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 512),
+							},
+						},
+					},
+				},
+			},
+			"disabled_inherited_assertion_attributes": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the assertion attributes inherited from the parent application which are not sent. Only applicable to child applications.",
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 255)),
+				},
+			},
+			"disabled_inherited_advanced_assertion_attributes": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the advanced assertion attributes inherited from the parent application which are not sent. Only applicable to child applications.",
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 512)),
+				},
+			},
+			"inherited_assertion_attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "The assertion attributes inherited from the parent application.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attribute_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the attribute sent to the application.",
+							Computed:            true,
+						},
+						"attribute_value": schema.StringAttribute{
+							MarkdownDescription: "The user attribute whose value is sent.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"inherited_advanced_assertion_attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "The advanced assertion attributes inherited from the parent application.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source": schema.StringAttribute{
+							MarkdownDescription: "The source of the value of the attribute.",
+							Computed:            true,
+						},
+						"attribute_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the attribute sent to the application.",
+							Computed:            true,
+						},
+						"attribute_value": schema.StringAttribute{
+							MarkdownDescription: "The attribute of the corporate identity provider or the expression whose result is sent.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *applicationAssertionAttributesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var plan applicationAssertionAttributesData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.apply(ctx, plan, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *applicationAssertionAttributesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var config applicationAssertionAttributesData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, _, err := r.cli.Application.GetByAppId(ctx, config.ApplicationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving application", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags := applicationAssertionAttributesValueFrom(ctx, res)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *applicationAssertionAttributesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var plan applicationAssertionAttributesData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.apply(ctx, plan, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *applicationAssertionAttributesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	var config applicationAssertionAttributesData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, _, err := r.cli.Application.GetByAppId(ctx, config.ApplicationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving application", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// the attributes of the application are removed and the inherited attributes are enabled again
+	reqs, diags := getAssertionAttributesPatch(res.AuthenticationSchema, applications.AuthenticationSchema{
+		AssertionAttributes:         []applications.AssertionAttribute{},
+		AdvancedAssertionAttributes: []applications.AdvancedAssertionAttribute{},
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(reqs) == 0 {
+		return
+	}
+
+	_, _, err = r.cli.Application.Update(ctx, reqs, config.ApplicationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing assertion attributes", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (r *applicationAssertionAttributesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// the attributes are only validated when they are created or updated, and require a configured provider
+	if req.Plan.Raw.IsNull() || r.cli == nil {
+		return
+	}
+
+	var plan applicationAssertionAttributesData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AssertionAttributes.IsUnknown() || plan.AdvancedAssertionAttributes.IsUnknown() {
+		return
+	}
+
+	var attributes []assertionAttributeMappingData
+	if !plan.AssertionAttributes.IsNull() {
+		resp.Diagnostics.Append(plan.AssertionAttributes.ElementsAs(ctx, &attributes, false)...)
+	}
+
+	var advancedAttributes []advancedAssertionAttributeMappingData
+	if !plan.AdvancedAssertionAttributes.IsNull() {
+		resp.Diagnostics.Append(plan.AdvancedAssertionAttributes.ElementsAs(ctx, &advancedAttributes, false)...)
+	}
+
+	if resp.Diagnostics.HasError() || (len(attributes) == 0 && len(advancedAttributes) == 0) {
+		return
+	}
+
+	resp.Diagnostics.Append(validateAssertionAttributes(ctx, r.cli, attributes, advancedAttributes)...)
+}
+
+func (r *applicationAssertionAttributesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), req.ID)...)
+}
+
+// apply reads the attributes of the application, replaces those which differ from the planned attributes and sets the resulting state
+func (r *applicationAssertionAttributesResource) apply(ctx context.Context, plan applicationAssertionAttributesData, state *tfsdk.State) diag.Diagnostics {
+
+	var diagnostics diag.Diagnostics
+
+	planned, diags := getAssertionAttributesArgs(ctx, plan)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	res, _, err := r.cli.Application.GetByAppId(ctx, plan.ApplicationId.ValueString())
+	if err != nil {
+		diagnostics.AddError("Error retrieving application", fmt.Sprintf("%s", err))
+		return diagnostics
+	}
+
+	if planned.DisabledInheritedProperties != nil && len(res.ParentApplicationId) == 0 {
+		diagnostics.AddError("Error updating assertion attributes", fmt.Sprintf("application %s has no parent application, inherited attributes cannot be disabled", res.Id))
+		return diagnostics
+	}
+
+	reqs, diags := getAssertionAttributesPatch(res.AuthenticationSchema, planned)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	if len(reqs) > 0 {
+		res, _, err = r.cli.Application.Update(ctx, reqs, plan.ApplicationId.ValueString())
+		if err != nil {
+			diagnostics.AddError("Error updating assertion attributes", fmt.Sprintf("%s", err))
+			return diagnostics
+		}
+	}
+
+	updatedState, diags := applicationAssertionAttributesValueFrom(ctx, res)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	diags = state.Set(ctx, &updatedState)
+	diagnostics.Append(diags...)
+
+	return diagnostics
+}
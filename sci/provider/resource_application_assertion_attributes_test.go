@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/schemas"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceApplicationAssertionAttributes(t *testing.T) {
+	t.Parallel()
+
+	mockUuid := "af2f7963-358d-4336-bc51-57099394dee7"
+
+	authSchemaPath := "/urn:sap:identity:application:schemas:extension:sci:1.0:Authentication"
+
+	customSchema := schemas.Schema{
+		Id: "urn:sap:cloud:scim:schemas:extension:custom:2.0:Employee",
+		Attributes: []schemas.Attribute{
+			{Name: "costCenter", Type: "string"},
+			{Name: "office", Type: "complex", SubAttributes: []schemas.Attribute{{Name: "building", Type: "string"}}},
+		},
+	}
+
+	childApp := applications.Application{
+		Id:                  mockUuid,
+		ParentApplicationId: "0d2e5f4e-8c1b-4c70-9d4e-3f6c0a3b9a11",
+		AuthenticationSchema: &applications.AuthenticationSchema{
+			AssertionAttributes: []applications.AssertionAttribute{
+				{AssertionAttributeName: "first_name", UserAttributeName: "firstName", Inherited: true},
+				{AssertionAttributeName: "email", UserAttributeName: "mail"},
+			},
+			AdvancedAssertionAttributes: []applications.AdvancedAssertionAttribute{
+				{AttributeName: "department", AttributeValue: "${corporateIdP.department}", Inherited: true},
+				{AttributeName: "full_name", AttributeValue: "${user.firstName} ${user.lastName}"},
+			},
+			DisabledInheritedProperties: &applications.DisabledInheritedProperties{
+				AssertionAttributes: []applications.AssertionAttribute{{AssertionAttributeName: "last_name", Inherited: true}},
+			},
+		},
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		requireCassette(t, "fixtures/resource_application_assertion_attributes")
+		rec, user := setupVCR(t, "fixtures/resource_application_assertion_attributes")
+		defer stopQuietly(rec)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + applicationAssertionAttributesTestConfig(
+						[]string{`{ attribute_name = "email", attribute_value = "mail" }`},
+						[]string{`{ source = "Expression", attribute_name = "full_name", attribute_value = "$${user.firstName} $${user.lastName}" }`},
+					),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrPair("sci_application_assertion_attributes.testAttributes", "application_id", "sci_application.testApp", "id"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "assertion_attributes.#", "1"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "assertion_attributes.0.attribute_name", "email"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "advanced_assertion_attributes.#", "1"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "advanced_assertion_attributes.0.source", "Expression"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "advanced_assertion_attributes.0.attribute_value", "${user.firstName} ${user.lastName}"),
+					),
+				},
+				{
+					ResourceName:      "sci_application_assertion_attributes.testAttributes",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: providerConfig("", user) + applicationAssertionAttributesTestConfig(
+						[]string{
+							`{ attribute_name = "email", attribute_value = "mail" }`,
+							`{ attribute_name = "first_name", attribute_value = "firstName" }`,
+						},
+						[]string{`{ source = "Corporate Identity Provider", attribute_name = "department", attribute_value = "department" }`},
+					),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "assertion_attributes.#", "2"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "assertion_attributes.1.attribute_name", "first_name"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "advanced_assertion_attributes.#", "1"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "advanced_assertion_attributes.0.source", "Corporate Identity Provider"),
+						resource.TestCheckResourceAttr("sci_application_assertion_attributes.testAttributes", "advanced_assertion_attributes.0.attribute_name", "department"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - application_id needs to be a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationAssertionAttributes("testAttributes", "not-a-valid-uuid", `{ attribute_name = "email", attribute_value = "mail" }`),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: not-a-valid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - assertion_attributes.attribute_value is required", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationAssertionAttributes("testAttributes", mockUuid, `{ attribute_name = "email" }`),
+					ExpectError: regexp.MustCompile(`Inappropriate value for attribute "assertion_attributes"`),
+				},
+			},
+		})
+	})
+
+	t.Run("value - inherited attributes are kept apart", func(t *testing.T) {
+
+		state, diags := applicationAssertionAttributesValueFrom(context.TODO(), childApp)
+		assert.False(t, diags.HasError())
+
+		var attributes, inheritedAttributes []assertionAttributeMappingData
+		assert.False(t, state.AssertionAttributes.ElementsAs(context.TODO(), &attributes, false).HasError())
+		assert.False(t, state.InheritedAssertionAttributes.ElementsAs(context.TODO(), &inheritedAttributes, false).HasError())
+
+		assert.Equal(t, []assertionAttributeMappingData{
+			{AttributeName: types.StringValue("email"), AttributeValue: types.StringValue("mail")},
+		}, attributes)
+		assert.Equal(t, []assertionAttributeMappingData{
+			{AttributeName: types.StringValue("first_name"), AttributeValue: types.StringValue("firstName")},
+		}, inheritedAttributes)
+
+		var advancedAttributes, inheritedAdvancedAttributes []advancedAssertionAttributeMappingData
+		assert.False(t, state.AdvancedAssertionAttributes.ElementsAs(context.TODO(), &advancedAttributes, false).HasError())
+		assert.False(t, state.InheritedAdvancedAssertionAttributes.ElementsAs(context.TODO(), &inheritedAdvancedAttributes, false).HasError())
+
+		assert.Equal(t, []advancedAssertionAttributeMappingData{
+			{Source: types.StringValue("Expression"), AttributeName: types.StringValue("full_name"), AttributeValue: types.StringValue("${user.firstName} ${user.lastName}")},
+		}, advancedAttributes)
+		assert.Equal(t, []advancedAssertionAttributeMappingData{
+			{Source: types.StringValue("Corporate Identity Provider"), AttributeName: types.StringValue("department"), AttributeValue: types.StringValue("department")},
+		}, inheritedAdvancedAttributes)
+
+		var disabled []string
+		assert.False(t, state.DisabledInheritedAssertionAttributes.ElementsAs(context.TODO(), &disabled, false).HasError())
+		assert.Equal(t, []string{"last_name"}, disabled)
+		assert.True(t, state.DisabledInheritedAdvancedAssertionAttributes.IsNull())
+	})
+
+	t.Run("diff - unchanged attributes of a child application", func(t *testing.T) {
+
+		state, diags := applicationAssertionAttributesValueFrom(context.TODO(), childApp)
+		assert.False(t, diags.HasError())
+
+		planned, diags := getAssertionAttributesArgs(context.TODO(), state)
+		assert.False(t, diags.HasError())
+
+		reqs, diags := getAssertionAttributesPatch(childApp.AuthenticationSchema, planned)
+		assert.False(t, diags.HasError())
+		assert.Empty(t, reqs)
+	})
+
+	t.Run("diff - changed attributes and enabled inherited attributes", func(t *testing.T) {
+
+		planned := applications.AuthenticationSchema{
+			AssertionAttributes: []applications.AssertionAttribute{
+				{AssertionAttributeName: "email", UserAttributeName: "mail"},
+				{AssertionAttributeName: "cost_center", UserAttributeName: "costCenter"},
+			},
+			AdvancedAssertionAttributes: []applications.AdvancedAssertionAttribute{
+				{AttributeName: "full_name", AttributeValue: "${user.firstName} ${user.lastName}"},
+			},
+		}
+
+		reqs, diags := getAssertionAttributesPatch(childApp.AuthenticationSchema, planned)
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, []generic.PatchRequest{
+			{Op: "replace", Path: authSchemaPath + "/assertionAttributes", Value: planned.AssertionAttributes},
+			{Op: "replace", Path: authSchemaPath + "/disabledInheritedProperties", Value: &applications.DisabledInheritedProperties{}},
+		}, reqs)
+	})
+
+	t.Run("validation - expressions", func(t *testing.T) {
+
+		references, err := parseAssertionExpression("${user.firstName} (${corporateIdP.department})")
+		assert.NoError(t, err)
+		assert.Equal(t, []expressionReference{
+			{Scope: "user", Attribute: "firstName"},
+			{Scope: "corporateIdP", Attribute: "department"},
+		}, references)
+
+		_, err = parseAssertionExpression("${user.firstName")
+		assert.ErrorContains(t, err, "is not closed")
+
+		_, err = parseAssertionExpression("${user.${user.mail}}")
+		assert.ErrorContains(t, err, "nested reference")
+
+		_, err = parseAssertionExpression("${firstName}")
+		assert.ErrorContains(t, err, "must be written as ${<scope>.<attribute>}")
+	})
+
+	t.Run("validation - user attributes and expressions", func(t *testing.T) {
+
+		known := knownUserAttributesFrom([]schemas.Schema{customSchema})
+
+		attributes := []assertionAttributeMappingData{
+			{AttributeName: types.StringValue("email"), AttributeValue: types.StringValue("mail")},
+			{AttributeName: types.StringValue("cost_center"), AttributeValue: types.StringValue(customSchema.Id + ":costCenter")},
+			{AttributeName: types.StringValue("building"), AttributeValue: types.StringValue("office.building")},
+			{AttributeName: types.StringValue("phone"), AttributeValue: types.StringValue("phoneNumber")},
+		}
+
+		advancedAttributes := []advancedAssertionAttributeMappingData{
+			{Source: types.StringValue("Expression"), AttributeName: types.StringValue("full_name"), AttributeValue: types.StringValue("${user.firstName} ${user.surname}")},
+			{Source: types.StringValue("Expression"), AttributeName: types.StringValue("department"), AttributeValue: types.StringValue("${corporateIdP.department}")},
+			{Source: types.StringValue("Expression"), AttributeName: types.StringValue("greeting"), AttributeValue: types.StringValue("Hello ${user.firstName")},
+			{Source: types.StringValue("Corporate Identity Provider"), AttributeName: types.StringValue("email"), AttributeValue: types.StringValue("mail")},
+		}
+
+		diags := validateAssertionAttributeMappings(known, attributes, advancedAttributes)
+
+		assert.Equal(t, 3, diags.ErrorsCount())
+		assert.Equal(t, "Invalid Expression", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "use the source Corporate Identity Provider")
+		assert.Equal(t, "Invalid Expression", diags.Errors()[1].Summary())
+		assert.Contains(t, diags.Errors()[1].Detail(), "is not closed")
+		assert.Equal(t, "Duplicate Assertion Attribute", diags.Errors()[2].Summary())
+
+		assert.Equal(t, 2, diags.WarningsCount())
+		assert.Contains(t, diags.Warnings()[0].Detail(), "phoneNumber")
+		assert.Contains(t, diags.Warnings()[1].Detail(), "surname")
+	})
+
+	t.Run("validation - schemas of the tenant", func(t *testing.T) {
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/scim/Schemas/" {
+				_ = json.NewEncoder(w).Encode(schemas.SchemasResponse{Resources: []schemas.Schema{customSchema}})
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		diags := validateAssertionAttributes(context.TODO(), client, []assertionAttributeMappingData{
+			{AttributeName: types.StringValue("cost_center"), AttributeValue: types.StringValue("costCenter")},
+		}, nil)
+
+		assert.False(t, diags.HasError())
+		assert.Zero(t, diags.WarningsCount())
+
+		srv.Close()
+
+		diags = validateAssertionAttributes(context.TODO(), client, []assertionAttributeMappingData{
+			{AttributeName: types.StringValue("phone"), AttributeValue: types.StringValue("phoneNumber")},
+		}, nil)
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "Unable to validate assertion attributes", diags.Warnings()[0].Summary())
+	})
+}
+
+func ResourceApplicationAssertionAttributes(resourceName string, applicationId string, attributes ...string) string {
+	return fmt.Sprintf(`
+	resource "sci_application_assertion_attributes" "%s" {
+		application_id = "%s"
+		assertion_attributes = [%s]
+	}
+	`, resourceName, applicationId, strings.Join(attributes, ", "))
+}
+
+func applicationAssertionAttributesTestConfig(attributes []string, advancedAttributes []string) string {
+	return fmt.Sprintf(`
+	resource "sci_application" "testApp" {
+		name = "Terraform Assertion Attributes"
+
+		lifecycle {
+			ignore_changes = [authentication_schema.assertion_attributes, authentication_schema.advanced_assertion_attributes]
+		}
+	}
+
+	resource "sci_application_assertion_attributes" "testAttributes" {
+		application_id = sci_application.testApp.id
+		assertion_attributes = [%s]
+		advanced_assertion_attributes = [%s]
+	}
+	`, strings.Join(attributes, ", "), strings.Join(advancedAttributes, ", "))
+}
@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func newApplicationAuthenticationRulesResource() resource.Resource {
+	return &applicationAuthenticationRulesResource{}
+}
+
+type applicationAuthenticationRulesResource struct {
+	cli *cli.SciClient
+}
+
+var _ resource.ResourceWithModifyPlan = &applicationAuthenticationRulesResource{}
+
+func (r *applicationAuthenticationRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (r *applicationAuthenticationRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_authentication_rules"
+}
+
+func (r *applicationAuthenticationRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manage the ordered conditional authentication rules of an application in the SAP Cloud Identity Services tenant.
+
+The rules are evaluated in the order of the list until the conditions of a rule are fulfilled. The groups and corporate identity providers referenced by the rules are validated when planning, and changes are applied by adding, removing or replacing only the rules which differ.
+
+Use the **sci_authentication_rule_evaluation** data source to find out which rule matches a given user.
+
+### Conflict Warning
+The resource manages all rules of the application and must not be combined with the **conditional_authentication** of the **sci_application** resource for the same application. Add ` + "`authentication_schema.conditional_authentication`" + ` to the ` + "`ignore_changes`" + ` of the **sci_application** resource, so that it does not remove the rules.
+
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique ID of the resource, which is the ID of the application.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Unique ID of the application.",
+				Validators: []validator.String{
+					utils.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The rules for selecting the identity provider which authenticates a user, by email domain, user type, user group and IP range. The rules are evaluated in order until the conditions of a rule are fulfilled.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"identity_provider_id": schema.StringAttribute{
+							MarkdownDescription: "The identity provider to delegate authentication to when all the conditions of the rule are fulfilled. Corporate identity providers referenced by their UUID must exist in the tenant.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 255),
+							},
+						},
+						"user_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the user." + utils.ValidValuesString(usersTypeValues),
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(usersTypeValues...),
+								stringvalidator.AtLeastOneOf(
+									path.MatchRelative().AtParent().AtName("user_group"),
+									path.MatchRelative().AtParent().AtName("user_email_domain"),
+									path.MatchRelative().AtParent().AtName("ip_network_range"),
+								),
+							},
+						},
+						"user_group": schema.StringAttribute{
+							MarkdownDescription: "The ID of a group the user is a member of. The group must exist in the tenant.",
+							Optional:            true,
+							Validators: []validator.String{
+								utils.ValidUUID(),
+							},
+						},
+						"user_email_domain": schema.StringAttribute{
+							MarkdownDescription: "The domain of the email of the user. A domain starting with `*.` matches all its subdomains.",
+							Optional:            true,
+							Validators: []validator.String{
+								utils.ValidEmailDomain(),
+							},
+						},
+						"ip_network_range": schema.StringAttribute{
+							MarkdownDescription: "The IP range of the user in CIDR notation.",
+							Optional:            true,
+							Validators: []validator.String{
+								utils.ValidCIDR(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *applicationAuthenticationRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var plan applicationAuthenticationRulesData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.apply(ctx, plan, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *applicationAuthenticationRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var config applicationAuthenticationRulesData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, _, err := r.cli.Application.GetByAppId(ctx, config.ApplicationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving application", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags := applicationAuthenticationRulesValueFrom(ctx, res)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *applicationAuthenticationRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var plan applicationAuthenticationRulesData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.apply(ctx, plan, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *applicationAuthenticationRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	var config applicationAuthenticationRulesData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rulesPath, diags := getAuthenticationRulesPath()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, _, err := r.cli.Application.Update(ctx, []generic.PatchRequest{
+		utils.GenerateReplacePatchRequest(rulesPath, []applications.AuthenicationRule{}),
+	}, config.ApplicationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing authentication rules", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (r *applicationAuthenticationRulesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// the references are only validated when the rules are created or updated, and require a configured provider
+	if req.Plan.Raw.IsNull() || r.cli == nil {
+		return
+	}
+
+	var plan applicationAuthenticationRulesData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Rules.IsNull() || plan.Rules.IsUnknown() {
+		return
+	}
+
+	var rules []authenticationRulesData
+	diags = plan.Rules.ElementsAs(ctx, &rules, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateAuthenticationRuleReferences(ctx, r.cli, rules, path.Root("rules"))...)
+}
+
+func (r *applicationAuthenticationRulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), req.ID)...)
+}
+
+// apply reads the rules of the application, applies the operations which turn them into the planned rules and sets the resulting state
+func (r *applicationAuthenticationRulesResource) apply(ctx context.Context, plan applicationAuthenticationRulesData, state *tfsdk.State) diag.Diagnostics {
+
+	var diagnostics diag.Diagnostics
+
+	var planned []applications.AuthenicationRule
+	diags := plan.Rules.ElementsAs(ctx, &planned, true)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	rulesPath, diags := getAuthenticationRulesPath()
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	res, _, err := r.cli.Application.GetByAppId(ctx, plan.ApplicationId.ValueString())
+	if err != nil {
+		diagnostics.AddError("Error retrieving application", fmt.Sprintf("%s", err))
+		return diagnostics
+	}
+
+	var current []applications.AuthenicationRule
+	if res.AuthenticationSchema != nil {
+		current = res.AuthenticationSchema.ConditionalAuthentication
+	}
+
+	if reqs := getAuthenticationRulesPatch(rulesPath, current, planned); len(reqs) > 0 {
+		res, _, err = r.cli.Application.Update(ctx, reqs, plan.ApplicationId.ValueString())
+		if err != nil {
+			diagnostics.AddError("Error updating authentication rules", fmt.Sprintf("%s", err))
+			return diagnostics
+		}
+	}
+
+	updatedState, diags := applicationAuthenticationRulesValueFrom(ctx, res)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	diags = state.Set(ctx, &updatedState)
+	diagnostics.Append(diags...)
+
+	return diagnostics
+}
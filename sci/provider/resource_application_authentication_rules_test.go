@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceApplicationAuthenticationRules(t *testing.T) {
+	t.Parallel()
+
+	mockUuid := "af2f7963-358d-4336-bc51-57099394dee7"
+
+	rulesPath := "/urn:sap:identity:application:schemas:extension:sci:1.0:Authentication/conditionalAuthentication"
+
+	ruleA := applications.AuthenicationRule{IdentityProviderId: "idp-a", UserType: "employee"}
+	ruleB := applications.AuthenicationRule{IdentityProviderId: "idp-b", UserEmailDomain: "example.com"}
+	ruleC := applications.AuthenicationRule{IdentityProviderId: "idp-c", IpNetworkRange: "10.0.0.0/8"}
+	ruleD := applications.AuthenicationRule{IdentityProviderId: "idp-d", UserGroup: mockUuid}
+
+	t.Run("happy path", func(t *testing.T) {
+		requireCassette(t, "fixtures/resource_application_authentication_rules")
+		rec, user := setupVCR(t, "fixtures/resource_application_authentication_rules")
+		defer stopQuietly(rec)
+
+		idpId := "c93f6b04-7a0f-42c1-b3c5-3b30d0ad8910"
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + applicationAuthenticationRulesTestConfig(
+						fmt.Sprintf(`{ identity_provider_id = "%s", user_type = "employee" }`, idpId),
+					),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrPair("sci_application_authentication_rules.testRules", "application_id", "sci_application.testApp", "id"),
+						resource.TestCheckResourceAttr("sci_application_authentication_rules.testRules", "rules.#", "1"),
+						resource.TestCheckResourceAttr("sci_application_authentication_rules.testRules", "rules.0.identity_provider_id", idpId),
+						resource.TestCheckResourceAttr("sci_application_authentication_rules.testRules", "rules.0.user_type", "employee"),
+					),
+				},
+				{
+					ResourceName:      "sci_application_authentication_rules.testRules",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: providerConfig("", user) + applicationAuthenticationRulesTestConfig(
+						fmt.Sprintf(`{ identity_provider_id = "%s", user_email_domain = "example.com" }`, idpId),
+						fmt.Sprintf(`{ identity_provider_id = "%s", ip_network_range = "10.0.0.0/8" }`, idpId),
+					),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("sci_application_authentication_rules.testRules", "rules.#", "2"),
+						resource.TestCheckResourceAttr("sci_application_authentication_rules.testRules", "rules.0.user_email_domain", "example.com"),
+						resource.TestCheckNoResourceAttr("sci_application_authentication_rules.testRules", "rules.0.user_type"),
+						resource.TestCheckResourceAttr("sci_application_authentication_rules.testRules", "rules.1.ip_network_range", "10.0.0.0/8"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - application_id needs to be a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationAuthenticationRules("testRules", "not-a-valid-uuid", `{ identity_provider_id = "idp", user_type = "employee" }`),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: not-a-valid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - rules.ip_network_range needs to be a valid CIDR range", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationAuthenticationRules("testRules", mockUuid, `{ identity_provider_id = "idp", ip_network_range = "10.0.0.1" }`),
+					ExpectError: regexp.MustCompile(`value must be a valid IP network range in CIDR notation`),
+				},
+			},
+		})
+	})
+
+	t.Run("validation - rules.ip_network_range accepts the ranges which are evaluated", func(t *testing.T) {
+
+		for value, valid := range map[string]bool{
+			"10.0.0.0/8":       true,
+			"2001:db8::/32":    true,
+			"10.0.0.1":         false,
+			"10.0.0.0/33":      false,
+			"300.0.0.0/8":      false,
+			"not-a-cidr-range": false,
+		} {
+			var resp validator.StringResponse
+			utils.ValidCIDR().ValidateString(context.TODO(), validator.StringRequest{
+				Path:        path.Root("rules").AtListIndex(0).AtName("ip_network_range"),
+				ConfigValue: types.StringValue(value),
+			}, &resp)
+
+			assert.Equal(t, !valid, resp.Diagnostics.HasError(), value)
+		}
+
+		assert.True(t, ipNetworkRangeContains("2001:db8::/32", "2001:db8::1"))
+	})
+
+	t.Run("error path - rules.user_email_domain needs to be a valid domain", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationAuthenticationRules("testRules", mockUuid, `{ identity_provider_id = "idp", user_email_domain = "example" }`),
+					ExpectError: regexp.MustCompile(`value must be a valid Email Domain`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - rules.user_group needs to be a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationAuthenticationRules("testRules", mockUuid, `{ identity_provider_id = "idp", user_group = "group" }`),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: group`),
+				},
+			},
+		})
+	})
+
+	t.Run("path - rules of the application", func(t *testing.T) {
+
+		rulesPath, diags := getAuthenticationRulesPath()
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "/urn:sap:identity:application:schemas:extension:sci:1.0:Authentication/conditionalAuthentication", rulesPath)
+	})
+
+	t.Run("diff - unchanged rules", func(t *testing.T) {
+
+		reqs := getAuthenticationRulesPatch(rulesPath, []applications.AuthenicationRule{ruleA, ruleB}, []applications.AuthenicationRule{ruleA, ruleB})
+
+		assert.Empty(t, reqs)
+	})
+
+	t.Run("diff - rule inserted at the top", func(t *testing.T) {
+
+		reqs := getAuthenticationRulesPatch(rulesPath, []applications.AuthenicationRule{ruleA, ruleB, ruleC}, []applications.AuthenicationRule{ruleD, ruleA, ruleB, ruleC})
+
+		assert.Equal(t, []generic.PatchRequest{
+			{Op: "add", Path: rulesPath + "/0", Value: ruleD},
+		}, reqs)
+	})
+
+	t.Run("diff - rule removed and rule replaced", func(t *testing.T) {
+
+		reqs := getAuthenticationRulesPatch(rulesPath, []applications.AuthenicationRule{ruleA, ruleB, ruleC}, []applications.AuthenicationRule{ruleA, ruleD})
+
+		assert.Equal(t, []generic.PatchRequest{
+			{Op: "replace", Path: rulesPath + "/1", Value: ruleD},
+			{Op: "remove", Path: rulesPath + "/2"},
+		}, reqs)
+	})
+
+	t.Run("diff - rules reordered", func(t *testing.T) {
+
+		current := []applications.AuthenicationRule{ruleA, ruleB, ruleC}
+		planned := []applications.AuthenicationRule{ruleC, ruleA, ruleB}
+
+		reqs := getAuthenticationRulesPatch(rulesPath, current, planned)
+
+		assert.Equal(t, []generic.PatchRequest{
+			{Op: "add", Path: rulesPath + "/0", Value: ruleC},
+			{Op: "remove", Path: rulesPath + "/3"},
+		}, reqs)
+	})
+
+	t.Run("diff - all rules added", func(t *testing.T) {
+
+		reqs := getAuthenticationRulesPatch(rulesPath, nil, []applications.AuthenicationRule{ruleA, ruleB})
+
+		assert.Equal(t, []generic.PatchRequest{
+			{Op: "add", Path: rulesPath + "/0", Value: ruleA},
+			{Op: "add", Path: rulesPath + "/1", Value: ruleB},
+		}, reqs)
+	})
+
+	t.Run("value - rules of the application", func(t *testing.T) {
+
+		rules, diags := applicationAuthenticationRulesValueFrom(context.TODO(), applications.Application{
+			Id: mockUuid,
+			AuthenticationSchema: &applications.AuthenticationSchema{
+				ConditionalAuthentication: []applications.AuthenicationRule{ruleA, ruleD},
+			},
+		})
+		assert.False(t, diags.HasError())
+
+		var rulesData []authenticationRulesData
+		diags = rules.Rules.ElementsAs(context.TODO(), &rulesData, false)
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, types.StringValue(mockUuid), rules.ApplicationId)
+		assert.Equal(t, []authenticationRulesData{
+			{
+				IdentityProviderId: types.StringValue("idp-a"),
+				UserType:           types.StringValue("employee"),
+				UserGroup:          types.StringNull(),
+				UserEmailDomain:    types.StringNull(),
+				IpNetworkRange:     types.StringNull(),
+			},
+			{
+				IdentityProviderId: types.StringValue("idp-d"),
+				UserType:           types.StringNull(),
+				UserGroup:          types.StringValue(mockUuid),
+				UserEmailDomain:    types.StringNull(),
+				IpNetworkRange:     types.StringNull(),
+			},
+		}, rulesData)
+	})
+
+	t.Run("validation - unknown groups and corporate idps", func(t *testing.T) {
+
+		existingIdp := "0d2e5f4e-8c1b-4c70-9d4e-3f6c0a3b9a11"
+		missingIdp := "8f3b6a1c-2d4e-4f5a-9b7c-1e2d3f4a5b6c"
+		missingGroup := "5c4b3a29-1d0e-4f8a-b7c6-d5e4f3a2b1c0"
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/IdentityProviders/v1/":
+				_ = json.NewEncoder(w).Encode(corporateidps.IdentityProvidersResponse{
+					IdentityProviders: []corporateidps.IdentityProvider{{Id: existingIdp}},
+				})
+			case r.URL.Path == "/scim/Groups/"+mockUuid:
+				_ = json.NewEncoder(w).Encode(groups.Group{Id: mockUuid})
+			case strings.HasPrefix(r.URL.Path, "/scim/Groups/"):
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"status":"404","detail":"group not found"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer srv.Close()
+
+		rules := []authenticationRulesData{
+			{IdentityProviderId: types.StringValue(existingIdp), UserGroup: types.StringValue(mockUuid)},
+			{IdentityProviderId: types.StringValue(missingIdp), UserGroup: types.StringValue(missingGroup)},
+			{IdentityProviderId: types.StringValue("sap.default"), UserGroup: types.StringUnknown()},
+		}
+
+		diags := validateAuthenticationRuleReferences(context.TODO(), client, rules, path.Root("rules"))
+
+		assert.Equal(t, 2, diags.ErrorsCount())
+		assert.Equal(t, "Unknown Corporate Identity Provider", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), missingIdp)
+		assert.Equal(t, "Unknown Group", diags.Errors()[1].Summary())
+		assert.Contains(t, diags.Errors()[1].Detail(), missingGroup)
+	})
+}
+
+func ResourceApplicationAuthenticationRules(resourceName string, applicationId string, rules ...string) string {
+	return fmt.Sprintf(`
+	resource "sci_application_authentication_rules" "%s" {
+		application_id = "%s"
+		rules = [%s]
+	}
+	`, resourceName, applicationId, strings.Join(rules, ", "))
+}
+
+func applicationAuthenticationRulesTestConfig(rules ...string) string {
+	return fmt.Sprintf(`
+	resource "sci_application" "testApp" {
+		name = "Terraform Authentication Rules"
+
+		lifecycle {
+			ignore_changes = [authentication_schema.conditional_authentication]
+		}
+	}
+
+	resource "sci_application_authentication_rules" "testRules" {
+		application_id = sci_application.testApp.id
+		rules = [%s]
+	}
+	`, strings.Join(rules, ", "))
+}
@@ -1,16 +1,33 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
 	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
 
+	"github.com/beevik/etree"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
 )
 
 var regexpUUID = utils.UuidRegexp
@@ -972,6 +989,82 @@ func TestResourceApplication(t *testing.T) {
 		})
 	})
 
+	t.Run("happy path - application api_certificates", func(t *testing.T) {
+
+		requireCassette(t, "fixtures/resource_application_api_certificates")
+
+		rec, user := setupVCR(t, "fixtures/resource_application_api_certificates")
+		defer stopQuietly(rec)
+
+		// the API certificates are added in the tenant, an application created by the provider has none
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + ResourceApplicationWithDescription("testApp", "api-certificates-test-app", "application without API certificates"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestMatchResourceAttr("sci_application.testApp", "id", regexpUUID),
+						resource.TestCheckNoResourceAttr("sci_application.testApp", "api_certificates.#"),
+					),
+				},
+				{
+					ResourceName:      "sci_application.testApp",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: providerConfig("", user) + ResourceApplicationWithDescription("testApp", "api-certificates-test-app", "application still without API certificates"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("sci_application.testApp", "description", "application still without API certificates"),
+						resource.TestCheckNoResourceAttr("sci_application.testApp", "api_certificates.#"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - oidc application oidc_client", func(t *testing.T) {
+
+		requireCassette(t, "fixtures/resource_application_oidc_client")
+
+		rec, user := setupVCR(t, "fixtures/resource_application_oidc_client")
+		defer stopQuietly(rec)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + ResourceApplicationWithSsoType("testApp", "oidc-client-test-app", "application with an OIDC client", "openIdConnect", ""),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestMatchResourceAttr("sci_application.testApp", "id", regexpUUID),
+						resource.TestMatchResourceAttr("sci_application.testApp", "oidc_client.client_id", regexpUUID),
+						resource.TestCheckResourceAttr("sci_application.testApp", "oidc_client.issuer", "https://iasprovidertestblr.accounts400.ondemand.com"),
+						resource.TestCheckResourceAttr("sci_application.testApp", "oidc_client.authorization_endpoint", "https://iasprovidertestblr.accounts400.ondemand.com/oauth2/authorize"),
+						resource.TestCheckResourceAttr("sci_application.testApp", "oidc_client.token_endpoint", "https://iasprovidertestblr.accounts400.ondemand.com/oauth2/token"),
+						resource.TestCheckResourceAttr("sci_application.testApp", "oidc_client.jwks_uri", "https://iasprovidertestblr.accounts400.ondemand.com/oauth2/certs"),
+						resource.TestCheckResourceAttr("sci_application.testApp", "oidc_client.logout_endpoint", "https://iasprovidertestblr.accounts400.ondemand.com/oauth2/logout"),
+						resource.TestCheckNoResourceAttr("sci_application.testApp", "saml2_idp.entity_id"),
+					),
+				},
+				{
+					ResourceName:      "sci_application.testApp",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: providerConfig("", user) + ResourceApplicationWithSsoType("testApp", "oidc-client-test-app", "application with an unchanged OIDC client", "openIdConnect", ""),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("sci_application.testApp", "description", "application with an unchanged OIDC client"),
+						resource.TestMatchResourceAttr("sci_application.testApp", "oidc_client.client_id", regexpUUID),
+						resource.TestCheckResourceAttr("sci_application.testApp", "oidc_client.issuer", "https://iasprovidertestblr.accounts400.ondemand.com"),
+					),
+				},
+			},
+		})
+	})
+
 	t.Run("happy path - application with fallback_attribute in subject_name_identifier", func(t *testing.T) {
 
 		appWithFallback := applications.Application{
@@ -1398,6 +1491,195 @@ func TestResourceApplication(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - saml2_config.proxy_authn_request.authn_context needs to be a valid value", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationWithSaml2ProxyAuthnRequest("testApp", "test-app", "this-is-not-a-valid-authn-context"),
+					ExpectError: regexp.MustCompile(`Attribute authentication_schema.saml2_config.proxy_authn_request.authn_context\nvalue must be one of`),
+				},
+			},
+		})
+	})
+
+	t.Run("value - saml2_config.proxy_authn_request is round-tripped", func(t *testing.T) {
+
+		proxyAuthnRequest := &applications.ProxyAuthnRequest{
+			AuthenticationContext: "urn:oasis:names:tc:SAML:2.0:ac:classes:X509",
+			IssuerNameSuffix:      "my-app",
+		}
+
+		app := applications.Application{
+			Name: "proxy-app",
+			AuthenticationSchema: &applications.AuthenticationSchema{
+				SsoType: "saml2",
+				Saml2Configuration: &applications.SamlConfiguration{
+					ProxyAuthnRequest: proxyAuthnRequest,
+				},
+			},
+		}
+
+		state, diags := applicationValueFrom(context.TODO(), app)
+		assert.False(t, diags.HasError())
+
+		args, diags := getApplicationRequest(context.TODO(), state)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, proxyAuthnRequest, args.AuthenticationSchema.Saml2Configuration.ProxyAuthnRequest)
+
+		// only the suffix is changed
+		app.AuthenticationSchema.Saml2Configuration.ProxyAuthnRequest = &applications.ProxyAuthnRequest{
+			AuthenticationContext: proxyAuthnRequest.AuthenticationContext,
+		}
+
+		plan, diags := applicationValueFrom(context.TODO(), app)
+		assert.False(t, diags.HasError())
+
+		reqs, diags := getApplicationUpdateRequest(context.TODO(), plan, state)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, []generic.PatchRequest{
+			{
+				Op:    "replace",
+				Path:  "/urn:sap:identity:application:schemas:extension:sci:1.0:Authentication/saml2Configuration/proxyAuthnRequest",
+				Value: applications.ProxyAuthnRequest{AuthenticationContext: proxyAuthnRequest.AuthenticationContext},
+			},
+		}, reqs)
+	})
+
+	t.Run("validation - planned saml2_config.metadata_xml", func(t *testing.T) {
+
+		saml2Path := path.Root("authentication_schema").AtName("saml2_config")
+		metadataPath := saml2Path.AtName("metadata_xml")
+		certificatePath := saml2Path.AtName("metadata_signing_certificate")
+		now := time.Now()
+
+		unsignedXml := samlMetadataXml(t)
+		signedXml, signingCertificate := signedSamlMetadataXml(t)
+		_, otherCertificate := signedSamlMetadataXml(t)
+
+		// unsigned metadata is accepted with a warning without signing certificate
+		diags := checkPlannedAppSamlMetadata(unsignedXml, types.StringNull(), metadataPath, certificatePath, now)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "SAML Metadata Not Signed", diags[0].Summary())
+
+		// the signature cannot be verified without signing certificate
+		diags = checkPlannedAppSamlMetadata(signedXml, types.StringNull(), metadataPath, certificatePath, now)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "SAML Metadata Signature Not Verified", diags[0].Summary())
+
+		diags = checkPlannedAppSamlMetadata(signedXml, types.StringValue(signingCertificate), metadataPath, certificatePath, now)
+		assert.Empty(t, diags)
+
+		diags = checkPlannedAppSamlMetadata(signedXml, types.StringValue(otherCertificate), metadataPath, certificatePath, now)
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "the signature of the metadata is invalid : Could not verify certificate against trusted certs", diags[0].Detail())
+
+		diags = checkPlannedAppSamlMetadata(unsignedXml, types.StringValue(signingCertificate), metadataPath, certificatePath, now)
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "the metadata is not signed", diags[0].Detail())
+
+		// the certificate of the metadata is valid for a day
+		diags = checkPlannedAppSamlMetadata(unsignedXml, types.StringNull(), metadataPath, certificatePath, now.Add(48*time.Hour))
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Detail(), "the certificate expired at")
+	})
+
+	t.Run("create - the application is kept in the state if the inherited values cannot be disabled", func(t *testing.T) {
+
+		app := applications.Application{
+			Id:                  "app-id",
+			Name:                "inheriting-app",
+			ParentApplicationId: "parent-id",
+			AuthenticationSchema: &applications.AuthenticationSchema{
+				AssertionAttributes: []applications.AssertionAttribute{
+					{AssertionAttributeName: "mail", UserAttributeName: "mail", Inherited: true},
+				},
+			},
+		}
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				w.Header().Set("Location", "/Applications/v1/app-id")
+				w.WriteHeader(http.StatusCreated)
+			case http.MethodPatch:
+				w.WriteHeader(http.StatusInternalServerError)
+			default:
+				_ = json.NewEncoder(w).Encode(app)
+			}
+		}))
+		defer srv.Close()
+
+		r := &applicationResource{cli: client}
+
+		var schemaResp tfresource.SchemaResponse
+		r.Schema(context.TODO(), tfresource.SchemaRequest{}, &schemaResp)
+
+		plan := tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.TODO()), nil),
+		}
+		assert.False(t, plan.SetAttribute(context.TODO(), path.Root("name"), app.Name).HasError())
+		assert.False(t, plan.SetAttribute(context.TODO(), path.Root("parent_application_id"), app.ParentApplicationId).HasError())
+		assert.False(t, plan.SetAttribute(context.TODO(), path.Root("override_inherited"), []string{"assertion_attributes"}).HasError())
+
+		resp := tfresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.TODO(), tfresource.CreateRequest{Plan: plan}, &resp)
+
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Error updating application", resp.Diagnostics.Errors()[0].Summary())
+
+		var id types.String
+		assert.False(t, resp.State.GetAttribute(context.TODO(), path.Root("id"), &id).HasError())
+		assert.Equal(t, "app-id", id.ValueString())
+	})
+
+	t.Run("value - saml2_config is derived from the verified metadata", func(t *testing.T) {
+
+		signedXml, signingCertificate := signedSamlMetadataXml(t)
+
+		metadata, err := appSamlMetadataFrom(signedXml, types.StringValue(signingCertificate))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, metadata.AcsEndpoints)
+
+		// metadata modified after signing is not used
+		_, err = appSamlMetadataFrom(strings.Replace(signedXml, "https://", "https://attacker.", 1), types.StringValue(signingCertificate))
+		assert.Error(t, err)
+
+		// without signing certificate the metadata is parsed as is
+		_, err = appSamlMetadataFrom(signedXml, types.StringNull())
+		assert.NoError(t, err)
+	})
+
+	t.Run("error path - saml2_config.metadata_xml must be valid metadata", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationWithSaml2MetadataXml("testApp", "test-app", `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com"></md:EntityDescriptor>`, ""),
+					ExpectError: regexp.MustCompile(`the metadata does not contain an SPSSODescriptor`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - saml2_config.metadata_xml conflicts with the derived attributes", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationWithSaml2MetadataXml("testApp", "test-app", samlMetadataXml(t), `acs_endpoints = [{ binding_name = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST", location = "https://sp.example.com/saml/acs", index = 0 }]`),
+					ExpectError: regexp.MustCompile(`Attribute "authentication_schema.saml2_config.acs_endpoints" cannot be specified\nwhen "authentication_schema.saml2_config.metadata_xml" is specified`),
+				},
+			},
+		})
+	})
+
 	t.Run("error path - oidc_config.front_channel_logout invalid URI", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -1475,6 +1757,273 @@ func TestResourceApplication(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("happy path - child application with override_inherited", func(t *testing.T) {
+
+		requireCassette(t, "fixtures/resource_application_override_inherited")
+
+		rec, user := setupVCR(t, "fixtures/resource_application_override_inherited")
+		defer stopQuietly(rec)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + applicationOverrideInheritedTestConfig("assertion_attributes"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestMatchResourceAttr("sci_application.childApp", "id", regexpUUID),
+						resource.TestCheckResourceAttrPair("sci_application.childApp", "parent_application_id", "sci_application.parentApp", "id"),
+						resource.TestCheckResourceAttr("sci_application.childApp", "override_inherited.#", "1"),
+						resource.TestCheckTypeSetElemAttr("sci_application.childApp", "override_inherited.*", "assertion_attributes"),
+						// only the values of the child application are part of its state
+						resource.TestCheckResourceAttr("sci_application.childApp", "authentication_schema.assertion_attributes.#", "1"),
+						resource.TestCheckResourceAttr("sci_application.childApp", "authentication_schema.assertion_attributes.0.attribute_name", "child_email"),
+					),
+				},
+				{
+					ResourceName:      "sci_application.childApp",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: providerConfig("", user) + applicationOverrideInheritedTestConfig("assertion_attributes", "advanced_assertion_attributes"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("sci_application.childApp", "override_inherited.#", "2"),
+						resource.TestCheckTypeSetElemAttr("sci_application.childApp", "override_inherited.*", "advanced_assertion_attributes"),
+						resource.TestCheckResourceAttr("sci_application.childApp", "authentication_schema.assertion_attributes.#", "1"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - override_inherited requires parent_application_id", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceApplicationWithOverrideInherited("testApp", "test-app", "", "assertion_attributes"),
+					ExpectError: regexp.MustCompile(`Attribute "parent_application_id" must be specified when "override_inherited"`),
+				},
+			},
+		})
+	})
+
+	childApp := applications.Application{
+		Id:                  "af2f7963-358d-4336-bc51-57099394dee7",
+		Name:                "child-app",
+		ParentApplicationId: "0d2e5f4e-8c1b-4c70-9d4e-3f6c0a3b9a11",
+		AuthenticationSchema: &applications.AuthenticationSchema{
+			AssertionAttributes: []applications.AssertionAttribute{
+				{AssertionAttributeName: "first_name", UserAttributeName: "firstName", Inherited: true},
+				{AssertionAttributeName: "email", UserAttributeName: "mail"},
+			},
+			AdvancedAssertionAttributes: []applications.AdvancedAssertionAttribute{
+				{AttributeName: "department", AttributeValue: "${corporateIdP.department}", Inherited: true},
+			},
+			DisabledInheritedProperties: &applications.DisabledInheritedProperties{
+				AssertionAttributes: []applications.AssertionAttribute{{AssertionAttributeName: "last_name", Inherited: true}},
+			},
+		},
+	}
+
+	t.Run("value - inherited values of a child application are left out", func(t *testing.T) {
+
+		plan := applicationData{
+			AuthenticationSchema: types.ObjectNull(authenticationSchemaObjType),
+			OverrideInherited:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("assertion_attributes"), types.StringValue("advanced_assertion_attributes")}),
+		}
+
+		state, diags := applicationResourceValueFrom(context.TODO(), childApp, plan)
+		assert.False(t, diags.HasError())
+
+		var authSchema authenticationSchemaData
+		diags = state.AuthenticationSchema.As(context.TODO(), &authSchema, basetypes.ObjectAsOptions{})
+		assert.False(t, diags.HasError())
+
+		var attributes []applications.AssertionAttribute
+		diags = authSchema.AssertionAttributes.ElementsAs(context.TODO(), &attributes, false)
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, []applications.AssertionAttribute{{AssertionAttributeName: "email", UserAttributeName: "mail"}}, attributes)
+		assert.True(t, authSchema.AdvancedAssertionAttributes.IsNull())
+
+		// both properties inherit values again, so that the overrides are planned again
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{}), state.OverrideInherited)
+
+		// without overrides nothing is planned
+		state, diags = applicationResourceValueFrom(context.TODO(), childApp, applicationData{
+			AuthenticationSchema: types.ObjectNull(authenticationSchemaObjType),
+			OverrideInherited:    types.SetNull(types.StringType),
+		})
+		assert.False(t, diags.HasError())
+		assert.True(t, state.OverrideInherited.IsNull())
+	})
+
+	t.Run("value - effective configuration of a child application", func(t *testing.T) {
+
+		state, diags := applicationValueFrom(context.TODO(), childApp)
+		assert.False(t, diags.HasError())
+
+		var authSchema authenticationSchemaData
+		diags = state.AuthenticationSchema.As(context.TODO(), &authSchema, basetypes.ObjectAsOptions{})
+		assert.False(t, diags.HasError())
+
+		assert.Len(t, authSchema.AssertionAttributes.Elements(), 2)
+		assert.Len(t, authSchema.AdvancedAssertionAttributes.Elements(), 1)
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("assertion_attributes")}), state.OverrideInherited)
+	})
+
+	t.Run("value - override_inherited is read from the tenant", func(t *testing.T) {
+
+		app := childApp
+		app.AuthenticationSchema = &applications.AuthenticationSchema{
+			AssertionAttributes: []applications.AssertionAttribute{
+				{AssertionAttributeName: "email", UserAttributeName: "mail"},
+			},
+			DisabledInheritedProperties: childApp.AuthenticationSchema.DisabledInheritedProperties,
+		}
+
+		// e.g. on import the override is taken from the disabled inherited properties
+		state, diags := applicationResourceValueFrom(context.TODO(), app, applicationData{
+			AuthenticationSchema: types.ObjectNull(authenticationSchemaObjType),
+			OverrideInherited:    types.SetNull(types.StringType),
+		})
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("assertion_attributes")}), state.OverrideInherited)
+
+		// an override which was removed outside of Terraform shows up as a difference
+		app.AuthenticationSchema.DisabledInheritedProperties = nil
+
+		state, diags = applicationResourceValueFrom(context.TODO(), app, applicationData{
+			AuthenticationSchema: types.ObjectNull(authenticationSchemaObjType),
+			OverrideInherited:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("assertion_attributes")}),
+		})
+		assert.False(t, diags.HasError())
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{}), state.OverrideInherited)
+	})
+
+	t.Run("diff - override inherited values", func(t *testing.T) {
+
+		patchReqs, diags := getOverrideInheritedPatch(context.TODO(), childApp, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("assertion_attributes")}))
+		assert.False(t, diags.HasError())
+
+		// only the list of the overridden property is replaced
+		assert.Equal(t, []generic.PatchRequest{
+			{
+				Op:   "replace",
+				Path: "/urn:sap:identity:application:schemas:extension:sci:1.0:Authentication/disabledInheritedProperties/assertionAttributes",
+				Value: []applications.AssertionAttribute{
+					{AssertionAttributeName: "last_name", Inherited: true},
+					{AssertionAttributeName: "first_name", UserAttributeName: "firstName", Inherited: true},
+				},
+			},
+		}, patchReqs)
+
+		// without overrides the disabled inherited values are enabled again
+		patchReqs, diags = getOverrideInheritedPatch(context.TODO(), childApp, types.SetNull(types.StringType))
+		assert.False(t, diags.HasError())
+		assert.Equal(t, []generic.PatchRequest{
+			{
+				Op:    "replace",
+				Path:  "/urn:sap:identity:application:schemas:extension:sci:1.0:Authentication/disabledInheritedProperties/assertionAttributes",
+				Value: []applications.AssertionAttribute{},
+			},
+		}, patchReqs)
+
+		// an override which is already in effect is left untouched
+		app := childApp
+		app.AuthenticationSchema = &applications.AuthenticationSchema{
+			DisabledInheritedProperties: childApp.AuthenticationSchema.DisabledInheritedProperties,
+		}
+
+		patchReqs, diags = getOverrideInheritedPatch(context.TODO(), app, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("assertion_attributes")}))
+		assert.False(t, diags.HasError())
+		assert.Empty(t, patchReqs)
+	})
+
+	t.Run("value - api certificates with certificate details", func(t *testing.T) {
+
+		certificate := testCertificate(t)
+		app := childApp
+		app.AuthenticationSchema = &applications.AuthenticationSchema{
+			ApiCertificates: []applications.ApiCertificateData{
+				{
+					Id:                "certificate-id",
+					Dn:                "CN=sp.example.com",
+					Description:       "certificate for testing purposes",
+					Base64Certificate: certificate,
+				},
+			},
+		}
+
+		data, diags := applicationValueFrom(context.TODO(), app)
+		assert.False(t, diags.HasError())
+
+		var apiCertificates []applicationApiCertificateData
+		diags = data.ApiCertificates.ElementsAs(context.TODO(), &apiCertificates, false)
+		assert.False(t, diags.HasError())
+
+		assert.Len(t, apiCertificates, 1)
+		assert.Equal(t, "certificate-id", apiCertificates[0].Id)
+		assert.Equal(t, certificate, apiCertificates[0].Base64Certificate)
+		assert.Equal(t, "CN=sp.example.com", *apiCertificates[0].SubjectDn)
+		assert.NotNil(t, apiCertificates[0].Sha256Fingerprint)
+		assert.NotNil(t, apiCertificates[0].NotAfter)
+
+		// the certificate is valid for a day
+		diags = certificateExpiryWarning(path.Root("api_certificates").AtListIndex(0), apiCertificates[0].Base64Certificate, 30, time.Now())
+		assert.Len(t, diags, 1)
+		assert.Equal(t, "Certificate Expires Soon", diags[0].Summary())
+
+		app.AuthenticationSchema.ApiCertificates = nil
+		data, diags = applicationValueFrom(context.TODO(), app)
+		assert.False(t, diags.HasError())
+		assert.True(t, data.ApiCertificates.IsNull())
+	})
+
+	t.Run("value - endpoints derived from the tenant URL", func(t *testing.T) {
+
+		tenantUrl, _ := url.Parse("https://iasprovidertestblr.accounts400.ondemand.com/")
+
+		oidcClient, saml2Idp, diags := applicationEndpointsValueFrom(context.TODO(), tenantUrl, "openIdConnect", applicationClientId(childApp))
+		assert.False(t, diags.HasError())
+		assert.True(t, saml2Idp.IsNull())
+
+		var client applicationOidcClientData
+		diags = oidcClient.As(context.TODO(), &client, basetypes.ObjectAsOptions{})
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, applicationOidcClientData{
+			ClientId:              types.StringValue(childApp.Id),
+			Issuer:                types.StringValue("https://iasprovidertestblr.accounts400.ondemand.com"),
+			AuthorizationEndpoint: types.StringValue("https://iasprovidertestblr.accounts400.ondemand.com/oauth2/authorize"),
+			TokenEndpoint:         types.StringValue("https://iasprovidertestblr.accounts400.ondemand.com/oauth2/token"),
+			JwksUri:               types.StringValue("https://iasprovidertestblr.accounts400.ondemand.com/oauth2/certs"),
+			LogoutEndpoint:        types.StringValue("https://iasprovidertestblr.accounts400.ondemand.com/oauth2/logout"),
+		}, client)
+
+		oidcClient, saml2Idp, diags = applicationEndpointsValueFrom(context.TODO(), tenantUrl, "saml2", applicationClientId(childApp))
+		assert.False(t, diags.HasError())
+		assert.True(t, oidcClient.IsNull())
+
+		var idp applicationSaml2IdpData
+		diags = saml2Idp.As(context.TODO(), &idp, basetypes.ObjectAsOptions{})
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, applicationSaml2IdpData{
+			EntityId: types.StringValue("iasprovidertestblr.accounts400.ondemand.com"),
+			SsoUrl:   types.StringValue("https://iasprovidertestblr.accounts400.ondemand.com/saml2/idp/sso"),
+			SloUrl:   types.StringValue("https://iasprovidertestblr.accounts400.ondemand.com/saml2/idp/slo"),
+		}, idp)
+
+		// a client ID returned by the tenant takes precedence over the ID of the application
+		app := childApp
+		app.AuthenticationSchema = &applications.AuthenticationSchema{ClientId: "0c7d8f1e-2b4a-4e6f-9a3d-5b8c1e7f2a90"}
+		assert.Equal(t, "0c7d8f1e-2b4a-4e6f-9a3d-5b8c1e7f2a90", applicationClientId(app))
+	})
 }
 
 func ResourceApplication(resourceName string, app applications.Application) string {
@@ -1742,6 +2291,15 @@ func ResourceApplicationWithParent(resourceName string, appName string, descript
 	`, resourceName, appName, description, parentAppId)
 }
 
+func ResourceApplicationWithDescription(resourceName string, appName string, description string) string {
+	return fmt.Sprintf(`
+	resource "sci_application" "%s" {
+		name = "%s"
+		description = "%s"
+	}
+	`, resourceName, appName, description)
+}
+
 func ResourceApplicationWithAppId(resourceName string, appID string, appName string, description string) string {
 	return fmt.Sprintf(`
 	resource "sci_application" "%s" {
@@ -1752,6 +2310,49 @@ func ResourceApplicationWithAppId(resourceName string, appID string, appName str
 	`, resourceName, appID, appName, description)
 }
 
+func ResourceApplicationWithOverrideInherited(resourceName string, appName string, parentAppId string, properties ...string) string {
+
+	parent := ""
+	if len(parentAppId) > 0 {
+		parent = fmt.Sprintf(`parent_application_id = "%s"`, parentAppId)
+	}
+
+	return fmt.Sprintf(`
+	resource "sci_application" "%s" {
+		name = "%s"
+		%s
+		override_inherited = ["%s"]
+	}
+	`, resourceName, appName, parent, strings.Join(properties, `", "`))
+}
+
+func applicationOverrideInheritedTestConfig(properties ...string) string {
+	return fmt.Sprintf(`
+	resource "sci_application" "parentApp" {
+		name = "Terraform Override Inherited Parent"
+		authentication_schema = {
+			assertion_attributes = [
+				{ attribute_name = "parent_email", attribute_value = "mail" }
+			]
+			advanced_assertion_attributes = [
+				{ source = "Expression", attribute_name = "parent_name", attribute_value = "$${user.firstName}" }
+			]
+		}
+	}
+
+	resource "sci_application" "childApp" {
+		name = "Terraform Override Inherited Child"
+		parent_application_id = sci_application.parentApp.id
+		override_inherited = ["%s"]
+		authentication_schema = {
+			assertion_attributes = [
+				{ attribute_name = "child_email", attribute_value = "mail" }
+			]
+		}
+	}
+	`, strings.Join(properties, `", "`))
+}
+
 func ResourceApplicationWithoutAppName(resourceName string) string {
 	return fmt.Sprintf(`
 	resource "sci_application" "%s" {
@@ -2055,6 +2656,23 @@ func ResourceApplicationWithSaml2EncryptionCertificates(resourceName string, app
     `, resourceName, appName, encryptionCertificate)
 }
 
+func ResourceApplicationWithSaml2MetadataXml(resourceName string, appName string, metadataXml string, saml2Config string) string {
+	return fmt.Sprintf(`
+    resource "sci_application" "%s" {
+        name = "%s"
+        authentication_schema = {
+            sso_type = "saml2"
+            saml2_config = {
+                metadata_xml = <<-EOT
+%s
+EOT
+                %s
+            }
+        }
+    }
+    `, resourceName, appName, metadataXml, saml2Config)
+}
+
 func ResourceApplicationWithSaml2DefaultNameIdFormat(resourceName string, appName string, nameIdFormat string) string {
 	return fmt.Sprintf(`
     resource "sci_application" "%s" {
@@ -2084,6 +2702,22 @@ func ResourceApplicationWithSaml2ResponseElementsToEncrypt(resourceName string,
     `, resourceName, appName, encryptionCertificate, element)
 }
 
+func ResourceApplicationWithSaml2ProxyAuthnRequest(resourceName string, appName string, authnContext string) string {
+	return fmt.Sprintf(`
+    resource "sci_application" "%s" {
+        name = "%s"
+        authentication_schema = {
+            sso_type = "saml2"
+            saml2_config = {
+				proxy_authn_request = {
+					authn_context = "%s"
+				}
+            }
+        }
+    }
+    `, resourceName, appName, authnContext)
+}
+
 func ResourceApplicationWithSaml2DigestAlgorithm(resourceName string, appName string, digestAlgorithm string) string {
 	return fmt.Sprintf(`
     resource "sci_application" "%s" {
@@ -2097,3 +2731,29 @@ func ResourceApplicationWithSaml2DigestAlgorithm(resourceName string, appName st
     }
     `, resourceName, appName, digestAlgorithm)
 }
+
+// signedSamlMetadataXml returns the metadata of samlMetadataXml signed with a random key, and the PEM certificate of the key
+func signedSamlMetadataXml(t *testing.T) (string, string) {
+	return signSamlMetadataXml(t, samlMetadataXml(t))
+}
+
+// signSamlMetadataXml signs the metadata with a random key and returns the signed metadata and the certificate of the key
+func signSamlMetadataXml(t *testing.T, metadataXml string) (string, string) {
+
+	document := etree.NewDocument()
+	err := document.ReadFromString(strings.Replace(metadataXml, "entityID=", `ID="_metadata" entityID=`, 1))
+	assert.NoError(t, err)
+
+	keyStore := dsig.RandomKeyStoreForTest()
+	signed, err := dsig.NewDefaultSigningContext(keyStore).SignEnveloped(document.Root())
+	assert.NoError(t, err)
+
+	document.SetRoot(signed)
+	metadataXml, err = document.WriteToString()
+	assert.NoError(t, err)
+
+	_, certificate, err := keyStore.GetKeyPair()
+	assert.NoError(t, err)
+
+	return metadataXml, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}))
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
@@ -25,6 +26,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 var (
@@ -47,6 +49,8 @@ type corporateIdPResource struct {
 	cli *cli.SciClient
 }
 
+var _ resource.ResourceWithModifyPlan = &corporateIdPResource{}
+
 func (r *corporateIdPResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -215,6 +219,40 @@ func (r *corporateIdPResource) Schema(_ context.Context, _ resource.SchemaReques
 							utils.ValidUrl(),
 						},
 					},
+					"metadata_xml": schema.StringAttribute{
+						MarkdownDescription: "The metadata of the identity provider, a SAML `EntityDescriptor` XML document. The SSO and SLO endpoints and the signing certificates are derived from the metadata and shown in the plan. The metadata is not stored by the tenant, a difference between the configuration derived from the metadata and the configuration of the tenant is reported as drift. Endpoints with bindings that are not supported are ignored, the first endpoint with the `HTTP-POST` binding, otherwise the `HTTP-Redirect` binding, is the default one. When the metadata is planned, it must not have expired and its certificates must be valid. The signature of the metadata is only verified with the `metadata_signing_certificate`.",
+						Optional:            true,
+						Validators: []validator.String{
+							utils.ValidSamlIdpMetadata(),
+							stringvalidator.ConflictsWith(
+								path.MatchRoot("saml2_config").AtName("metadata_file"),
+								path.MatchRoot("saml2_config").AtName("saml_metadata_url"),
+								path.MatchRoot("saml2_config").AtName("signing_certificates"),
+								path.MatchRoot("saml2_config").AtName("sso_endpoints"),
+								path.MatchRoot("saml2_config").AtName("slo_endpoints"),
+							),
+						},
+					},
+					"metadata_file": schema.StringAttribute{
+						MarkdownDescription: "The path of a file with the metadata of the identity provider. The file is read by the provider like `metadata_xml`, a change of the content of the file is detected on the next plan.",
+						Optional:            true,
+						Validators: []validator.String{
+							utils.ValidSamlIdpMetadataFile(),
+							stringvalidator.ConflictsWith(
+								path.MatchRoot("saml2_config").AtName("saml_metadata_url"),
+								path.MatchRoot("saml2_config").AtName("signing_certificates"),
+								path.MatchRoot("saml2_config").AtName("sso_endpoints"),
+								path.MatchRoot("saml2_config").AtName("slo_endpoints"),
+							),
+						},
+					},
+					"metadata_signing_certificate": schema.StringAttribute{
+						MarkdownDescription: "The trusted certificate of the identity provider, in PEM format, which the metadata of `metadata_xml` or `metadata_file` must be signed with. The signature is verified when the metadata or the certificate are planned, the certificate must be valid then. The `sso_endpoints`, `slo_endpoints` and `signing_certificates` derived from the metadata are only taken from the signed content. Without the certificate the metadata is accepted with a warning and its signature is not verified, as a certificate contained in the metadata does not prove who signed it. The certificate is not stored by the tenant.",
+						Optional:            true,
+						Validators: []validator.String{
+							utils.ValidCertificate(),
+						},
+					},
 					"assertion_attributes": schema.ListNestedAttribute{
 						MarkdownDescription: "Enrich the assertion attributes coming from the corporate IdP.",
 						Optional:            true,
@@ -242,6 +280,10 @@ func (r *corporateIdPResource) Schema(_ context.Context, _ resource.SchemaReques
 					"signing_certificates": schema.ListNestedAttribute{
 						MarkdownDescription: "Base64-encoded certificates used by the service provider to sign digitally, SAML protocol messages sent to Identity Authentication. A maximum of 2 certificates are allowed.",
 						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.List{
+							idpSamlMetadataValue(),
+						},
 						Validators: []validator.List{
 							listvalidator.SizeAtMost(2),
 							listvalidator.AlsoRequires(
@@ -250,7 +292,7 @@ func (r *corporateIdPResource) Schema(_ context.Context, _ resource.SchemaReques
 							),
 						},
 						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
+							Attributes: certificateDetailsResourceSchema(map[string]schema.Attribute{
 								"base64_certificate": schema.StringAttribute{
 									MarkdownDescription: "The content of the Base64 certificate. The certificate must be in PEM format.",
 									Optional:            true,
@@ -288,12 +330,16 @@ func (r *corporateIdPResource) Schema(_ context.Context, _ resource.SchemaReques
 										utils.ValidDateTime(),
 									},
 								},
-							},
+							}),
 						},
 					},
 					"sso_endpoints": schema.ListNestedAttribute{
 						MarkdownDescription: "Configure the URLs of the identity provider single sign-on endpoint that receive authentication requests.",
 						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.List{
+							idpSamlMetadataValue(),
+						},
 						Validators: []validator.List{
 							listvalidator.AlsoRequires(
 								path.MatchRoot("saml2_config").AtName("sso_endpoints").AtAnyListIndex().AtName("binding_name"),
@@ -330,6 +376,10 @@ func (r *corporateIdPResource) Schema(_ context.Context, _ resource.SchemaReques
 					"slo_endpoints": schema.ListNestedAttribute{
 						MarkdownDescription: "Configure the URLs of the identity provider single logout endpoint that receive logout messages.",
 						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.List{
+							idpSamlMetadataValue(),
+						},
 						Validators: []validator.List{
 							listvalidator.AlsoRequires(
 								path.MatchRoot("saml2_config").AtName("slo_endpoints").AtAnyListIndex().AtName("binding_name"),
@@ -597,6 +647,10 @@ func (r *corporateIdPResource) Schema(_ context.Context, _ resource.SchemaReques
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"resolve_discovery": schema.BoolAttribute{
+						MarkdownDescription: "Resolve the OpenID configuration of the `discovery_url` and its JSON Web Key Set at plan time, so that `issuer`, `jwks_uri`, `jwks` and the endpoints are known in the plan. The plan fails if the configured `scopes` or `token_endpoint_auth_method` are not supported by the IdP. This value is not sent to the API.",
+						Optional:            true,
+					},
 				},
 			},
 		},
@@ -635,6 +689,10 @@ func (r *corporateIdPResource) Create(ctx context.Context, req resource.CreateRe
 		// The client secret must be read from the plan as the GET call on the IdP does not return the configured secret
 		diags = mapOidcClientSecret(ctx, plan, &state)
 		resp.Diagnostics.Append(diags...)
+
+		// resolve_discovery is not returned by the API, and the tenant may store the JSON Web Key Set in another format
+		diags = mapOidcDiscovery(ctx, plan, &state)
+		resp.Diagnostics.Append(diags...)
 	}
 
 	if !plan.Saml2Config.IsNull() && !plan.Saml2Config.IsUnknown() {
@@ -642,6 +700,9 @@ func (r *corporateIdPResource) Create(ctx context.Context, req resource.CreateRe
 		// inconsistent-sensitive-attribute errors when base64_certificate derives from a sensitive variable
 		diags = mapSigningCertificates(ctx, plan, &state)
 		resp.Diagnostics.Append(diags...)
+
+		diags = mapSamlMetadata(ctx, plan, &state)
+		resp.Diagnostics.Append(diags...)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -696,11 +757,18 @@ func (r *corporateIdPResource) Update(ctx context.Context, req resource.UpdateRe
 		// The client secret must be read from the plan as the GET call on the IdP does not return the configured secret
 		diags = mapOidcClientSecret(ctx, plan, &newState)
 		resp.Diagnostics.Append(diags...)
+
+		// resolve_discovery is not returned by the API, and the tenant may store the JSON Web Key Set in another format
+		diags = mapOidcDiscovery(ctx, plan, &newState)
+		resp.Diagnostics.Append(diags...)
 	}
 
 	if !plan.Saml2Config.IsNull() && !plan.Saml2Config.IsUnknown() {
 		diags = mapSigningCertificates(ctx, plan, &newState)
 		resp.Diagnostics.Append(diags...)
+
+		diags = mapSamlMetadata(ctx, plan, &newState)
+		resp.Diagnostics.Append(diags...)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -737,11 +805,21 @@ func (r *corporateIdPResource) Read(ctx context.Context, req resource.ReadReques
 		// The client secret must be read from the plan as the GET call on the IdP does not return the configured secret
 		diags = mapOidcClientSecret(ctx, config, &state)
 		resp.Diagnostics.Append(diags...)
+
+		// resolve_discovery is not returned by the API, and the tenant may store the JSON Web Key Set in another format
+		diags = mapOidcDiscovery(ctx, config, &state)
+		resp.Diagnostics.Append(diags...)
 	}
 
 	if !config.Saml2Config.IsNull() && !config.Saml2Config.IsUnknown() {
 		diags = mapSigningCertificates(ctx, config, &state)
 		resp.Diagnostics.Append(diags...)
+
+		diags = mapSamlMetadata(ctx, config, &state)
+		resp.Diagnostics.Append(diags...)
+
+		diags = checkSamlMetadataDrift(ctx, state)
+		resp.Diagnostics.Append(diags...)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -769,6 +847,68 @@ func (r *corporateIdPResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
+func (r *corporateIdPResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// the certificates are only checked and the discovery is only resolved when the IdP is created or updated, and requires a configured provider
+	if req.Plan.Raw.IsNull() || r.cli == nil {
+		return
+	}
+
+	now := time.Now()
+
+	diags := certificateListExpiryWarnings(ctx, req.Plan, path.Root("saml2_config").AtName("signing_certificates"), r.cli.CertificateExpiryWarningDays, now)
+	resp.Diagnostics.Append(diags...)
+
+	diags = checkIdPSamlMetadata(ctx, req, path.Root("saml2_config"), now)
+	resp.Diagnostics.Append(diags...)
+
+	var plan corporateIdPData
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.OidcConfig.IsNull() || plan.OidcConfig.IsUnknown() {
+		return
+	}
+
+	var oidcPlan oidcConfigData
+	diags = plan.OidcConfig.As(ctx, &oidcPlan, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !oidcPlan.ResolveDiscovery.ValueBool() || oidcPlan.DiscoveryUrl.IsNull() || oidcPlan.DiscoveryUrl.IsUnknown() {
+		return
+	}
+
+	discovery, err := r.cli.CorporateIdP.ResolveOidcDiscovery(ctx, oidcPlan.DiscoveryUrl.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("oidc_config").AtName("discovery_url"), "Error resolving OIDC discovery", fmt.Sprintf("%s", err))
+		return
+	}
+
+	oidcPlan, diags = oidcConfigFromDiscovery(ctx, discovery, oidcPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.OidcConfig, diags = types.ObjectValueFrom(ctx, oidcConfigObjType.AttrTypes, oidcPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, path.Root("oidc_config"), plan.OidcConfig)
+	resp.Diagnostics.Append(diags...)
+}
+
 func (r *corporateIdPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
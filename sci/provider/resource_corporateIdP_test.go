@@ -1,13 +1,23 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
 	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestResourceCorporateIdP(t *testing.T) {
@@ -210,6 +220,64 @@ func TestResourceCorporateIdP(t *testing.T) {
 		})
 	})
 
+	t.Run("happy path - oidc corporate idp resolved from discovery", func(t *testing.T) {
+
+		requireCassette(t, "fixtures/resource_corporateIdP_oidc_discovery")
+
+		rec, user := setupVCR(t, "fixtures/resource_corporateIdP_oidc_discovery")
+		defer stopQuietly(rec)
+
+		oidcConfig := func(scopes string) string {
+			return fmt.Sprintf(`
+				discovery_url = "%s"
+				client_id = "%s"
+				client_secret = "%s"
+				token_endpoint_auth_method = "clientSecretBasic"
+				scopes = [%s]
+				resolve_discovery = true
+			`, oidcCoporateIdP.DiscoveryUrl, oidcCoporateIdP.ClientId, oidcCoporateIdP.ClientSecret, scopes)
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + ResourceOidcCorporateIdP("testIdP", "OIDC Discovery - Test Corporate IdP", "OIDC Discovery - Test IdP", "openIdConnect", oidcConfig(`"openid"`)),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestMatchResourceAttr("sci_corporate_idp.testIdP", "id", regexpUUID),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.resolve_discovery", "true"),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.scopes.#", "1"),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.issuer", oidcCoporateIdP.DiscoveryUrl),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.jwks_uri", oidcCoporateIdP.DiscoveryUrl+"/oauth2/certs"),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.token_endpoint", oidcCoporateIdP.DiscoveryUrl+"/oauth2/token"),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.authorization_endpoint", oidcCoporateIdP.DiscoveryUrl+"/oauth2/authorize"),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.user_info_endpoint", oidcCoporateIdP.DiscoveryUrl+"/oauth2/userinfo"),
+						resource.TestCheckResourceAttrSet("sci_corporate_idp.testIdP", "oidc_config.jwks"),
+					),
+				},
+				{
+					ResourceName:      "sci_corporate_idp.testIdP",
+					ImportState:       true,
+					ImportStateVerify: true,
+					ImportStateVerifyIgnore: []string{
+						"oidc_config.client_secret",     // The client secret is not returned by the GET call, so it cannot be verified
+						"oidc_config.resolve_discovery", // resolve_discovery is not returned by the API
+					},
+				},
+				{
+					Config: providerConfig("", user) + ResourceOidcCorporateIdP("testIdP", "OIDC Discovery - Updated IdP", "OIDC Discovery - Test IdP", "openIdConnect", oidcConfig(`"openid", "email"`)),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "display_name", "OIDC Discovery - Updated IdP"),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.scopes.#", "2"),
+						resource.TestCheckResourceAttr("sci_corporate_idp.testIdP", "oidc_config.issuer", oidcCoporateIdP.DiscoveryUrl),
+						resource.TestCheckResourceAttrSet("sci_corporate_idp.testIdP", "oidc_config.jwks"),
+					),
+				},
+			},
+		})
+	})
+
 	t.Run("update - saml2 corporate idp - all fields", func(t *testing.T) {
 
 		saml2Initial := corporateIdP
@@ -818,6 +886,330 @@ func TestResourceCorporateIdP(t *testing.T) {
 		})
 	})
 
+	t.Run("happy path - saml2_config derived from metadata", func(t *testing.T) {
+
+		ctx := context.Background()
+
+		metadata, err := utils.ParseSamlIdpMetadata(samlIdpMetadataXml(t))
+		assert.NoError(t, err)
+
+		derived, diags := saml2IdPConfigFromMetadata(ctx, metadata)
+		assert.False(t, diags.HasError())
+
+		// the SOAP endpoint is supported, the PAOS endpoint is ignored
+		var ssoEndpoints []corporateidps.SAML2SSOEndpoint
+		derived.SsoEndpoints.ElementsAs(ctx, &ssoEndpoints, false)
+		assert.Equal(t, []corporateidps.SAML2SSOEndpoint{
+			{
+				BindingName: "urn:oasis:names:tc:SAML:2.0:bindings:SOAP",
+				Location:    "https://idp.example.com/saml/soap",
+			},
+			{
+				BindingName: "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect",
+				Location:    "https://idp.example.com/saml/sso",
+				IsDefault:   true,
+			},
+		}, ssoEndpoints)
+
+		var sloEndpoints []sloEndpointData
+		derived.SloEndpoints.ElementsAs(ctx, &sloEndpoints, false)
+		assert.Equal(t, []sloEndpointData{
+			{
+				BindingName:      types.StringValue("urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"),
+				Location:         types.StringValue("https://idp.example.com/saml/slo"),
+				ResponseLocation: types.StringNull(),
+				Default:          types.BoolValue(true),
+			},
+		}, sloEndpoints)
+
+		var signingCertificates []corporateidps.SigningCertificateData
+		derived.SigningCertificates.ElementsAs(ctx, &signingCertificates, false)
+		assert.Len(t, signingCertificates, 1)
+		assert.Equal(t, "CN=sp.example.com", signingCertificates[0].Dn)
+		assert.True(t, signingCertificates[0].IsDefault)
+	})
+
+	t.Run("happy path - saml2_config drift from metadata", func(t *testing.T) {
+
+		ctx := context.Background()
+		metadataXml, signingCertificate := signSamlMetadataXml(t, samlIdpMetadataXml(t))
+
+		metadata, err := utils.ParseSamlIdpMetadata(metadataXml)
+		assert.NoError(t, err)
+
+		derived, diags := saml2IdPConfigFromMetadata(ctx, metadata)
+		assert.False(t, diags.HasError())
+
+		tenantConfig, diags := corporateIdPValueFrom(ctx, corporateidps.IdentityProvider{
+			Type:                   "saml2",
+			IdentityFederation:     &corporateidps.IdentityFederation{},
+			LoginHintConfiguration: &corporateidps.LoginHintConfiguration{},
+			Saml2Configuration: &corporateidps.SAML2Configuration{
+				CertificatesForSigning: metadata.CertificatesForSigning,
+				SsoEndpoints:           metadata.SsoEndpoints[:2],
+				SloEndpoints:           metadata.SloEndpoints,
+			},
+		})
+		assert.False(t, diags.HasError())
+
+		plan := tenantConfig
+		plan.Saml2Config, diags = types.ObjectValueFrom(ctx, IdPSaml2ConfigObjType.AttrTypes, saml2ConfigData{
+			SamlMetadataUrl:     types.StringNull(),
+			AssertionAttributes: types.ListNull(saml2AssertionAttributeObjType),
+			SigningCertificates: derived.SigningCertificates,
+			SsoEndpoints:        derived.SsoEndpoints,
+			SloEndpoints:        derived.SloEndpoints,
+			MetadataXml:         types.StringValue(metadataXml),
+			MetadataFile:        types.StringNull(),
+			MetadataSigningCert: types.StringValue(signingCertificate),
+		})
+		assert.False(t, diags.HasError())
+
+		// the metadata and its signing certificate are kept in the state, the endpoints of the tenant match the metadata
+		state := tenantConfig
+		diags = mapSamlMetadata(ctx, plan, &state)
+		assert.False(t, diags.HasError())
+		assert.Empty(t, checkSamlMetadataDrift(ctx, state))
+
+		var samlState saml2ConfigData
+		state.Saml2Config.As(ctx, &samlState, basetypes.ObjectAsOptions{})
+		assert.Equal(t, signingCertificate, samlState.MetadataSigningCert.ValueString())
+
+		// the metadata and its signing certificate are not sent to the tenant
+		args, diags := (&corporateIdPResource{}).getCorporateIdPRequest(ctx, plan)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, derived.SsoEndpoints.Elements()[0].(types.Object).Attributes()["location"], types.StringValue(args.Saml2Configuration.SsoEndpoints[0].Location))
+
+		// an endpoint has been removed in the tenant
+		tenantConfig, _ = corporateIdPValueFrom(ctx, corporateidps.IdentityProvider{
+			Type:                   "saml2",
+			IdentityFederation:     &corporateidps.IdentityFederation{},
+			LoginHintConfiguration: &corporateidps.LoginHintConfiguration{},
+			Saml2Configuration: &corporateidps.SAML2Configuration{
+				CertificatesForSigning: metadata.CertificatesForSigning,
+				SsoEndpoints:           metadata.SsoEndpoints[1:2],
+				SloEndpoints:           metadata.SloEndpoints,
+			},
+		})
+		state = tenantConfig
+		diags = mapSamlMetadata(ctx, plan, &state)
+		assert.False(t, diags.HasError())
+
+		diags = checkSamlMetadataDrift(ctx, state)
+		assert.Len(t, diags, 1)
+		assert.Equal(t, "SAML Metadata Drift", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "for: sso_endpoints.")
+	})
+
+	t.Run("validation - planned saml2_config.metadata_xml", func(t *testing.T) {
+
+		saml2Path := path.Root("saml2_config")
+		metadataPath := saml2Path.AtName("metadata_xml")
+		certificatePath := saml2Path.AtName("metadata_signing_certificate")
+		now := time.Now()
+
+		signedXml, signingCertificate := signSamlMetadataXml(t, samlIdpMetadataXml(t))
+
+		diags := checkPlannedIdPSamlMetadata(signedXml, types.StringValue(signingCertificate), metadataPath, certificatePath, now)
+		assert.Empty(t, diags)
+
+		diags = checkPlannedIdPSamlMetadata(samlIdpMetadataXml(t), types.StringNull(), metadataPath, certificatePath, now)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "SAML Metadata Not Signed", diags[0].Summary())
+
+		// an endpoint modified after signing is rejected, it is neither planned nor compared with the tenant
+		tamperedXml := strings.Replace(signedXml, "https://idp.example.com/saml/sso", "https://attacker.example.com/saml/sso", 1)
+
+		diags = checkPlannedIdPSamlMetadata(tamperedXml, types.StringValue(signingCertificate), metadataPath, certificatePath, now)
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Invalid SAML Metadata Signature", diags[0].Summary())
+
+		_, err := idpSamlMetadataFrom(tamperedXml, types.StringValue(signingCertificate))
+		assert.Error(t, err)
+
+		metadata, err := idpSamlMetadataFrom(signedXml, types.StringValue(signingCertificate))
+		assert.NoError(t, err)
+		assert.Equal(t, "https://idp.example.com/saml/sso", metadata.SsoEndpoints[1].Location)
+	})
+
+	t.Run("error path - saml2_config.metadata_xml conflicts with the derived attributes", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceSaml2CorporateIdPWithMetadata("testIdP", "Test SAML2 IDP", fmt.Sprintf("metadata_xml = <<-EOT\n%s\nEOT", samlIdpMetadataXml(t)), `sso_endpoints = [{ binding_name = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST", location = "https://test.com" }]`),
+					ExpectError: regexp.MustCompile("Attribute \"saml2_config.sso_endpoints\" cannot be specified when\n\"saml2_config.metadata_xml\" is specified"),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - saml2_config.metadata_file must be readable", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceSaml2CorporateIdPWithMetadata("testIdP", "Test SAML2 IDP", `metadata_file = "does-not-exist.xml"`, ""),
+					ExpectError: regexp.MustCompile("the metadata file cannot be read"),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - saml2_config.metadata_xml must be identity provider metadata", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceSaml2CorporateIdPWithMetadata("testIdP", "Test SAML2 IDP", fmt.Sprintf("metadata_xml = <<-EOT\n%s\nEOT", samlMetadataXml(t)), ""),
+					ExpectError: regexp.MustCompile("the metadata does not contain an IDPSSODescriptor"),
+				},
+			},
+		})
+	})
+
+	t.Run("happy path - oidc_config resolved from discovery", func(t *testing.T) {
+
+		ctx := context.Background()
+
+		discoveryServer := oidcDiscoveryStandIn(t)
+		defer discoveryServer.Close()
+
+		idpCli := cli.NewCorporateIdPCli(nil)
+		discovery, err := idpCli.ResolveOidcDiscovery(ctx, discoveryServer.URL)
+		assert.NoError(t, err)
+
+		scopes, _ := types.SetValueFrom(ctx, types.StringType, []string{"openid", "email"})
+		resolved, diags := oidcConfigFromDiscovery(ctx, discovery, oidcConfigData{
+			DiscoveryUrl:            types.StringValue(discoveryServer.URL),
+			TokenEndpointAuthMethod: types.StringValue("clientSecretBasic"),
+			Scopes:                  scopes,
+			AdditionalConfig:        types.ObjectNull(OidcCAdditionalConfigObjType.AttrTypes),
+			ResolveDiscovery:        types.BoolValue(true),
+			Issuer:                  types.StringUnknown(),
+			LogoutEndpoint:          types.StringUnknown(),
+		})
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, types.StringValue(discoveryServer.URL), resolved.Issuer)
+		assert.Equal(t, types.StringValue(discoveryServer.URL+"/authorize"), resolved.AuthorizationEndpoint)
+		assert.Equal(t, types.StringValue(discoveryServer.URL+"/token"), resolved.TokenEndpoint)
+		assert.Equal(t, types.StringValue(discoveryServer.URL+"/userinfo"), resolved.UserInfoEndpoint)
+		assert.Equal(t, types.StringNull(), resolved.LogoutEndpoint)
+		assert.Equal(t, types.StringValue(discoveryServer.URL+"/jwks"), resolved.JwksUri)
+		assert.Equal(t, types.StringValue(`{"keys":[{"kty":"EC","kid":"key-1"}]}`), resolved.Jwks)
+
+		// the tenant returns the keys in another format, the resolved keys are kept in the state
+		plan := corporateIdPData{}
+		plan.OidcConfig, diags = types.ObjectValueFrom(ctx, oidcConfigObjType.AttrTypes, resolved)
+		assert.False(t, diags.HasError())
+
+		tenant := resolved
+		tenant.ResolveDiscovery = types.BoolNull()
+		tenant.Jwks = types.StringValue(`{"keys": [{"kid": "key-1", "kty": "EC"}]}`)
+		state := corporateIdPData{}
+		state.OidcConfig, diags = types.ObjectValueFrom(ctx, oidcConfigObjType.AttrTypes, tenant)
+		assert.False(t, diags.HasError())
+
+		diags = mapOidcDiscovery(ctx, plan, &state)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, plan.OidcConfig, state.OidcConfig)
+	})
+
+	t.Run("error path - oidc_config.resolve_discovery rejects unsupported scopes and auth methods", func(t *testing.T) {
+
+		ctx := context.Background()
+
+		discoveryServer := oidcDiscoveryStandIn(t)
+		defer discoveryServer.Close()
+
+		idpCli := cli.NewCorporateIdPCli(nil)
+		discovery, err := idpCli.ResolveOidcDiscovery(ctx, discoveryServer.URL+"/.well-known/openid-configuration")
+		assert.NoError(t, err)
+
+		scopes, _ := types.SetValueFrom(ctx, types.StringType, []string{"openid", "offline_access"})
+		_, diags := oidcConfigFromDiscovery(ctx, discovery, oidcConfigData{
+			TokenEndpointAuthMethod: types.StringValue("privateKeyJwt"),
+			Scopes:                  scopes,
+		})
+
+		assert.Equal(t, 2, diags.ErrorsCount())
+		assert.Contains(t, diags.Errors()[0].Detail(), "the scope offline_access is not supported by the IdP, supported scopes : openid, email, profile")
+		assert.Contains(t, diags.Errors()[1].Detail(), "the token endpoint auth method privateKeyJwt is not supported by the IdP, supported methods : client_secret_basic, client_secret_post")
+	})
+
+	t.Run("happy path - saml2_config.signing_certificates details read from the certificate", func(t *testing.T) {
+
+		ctx := context.Background()
+
+		certificate := testCertificate(t)
+		parsed, err := utils.ParseCertificate(certificate)
+		assert.NoError(t, err)
+
+		saml2IdP := corporateIdP
+		saml2IdP.Type = "saml2"
+		saml2IdP.Saml2Configuration = &corporateidps.SAML2Configuration{
+			CertificatesForSigning: []corporateidps.SigningCertificateData{
+				{
+					Base64Certificate: certificate,
+					IsDefault:         true,
+				},
+				saml2Config.CertificatesForSigning[0],
+			},
+		}
+
+		idp, diags := corporateIdPValueFrom(ctx, saml2IdP)
+		assert.False(t, diags.HasError())
+
+		var saml2Config saml2ConfigData
+		diags = idp.Saml2Config.As(ctx, &saml2Config, basetypes.ObjectAsOptions{})
+		assert.False(t, diags.HasError())
+
+		var certificates []signingCertificateData
+		diags = saml2Config.SigningCertificates.ElementsAs(ctx, &certificates, false)
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, types.StringValue("CN=sp.example.com"), certificates[0].SubjectDn)
+		assert.Regexp(t, regexp.MustCompile(`^([0-9A-F]{2}:){19}[0-9A-F]{2}$`), certificates[0].Sha1Fingerprint.ValueString())
+		assert.Regexp(t, regexp.MustCompile(`^([0-9A-F]{2}:){31}[0-9A-F]{2}$`), certificates[0].Sha256Fingerprint.ValueString())
+		assert.Equal(t, types.StringValue(parsed.NotBefore.UTC().Format(time.RFC3339)), certificates[0].NotBefore)
+		assert.Equal(t, types.StringValue(parsed.NotAfter.UTC().Format(time.RFC3339)), certificates[0].NotAfter)
+
+		// the details of a certificate which cannot be parsed are unknown to the provider
+		assert.True(t, certificates[1].SubjectDn.IsNull())
+		assert.True(t, certificates[1].NotAfter.IsNull())
+	})
+
+	t.Run("happy path - saml2_config.signing_certificates expiry warnings", func(t *testing.T) {
+
+		certificate := testCertificate(t)
+		certificatePath := path.Root("saml2_config").AtName("signing_certificates").AtListIndex(0)
+
+		// the certificate is valid for a day
+		diags := certificateExpiryWarning(certificatePath, certificate, 30, time.Now())
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "Certificate Expires Soon", diags.Warnings()[0].Summary())
+		assert.Contains(t, diags.Warnings()[0].Detail(), "The certificate CN=sp.example.com expires at")
+
+		diags = certificateExpiryWarning(certificatePath, certificate, 30, time.Now().AddDate(0, 0, 2))
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "Certificate Expired", diags.Warnings()[0].Summary())
+
+		diags = certificateExpiryWarning(certificatePath, certificate, 30, time.Now().AddDate(0, 0, -60))
+		assert.Empty(t, diags)
+
+		// the warnings are disabled
+		diags = certificateExpiryWarning(certificatePath, certificate, 0, time.Now())
+		assert.Empty(t, diags)
+
+		// the certificate cannot be parsed
+		diags = certificateExpiryWarning(certificatePath, "-----BEGIN CERTIFICATE-----\nredacted\n-----END CERTIFICATE-----", 30, time.Now())
+		assert.Empty(t, diags)
+	})
+
 	t.Run("error path - oidc_config requires root attributes name & type", func(t *testing.T) {
 		resource.Test(t, resource.TestCase{
 			IsUnitTest:               true,
@@ -1136,6 +1528,35 @@ func ResourceSaml2CorporateIdPWithSsoEndpoints(resourceName string, idpName stri
 	`, resourceName, idpName, idpName, ssoEndpoints)
 }
 
+// samlIdpMetadataXml returns the unsigned metadata of an identity provider with a certificate valid for a day
+func samlIdpMetadataXml(t *testing.T) string {
+	return fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/metadata">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/saml/slo"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:SOAP" Location="https://idp.example.com/saml/soap"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/saml/sso"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:PAOS" Location="https://idp.example.com/saml/ecp"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, testCertificate(t))
+}
+
+func ResourceSaml2CorporateIdPWithMetadata(resourceName string, idpName string, metadata string, saml2Config string) string {
+	return fmt.Sprintf(`
+	resource "sci_corporate_idp" "%s" {
+		display_name = "%s"
+		name = "%s"
+		type = "saml2"
+		saml2_config = {
+			%s
+			%s
+		}
+	}
+	`, resourceName, idpName, idpName, metadata, saml2Config)
+}
+
 func ResourceSaml2CorporateIdPWithSloEndpoints(resourceName string, idpName string, sloEndpoints string) string {
 	return fmt.Sprintf(`
 	resource "sci_corporate_idp" "%s" {
@@ -1166,6 +1587,33 @@ func ResourceOidcCorporateIdP(resourceName string, idpDisplayName string, idpNam
 	`, resourceName, idpDisplayName, idpType, idpName, oidcConfig)
 }
 
+// oidcDiscoveryStandIn serves the OpenID configuration and the JSON Web Key Set of an IdP
+func oidcDiscoveryStandIn(t *testing.T) *httptest.Server {
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_, _ = fmt.Fprintf(w, `{
+				"issuer": "%[1]s",
+				"authorization_endpoint": "%[1]s/authorize",
+				"token_endpoint": "%[1]s/token",
+				"userinfo_endpoint": "%[1]s/userinfo",
+				"jwks_uri": "%[1]s/jwks",
+				"scopes_supported": ["openid", "email", "profile"],
+				"token_endpoint_auth_methods_supported": ["client_secret_basic", "client_secret_post"]
+			}`, srv.URL)
+		case "/jwks":
+			_, _ = fmt.Fprint(w, `{ "keys": [ { "kty": "EC", "kid": "key-1" } ] }`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return srv
+}
+
 // ResourceCorporateIdPSaml2WithoutOptionals renders a SAML2 IdP config that omits
 // all list-based optional fields (assertion_attributes, sso_endpoints, slo_endpoints,
 // required_groups) so the update step can verify removal/empty-replace operations.
@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
@@ -37,6 +39,8 @@ type groupResource struct {
 	cli *cli.SciClient
 }
 
+var _ resource.ResourceWithModifyPlan = &groupResource{}
+
 func (d *groupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -51,7 +55,9 @@ func (r *groupResource) Metadata(_ context.Context, req resource.MetadataRequest
 
 func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: `Creates a group in the SAP Cloud Identity Services.`,
+		MarkdownDescription: `Creates a group in the SAP Cloud Identity Services.
+
+If the tenant supports ETags, the group is only updated or deleted if it has not been modified outside of Terraform since it was last read.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -112,6 +118,16 @@ func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			"custom_schemas": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Further enhance your group with custom schemas. The attribute must be configured as a valid JSON string, with the custom schema IDs as keys. The custom schema IDs must also be configured in `schemas`.\n" +
+					"For custom schema attributes of type `complex`, overwriting specific attributes of the object to null is not supported, the entire complex attribute must be set to null instead.",
+				Validators: []validator.String{
+					utils.ValidJSON(),
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"meta": metaResourceSchema("group"),
 			"group_extension": schema.SingleNestedAttribute{
 				MarkdownDescription: "Configure attributes particular to the schema `" + defaultGroupSchemas[1].String() + "`.",
 				Optional:            true,
@@ -148,19 +164,20 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	args, diags := r.GetGroupRequest(ctx, plan)
+	args, customSchemas, diags := r.GetGroupRequest(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	res, _, err := r.cli.Group.Create(ctx, args)
-	if err != nil {
+	res, _, err := r.cli.Group.Create(ctx, customSchemas, args)
+	var partialErr cli.GroupPartiallyUpdatedError
+	if err != nil && !errors.As(err, &partialErr) {
 		resp.Diagnostics.AddError("Error creating group", fmt.Sprintf("%s", err))
 		return
 	}
 
-	state, diags := groupValueFrom(ctx, res)
+	state, diags := groupValueFrom(ctx, res, customSchemas)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -168,6 +185,18 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
+
+	// the group has been created, but not all of its members have been added
+	// the state holds the group as read after the failure, so that the created group is not lost
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating group", fmt.Sprintf("%s", err))
+	}
 }
 
 func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -179,13 +208,13 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	res, _, err := r.cli.Group.GetByGroupId(ctx, config.Id.ValueString())
+	res, customSchemasRes, err := r.cli.Group.GetByGroupId(ctx, config.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving group", fmt.Sprintf("%s", err))
 		return
 	}
 
-	state, diags := groupValueFrom(ctx, res)
+	state, diags := groupValueFrom(ctx, res, customSchemasRes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -193,6 +222,12 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -221,13 +256,20 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	res, _, err := r.cli.Group.Update(ctx, args, state.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error updating Group", fmt.Sprintf("%s", err))
+	version, diags := getPrivateVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	updatedState, diags := groupValueFrom(ctx, res)
+	res, customSchemasRes, err := r.cli.Group.Update(ctx, args, state.Id.ValueString(), plan.CustomSchemas.ValueString(), version)
+	var partialErr cli.GroupPartiallyUpdatedError
+	if err != nil && !errors.As(err, &partialErr) {
+		resp.Diagnostics.Append(versionErrorDiagnostics("Error updating group", "group", err)...)
+		return
+	}
+
+	updatedState, diags := groupValueFrom(ctx, res, customSchemasRes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -235,6 +277,17 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	diags = resp.State.Set(ctx, &updatedState)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
+
+	// the state holds the group as read after the failure, so that the changes that have not been applied are planned again
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating group", fmt.Sprintf("%s", err))
+	}
 }
 
 func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -251,11 +304,78 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.cli.Group.Delete(ctx, config.Id.ValueString())
+	version, diags := getPrivateVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.cli.Group.Delete(ctx, config.Id.ValueString(), version)
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting group", fmt.Sprintf("%s", err))
+		resp.Diagnostics.Append(versionErrorDiagnostics("Error deleting group", "group", err)...)
+		return
+	}
+}
+
+func (r *groupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// the check is only carried out when the group is updated, as no group can contain a group which is created
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state groupData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.GroupMembers.IsNull() || plan.GroupMembers.IsUnknown() {
+		return
+	}
+
+	var planMembers, stateMembers []memberData
+	diags = plan.GroupMembers.ElementsAs(ctx, &planMembers, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.GroupMembers.IsNull() {
+		diags = state.GroupMembers.ElementsAs(ctx, &stateMembers, true)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	assigned := map[string]bool{}
+	for _, member := range stateMembers {
+		assigned[member.Value.ValueString()] = true
+	}
+
+	// only the members which are added can create a cycle
+	added := []groups.GroupMember{}
+	for _, member := range planMembers {
+		if member.Value.IsUnknown() || assigned[member.Value.ValueString()] {
+			continue
+		}
+
+		groupMember := groups.GroupMember{Value: member.Value.ValueString()}
+		if !member.Type.IsNull() && !member.Type.IsUnknown() {
+			groupMember.Type = member.Type.ValueString()
+		}
+		added = append(added, groupMember)
+	}
+
+	resp.Diagnostics.Append(validateGroupCycles(ctx, r.cli, state.Id.ValueString(), added, path.Root("group_members"))...)
 }
 
 func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
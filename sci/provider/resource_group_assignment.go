@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
-	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
 
@@ -33,6 +32,8 @@ type groupAssignmentResource struct {
 	cli *cli.SciClient
 }
 
+var _ resource.ResourceWithModifyPlan = &groupAssignmentResource{}
+
 func (r *groupAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -125,9 +126,7 @@ func (r *groupAssignmentResource) Create(ctx context.Context, req resource.Creat
 		groupMember.Type = member.Type.ValueString()
 	}
 
-	patchOp := utils.GenerateAddPatchRequest("members", []groups.GroupMember{groupMember})
-
-	res, _, err := r.cli.Group.Update(ctx, []generic.PatchRequest{patchOp}, plan.GroupId.ValueString())
+	res, _, err := r.cli.Group.AddMembers(ctx, plan.GroupId.ValueString(), []groups.GroupMember{groupMember})
 	if err != nil {
 		resp.Diagnostics.AddError("Error adding group member", fmt.Sprintf("%s", err))
 		return
@@ -209,17 +208,60 @@ func (r *groupAssignmentResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	removeOp := utils.GenerateDeletePatchRequest(
-		fmt.Sprintf(`members[value eq "%s"]`, member.Value.ValueString()),
-	)
-
-	_, _, err := r.cli.Group.Update(ctx, []generic.PatchRequest{removeOp}, config.GroupId.ValueString())
+	_, _, err := r.cli.Group.RemoveMembers(ctx, config.GroupId.ValueString(), []string{member.Value.ValueString()})
 	if err != nil {
 		resp.Diagnostics.AddError("Error removing group member", fmt.Sprintf("%s", err))
 		return
 	}
 }
 
+func (r *groupAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// the check is carried out when the assignment is created or re-created with a different group or member
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan groupAssignmentData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+
+		var state groupAssignmentData
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plan.GroupId.Equal(state.GroupId) && plan.GroupMember.Equal(state.GroupMember) {
+			return
+		}
+	}
+
+	if plan.GroupId.IsUnknown() || plan.GroupMember.IsUnknown() {
+		return
+	}
+
+	var member memberData
+	diags = plan.GroupMember.As(ctx, &member, memberAsOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || member.Value.IsUnknown() {
+		return
+	}
+
+	groupMember := groups.GroupMember{Value: member.Value.ValueString()}
+	if !member.Type.IsNull() && !member.Type.IsUnknown() {
+		groupMember.Type = member.Type.ValueString()
+	}
+
+	resp.Diagnostics.Append(validateGroupCycles(ctx, r.cli, plan.GroupId.ValueString(), []groups.GroupMember{groupMember}, path.Root("group_member"))...)
+}
+
 func (r *groupAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	idParts := strings.Split(req.ID, ",")
 
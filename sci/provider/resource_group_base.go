@@ -8,6 +8,7 @@ import (
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -51,6 +52,9 @@ There are 2 ways to manage members assigned to a group:
 
 If both the monolithic resource and the individual base/assignment resources are used against the same Group, spurious changes and conflicting state updates will occur.
 
+### Concurrent Modifications
+If the tenant supports ETags, the group is only updated or deleted if it has not been modified outside of Terraform since it was last read. Member assignments are not considered a modification, as they are not managed by this resource.
+
 		`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -81,6 +85,16 @@ If both the monolithic resource and the individual base/assignment resources are
 				MarkdownDescription: "Display Name of the group.",
 				Required:            true,
 			},
+			"custom_schemas": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Further enhance your group with custom schemas. The attribute must be configured as a valid JSON string, with the custom schema IDs as keys. The custom schema IDs must also be configured in `schemas`.\n" +
+					"For custom schema attributes of type `complex`, overwriting specific attributes of the object to null is not supported, the entire complex attribute must be set to null instead.",
+				Validators: []validator.String{
+					utils.ValidJSON(),
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"meta": metaResourceSchema("group"),
 			"group_extension": schema.SingleNestedAttribute{
 				MarkdownDescription: "Configure attributes particular to the schema `" + defaultGroupSchemas[1].String() + "`.",
 				Optional:            true,
@@ -117,19 +131,19 @@ func (r *groupBaseResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	args, diags := getGroupBaseRequest(ctx, plan)
+	args, customSchemas, diags := getGroupBaseRequest(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	res, _, err := r.cli.Group.Create(ctx, args)
+	res, _, err := r.cli.Group.Create(ctx, customSchemas, args)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating group", fmt.Sprintf("%s", err))
 		return
 	}
 
-	state, diags := groupBaseValueFrom(ctx, res)
+	state, diags := groupBaseValueFrom(ctx, res, customSchemas)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -137,6 +151,12 @@ func (r *groupBaseResource) Create(ctx context.Context, req resource.CreateReque
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *groupBaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -148,13 +168,13 @@ func (r *groupBaseResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	res, _, err := r.cli.Group.GetByGroupId(ctx, config.Id.ValueString())
+	res, customSchemasRes, err := r.cli.Group.GetByGroupId(ctx, config.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving group", fmt.Sprintf("%s", err))
 		return
 	}
 
-	state, diags := groupBaseValueFrom(ctx, res)
+	state, diags := groupBaseValueFrom(ctx, res, customSchemasRes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -162,6 +182,12 @@ func (r *groupBaseResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *groupBaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -191,13 +217,31 @@ func (r *groupBaseResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	res, _, err := r.cli.Group.Update(ctx, args, state.Id.ValueString())
+	version, diags := getPrivateVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, customSchemasRes, err := r.cli.Group.Update(ctx, args, state.Id.ValueString(), plan.CustomSchemas.ValueString(), version)
+	if cli.IsPreconditionFailed(err) {
+		// the update is retried with the current version, if the group has only been modified by member assignments
+		currentVersion, unmodified, diags := unmodifiedGroupBaseVersion(ctx, r.cli, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if unmodified {
+			res, customSchemasRes, err = r.cli.Group.Update(ctx, args, state.Id.ValueString(), plan.CustomSchemas.ValueString(), currentVersion)
+		}
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating group", fmt.Sprintf("%s", err))
+		resp.Diagnostics.Append(versionErrorDiagnostics("Error updating group", "group", err)...)
 		return
 	}
 
-	updatedState, diags := groupBaseValueFrom(ctx, res)
+	updatedState, diags := groupBaseValueFrom(ctx, res, customSchemasRes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -205,6 +249,12 @@ func (r *groupBaseResource) Update(ctx context.Context, req resource.UpdateReque
 
 	diags = resp.State.Set(ctx, &updatedState)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *groupBaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -221,9 +271,27 @@ func (r *groupBaseResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := r.cli.Group.Delete(ctx, config.Id.ValueString())
+	version, diags := getPrivateVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.cli.Group.Delete(ctx, config.Id.ValueString(), version)
+	if cli.IsPreconditionFailed(err) {
+		// the deletion is retried with the current version, if the group has only been modified by member assignments
+		currentVersion, unmodified, diags := unmodifiedGroupBaseVersion(ctx, r.cli, config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if unmodified {
+			err = r.cli.Group.Delete(ctx, config.Id.ValueString(), currentVersion)
+		}
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting group", fmt.Sprintf("%s", err))
+		resp.Diagnostics.Append(versionErrorDiagnostics("Error deleting group", "group", err)...)
 		return
 	}
 }
@@ -1,12 +1,18 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestResourceGroupBase(t *testing.T) {
@@ -109,6 +115,43 @@ func TestResourceGroupBase(t *testing.T) {
 			},
 		})
 	})
+	t.Run("version after member assignments", func(t *testing.T) {
+
+		current := groups.Group{
+			Id:          "group-id",
+			Schemas:     []string{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+			DisplayName: group.DisplayName,
+			GroupExtension: &groups.GroupExtension{
+				Name:        group.GroupExtension.Name,
+				Description: group.GroupExtension.Description,
+			},
+			GroupMembers: []groups.GroupMember{{Value: "user-id", Type: "User"}},
+			Meta:         users.Meta{Version: `W/"2"`},
+		}
+
+		client, srv := usersBulkTestClient(func(w http.ResponseWriter, r *http.Request) {
+			res, _ := json.Marshal(current)
+			_, err := w.Write(res)
+			assert.NoError(t, err, "Failed to write response")
+		})
+
+		defer srv.Close()
+
+		state, diags := groupBaseValueFrom(context.TODO(), current, "")
+		assert.False(t, diags.HasError())
+
+		// members are not managed by the resource, hence their assignment is no conflict
+		version, unmodified, diags := unmodifiedGroupBaseVersion(context.TODO(), client, state)
+		assert.False(t, diags.HasError())
+		assert.True(t, unmodified)
+		assert.Equal(t, `W/"2"`, version)
+
+		state.DisplayName = types.StringValue("Previous Display Name")
+
+		_, unmodified, diags = unmodifiedGroupBaseVersion(context.TODO(), client, state)
+		assert.False(t, diags.HasError())
+		assert.False(t, unmodified)
+	})
 }
 
 func ResourceGroupBase(resourceName string, group groups.Group) string {
@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newGroupMembersResource() resource.Resource {
+	return &groupMembersResource{}
+}
+
+type groupMembersResource struct {
+	cli *cli.SciClient
+}
+
+var _ resource.ResourceWithModifyPlan = &groupMembersResource{}
+
+func (r *groupMembersResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (r *groupMembersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_members"
+}
+
+func (r *groupMembersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Assign and manage a set of members of a group in the SAP Cloud Identity Services tenant.
+
+The changes to the members are applied with as few PATCH requests as possible, which makes the resource suitable for groups with a large number of members.
+
+### Conflict Warning
+If the resource is **authoritative**, it manages all members of the group and must not be combined with the **group_members** of the **sci_group** resource, **sci_group_assignment** resources or other **sci_group_members** resources for the same group.
+
+If the resource is not authoritative, it only manages the members it assigned, and can be combined with other non-authoritative **sci_group_members** or **sci_group_assignment** resources, as long as they do not manage the same members.
+
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique ID of the resource, which is the ID of the group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Unique ID of the group.",
+				Validators: []validator.String{
+					utils.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "SCIM IDs of the users or groups assigned to the group.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(utils.ValidUUID()),
+				},
+			},
+			"authoritative": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Set to `true` to remove all members of the group which are not part of `member_ids`. If `false`, only the members assigned by the resource are managed. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func (r *groupMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var plan groupMembersData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// no members are managed yet, so that a non-authoritative resource leaves the existing members untouched
+	diags = r.apply(ctx, plan, []string{}, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *groupMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var config groupMembersData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, _, err := r.cli.Group.GetByGroupId(ctx, config.GroupId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving group", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// the members are not set after an import, in which case all members of the group are adopted
+	var managedIds []string
+	if !config.MemberIds.IsNull() {
+		managedIds = []string{}
+		diags = config.MemberIds.ElementsAs(ctx, &managedIds, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	state, diags := groupMembersValueFrom(ctx, res, managedIds, config.Authoritative.ValueBool())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *groupMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var plan, state groupMembersData
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var managedIds []string
+	diags = state.MemberIds.ElementsAs(ctx, &managedIds, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.apply(ctx, plan, managedIds, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *groupMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	var config groupMembersData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var managedIds []string
+	diags = config.MemberIds.ElementsAs(ctx, &managedIds, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, _, err := r.cli.Group.GetByGroupId(ctx, config.GroupId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving group", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// only the members managed by the resource are removed, even if the resource is authoritative
+	_, remove := getGroupMembersDiff(group.GroupMembers, []string{}, managedIds, false)
+
+	_, _, err = applyGroupMembersDiff(ctx, r.cli, group, nil, remove)
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing group members", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (r *groupMembersResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// the check is skipped when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan groupMembersData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.GroupId.IsUnknown() || plan.MemberIds.IsUnknown() {
+		return
+	}
+
+	var plannedIds, managedIds []string
+	diags = plan.MemberIds.ElementsAs(ctx, &plannedIds, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state groupMembersData
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		diags = state.MemberIds.ElementsAs(ctx, &managedIds, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// only the members which are added can create a cycle
+	added := []groups.GroupMember{}
+	for _, memberId := range plannedIds {
+		if len(memberId) > 0 && !slices.Contains(managedIds, memberId) {
+			added = append(added, groups.GroupMember{Value: memberId})
+		}
+	}
+
+	resp.Diagnostics.Append(validateGroupCycles(ctx, r.cli, plan.GroupId.ValueString(), added, path.Root("member_ids"))...)
+}
+
+func (r *groupMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), req.ID)...)
+}
+
+// apply reads the members of the group once, applies the difference to the planned members and sets the resulting state
+// if only some of the changes have been applied, the state reflects them before the error is returned
+func (r *groupMembersResource) apply(ctx context.Context, plan groupMembersData, managedIds []string, state *tfsdk.State) diag.Diagnostics {
+
+	var diagnostics diag.Diagnostics
+
+	var plannedIds []string
+	diags := plan.MemberIds.ElementsAs(ctx, &plannedIds, false)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	group, _, err := r.cli.Group.GetByGroupId(ctx, plan.GroupId.ValueString())
+	if err != nil {
+		diagnostics.AddError("Error retrieving group", fmt.Sprintf("%s", err))
+		return diagnostics
+	}
+
+	add, remove := getGroupMembersDiff(group.GroupMembers, plannedIds, managedIds, plan.Authoritative.ValueBool())
+
+	res, applied, err := applyGroupMembersDiff(ctx, r.cli, group, add, remove)
+	if err != nil && !applied {
+		diagnostics.AddError("Error updating group members", fmt.Sprintf("%s", err))
+		return diagnostics
+	}
+
+	// after a failure, the members assigned by a previous apply are still managed if they have not been removed yet
+	updatedState, diags := groupMembersValueFrom(ctx, res, append(slices.Clone(managedIds), plannedIds...), plan.Authoritative.ValueBool())
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	diags = state.Set(ctx, &updatedState)
+	diagnostics.Append(diags...)
+
+	if err != nil {
+		diagnostics.AddError("Error updating group members", fmt.Sprintf("%s", err))
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceGroupMembers(t *testing.T) {
+	t.Parallel()
+
+	mockUuid := "af2f7963-358d-4336-bc51-57099394dee7"
+
+	t.Run("happy path", func(t *testing.T) {
+		requireCassette(t, "fixtures/resource_group_members")
+		rec, user := setupVCR(t, "fixtures/resource_group_members")
+		defer stopQuietly(rec)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + groupMembersTestConfig(false, "sci_user.member1.id"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrPair("sci_group_members.testMembers", "group_id", "sci_group.testGroup", "id"),
+						resource.TestCheckResourceAttr("sci_group_members.testMembers", "member_ids.#", "1"),
+						resource.TestCheckTypeSetElemAttrPair("sci_group_members.testMembers", "member_ids.*", "sci_user.member1", "id"),
+						resource.TestCheckResourceAttr("sci_group_members.testMembers", "authoritative", "false"),
+					),
+				},
+				{
+					ResourceName:      "sci_group_members.testMembers",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: providerConfig("", user) + groupMembersTestConfig(true, "sci_user.member1.id", "sci_user.member2.id"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("sci_group_members.testMembers", "member_ids.#", "2"),
+						resource.TestCheckTypeSetElemAttrPair("sci_group_members.testMembers", "member_ids.*", "sci_user.member1", "id"),
+						resource.TestCheckTypeSetElemAttrPair("sci_group_members.testMembers", "member_ids.*", "sci_user.member2", "id"),
+						resource.TestCheckResourceAttr("sci_group_members.testMembers", "authoritative", "true"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - group_id needs to be a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceGroupMembers("testMembers", "not-a-valid-uuid", []string{mockUuid}, false),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: not-a-valid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - member_ids need to be valid UUIDs", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceGroupMembers("testMembers", mockUuid, []string{"not-a-valid-uuid"}, true),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: not-a-valid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("diff - non-authoritative members", func(t *testing.T) {
+
+		current := []groups.GroupMember{
+			{Value: "managed-kept"},
+			{Value: "managed-removed"},
+			{Value: "unmanaged"},
+		}
+
+		add, remove := getGroupMembersDiff(current, []string{"managed-kept", "new"}, []string{"managed-kept", "managed-removed", "already-removed"}, false)
+
+		assert.Equal(t, []string{"new"}, add)
+		assert.Equal(t, []string{"managed-removed"}, remove)
+	})
+
+	t.Run("diff - authoritative members", func(t *testing.T) {
+
+		current := []groups.GroupMember{
+			{Value: "managed-kept"},
+			{Value: "unmanaged"},
+		}
+
+		add, remove := getGroupMembersDiff(current, []string{"managed-kept", "new"}, []string{"managed-kept"}, true)
+
+		assert.Equal(t, []string{"new"}, add)
+		assert.Equal(t, []string{"unmanaged"}, remove)
+	})
+
+	t.Run("value - only managed members are kept", func(t *testing.T) {
+
+		group := groups.Group{
+			Id:           "group-id",
+			GroupMembers: []groups.GroupMember{{Value: "managed"}, {Value: "unmanaged"}},
+		}
+
+		state, diags := groupMembersValueFrom(context.TODO(), group, []string{"managed", "removed-outside"}, false)
+		assert.False(t, diags.HasError())
+		assert.Len(t, state.MemberIds.Elements(), 1)
+
+		state, diags = groupMembersValueFrom(context.TODO(), group, []string{"managed"}, true)
+		assert.False(t, diags.HasError())
+		assert.Len(t, state.MemberIds.Elements(), 2)
+
+		// after an import, all members are adopted
+		state, diags = groupMembersValueFrom(context.TODO(), group, nil, false)
+		assert.False(t, diags.HasError())
+		assert.Len(t, state.MemberIds.Elements(), 2)
+	})
+
+	t.Run("apply - members are added and removed in chunks", func(t *testing.T) {
+
+		group := groups.Group{Id: "group-id"}
+		for i := range 150 {
+			group.GroupMembers = append(group.GroupMembers, groups.GroupMember{Value: fmt.Sprintf("old-%d", i)})
+		}
+
+		patchSizes := []int{}
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch {
+				var req groups.PatchRequestBody
+				_ = json.NewDecoder(r.Body).Decode(&req)
+
+				if req.Operations[0].Op == "add" {
+					patchSizes = append(patchSizes, len(req.Operations[0].Value.([]any)))
+				} else {
+					patchSizes = append(patchSizes, len(req.Operations))
+				}
+			}
+
+			if !strings.HasPrefix(r.URL.Path, "/scim/Groups/") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(group)
+		}))
+		defer srv.Close()
+
+		add := []string{}
+		for i := range 120 {
+			add = append(add, fmt.Sprintf("new-%d", i))
+		}
+
+		remove := []string{}
+		for _, member := range group.GroupMembers {
+			remove = append(remove, member.Value)
+		}
+
+		_, _, err := applyGroupMembersDiff(context.TODO(), client, group, add, remove)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{100, 50, 100, 20}, patchSizes)
+	})
+}
+
+func ResourceGroupMembers(resourceName string, groupId string, memberIds []string, authoritative bool) string {
+	return fmt.Sprintf(`
+	resource "sci_group_members" "%s" {
+		group_id = "%s"
+		member_ids = ["%s"]
+		authoritative = %t
+	}
+	`, resourceName, groupId, strings.Join(memberIds, `", "`), authoritative)
+}
+
+// groupMembersTestConfig creates a group and two users, the members are passed as references to the users
+// the members of the group are ignored by the group resource, as they are managed by the sci_group_members resource
+func groupMembersTestConfig(authoritative bool, memberIds ...string) string {
+	return fmt.Sprintf(`
+	resource "sci_user" "member1" {
+		user_name = "tf_group_members_1"
+		emails = [{ value = "tf.group.members.1@test.com", type = "work", primary = true }]
+	}
+
+	resource "sci_user" "member2" {
+		user_name = "tf_group_members_2"
+		emails = [{ value = "tf.group.members.2@test.com", type = "work", primary = true }]
+	}
+
+	resource "sci_group" "testGroup" {
+		display_name = "Terraform Group Members"
+
+		lifecycle {
+			ignore_changes = [group_members]
+		}
+	}
+
+	resource "sci_group_members" "testMembers" {
+		group_id = sci_group.testGroup.id
+		member_ids = [%s]
+		authoritative = %t
+	}
+	`, strings.Join(memberIds, ", "), authoritative)
+}
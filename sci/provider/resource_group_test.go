@@ -1,14 +1,25 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestResourceGroup(t *testing.T) {
@@ -179,6 +190,179 @@ func TestResourceGroup(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - custom_schemas must be a valid JSON", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceGroupWithCustomSchemas("testGroup", "Terraform Group", "{\\\"urn:test:terraform:1.0:Group\\\": "),
+					ExpectError: regexp.MustCompile("Attribute custom_schemas value must be valid json"),
+				},
+			},
+		})
+	})
+
+	t.Run("custom schemas update request", func(t *testing.T) {
+
+		state := groupData{
+			Schemas:       types.SetNull(types.StringType),
+			GroupMembers:  types.SetNull(membersObjType),
+			CustomSchemas: types.StringValue(`{"urn:test:terraform:1.0:Group":{"owner":"owner@example.com","classification":"internal"},"urn:test:terraform:2.0:Group":{"cost_center":"1234"}}`),
+		}
+		plan := groupData{
+			Schemas:       types.SetNull(types.StringType),
+			GroupMembers:  types.SetNull(membersObjType),
+			CustomSchemas: types.StringValue(`{"urn:test:terraform:1.0:Group":{"owner":"owner@example.com","classification":"confidential","retention":365}}`),
+		}
+
+		reqs, diags := getGroupUpdateRequest(context.TODO(), plan, state)
+
+		assert.False(t, diags.HasError())
+		assert.ElementsMatch(t, []generic.PatchRequest{
+			utils.GenerateReplacePatchRequest("urn:test:terraform:1.0:Group:classification", "confidential"),
+			utils.GenerateAddPatchRequest("urn:test:terraform:1.0:Group:retention", float64(365)),
+			utils.GenerateDeletePatchRequest("urn:test:terraform:2.0:Group"),
+		}, reqs)
+	})
+
+	t.Run("custom schemas value", func(t *testing.T) {
+
+		state, diags := groupValueFrom(context.TODO(), group, `{"urn:test:terraform:1.0:Group":{"owner":"owner@example.com"}}`)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, `{"urn:test:terraform:1.0:Group":{"owner":"owner@example.com"}}`, state.CustomSchemas.ValueString())
+
+		state, diags = groupValueFrom(context.TODO(), group, "")
+		assert.False(t, diags.HasError())
+		assert.True(t, state.CustomSchemas.IsNull())
+	})
+
+	t.Run("meta value", func(t *testing.T) {
+
+		group := group
+		group.Meta = users.Meta{
+			ResourceType: "Group",
+			Created:      "2024-01-15T08:30:00Z",
+			LastModified: "2025-03-02T10:00:00Z",
+			Version:      "2",
+		}
+
+		state, diags := groupValueFrom(context.TODO(), group, "")
+		assert.False(t, diags.HasError())
+
+		var meta scimMetaData
+		diags = state.Meta.As(context.TODO(), &meta, basetypes.ObjectAsOptions{})
+		assert.False(t, diags.HasError())
+		assert.Equal(t, scimMetaData{
+			ResourceType: types.StringValue("Group"),
+			Created:      types.StringValue("2024-01-15T08:30:00Z"),
+			LastModified: types.StringValue("2025-03-02T10:00:00Z"),
+			Location:     types.StringNull(),
+			Version:      types.StringValue("2"),
+		}, meta)
+
+		group.Meta = users.Meta{}
+		state, diags = groupValueFrom(context.TODO(), group, "")
+		assert.False(t, diags.HasError())
+		assert.True(t, state.Meta.IsNull())
+	})
+
+	t.Run("members update request", func(t *testing.T) {
+
+		member := func(value string, memberType string) memberData {
+			return memberData{Value: types.StringValue(value), Type: types.StringValue(memberType)}
+		}
+
+		state := groupData{
+			Schemas:      types.SetNull(types.StringType),
+			GroupMembers: types.SetValueMust(membersObjType, nil),
+		}
+		plan := state
+
+		var diags diag.Diagnostics
+		state.GroupMembers, diags = types.SetValueFrom(context.TODO(), membersObjType, []memberData{member("user-1", "User"), member("user-2", "User")})
+		assert.False(t, diags.HasError())
+		plan.GroupMembers, diags = types.SetValueFrom(context.TODO(), membersObjType, []memberData{member("user-1", "User"), member("group-1", "Group")})
+		assert.False(t, diags.HasError())
+
+		reqs, diags := getGroupUpdateRequest(context.TODO(), plan, state)
+		assert.False(t, diags.HasError())
+
+		// the members are replaced as a whole
+		assert.Equal(t, []generic.PatchRequest{
+			{
+				Op:   "replace",
+				Path: "members",
+				Value: []map[string]any{
+					{"value": "user-1", "type": "User"},
+					{"value": "group-1", "type": "Group"},
+				},
+			},
+		}, reqs)
+	})
+
+	t.Run("members update request with thousands of members is sent in chunks", func(t *testing.T) {
+
+		members := make([]attr.Value, 2550)
+		for i := range members {
+			members[i] = types.ObjectValueMust(membersObjType.AttrTypes, map[string]attr.Value{
+				"value": types.StringValue(fmt.Sprintf("member-%d", i)),
+				"type":  types.StringValue("User"),
+			})
+		}
+
+		state := groupData{
+			Schemas:      types.SetNull(types.StringType),
+			GroupMembers: types.SetNull(membersObjType),
+		}
+		plan := state
+		plan.GroupMembers = types.SetValueMust(membersObjType, members)
+
+		reqs, diags := getGroupUpdateRequest(context.TODO(), plan, state)
+		assert.False(t, diags.HasError())
+
+		var patches []groups.PatchRequestBody
+		client, srv := usersBulkTestClient(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch {
+				var patch groups.PatchRequestBody
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+				patches = append(patches, patch)
+			}
+			_, _ = w.Write([]byte(`{"id":"valid-group-id","displayName":"Test Group"}`))
+		})
+		defer srv.Close()
+
+		client.Group.MembersChunkSize = 500
+
+		_, _, err := client.Group.Update(context.TODO(), reqs, "valid-group-id", "", "")
+		assert.NoError(t, err)
+
+		// the first request replaces the members with the first chunk, the following requests add the remaining members
+		assert.Len(t, patches, 6)
+		for i, patch := range patches {
+
+			assert.Len(t, patch.Operations, 1)
+
+			op := "add"
+			if i == 0 {
+				op = "replace"
+			}
+			assert.Equal(t, op, patch.Operations[0].Op)
+			assert.Equal(t, "members", patch.Operations[0].Path)
+
+			chunk, _ := patch.Operations[0].Value.([]any)
+			if i < 5 {
+				assert.Len(t, chunk, 500)
+			} else {
+				assert.Len(t, chunk, 50)
+			}
+
+			// the chunks hold the members in order, without gaps or overlaps
+			assert.Equal(t, map[string]any{"value": fmt.Sprintf("member-%d", i*500), "type": "User"}, chunk[0])
+			assert.Equal(t, map[string]any{"value": fmt.Sprintf("member-%d", i*500+len(chunk)-1), "type": "User"}, chunk[len(chunk)-1])
+		}
+	})
+
 	t.Run("error path - group_members.type must be a valid value", func(t *testing.T) {
 
 		group.GroupMembers = []groups.GroupMember{
@@ -214,6 +398,20 @@ func ResourceGroup(resoureName string, group groups.Group) string {
 	`, resoureName, group.DisplayName, getGroupMembers(group.GroupMembers), group.GroupExtension.Name, group.GroupExtension.Description)
 }
 
+func ResourceGroupWithCustomSchemas(resoureName string, displayName string, customSchemas string) string {
+	return fmt.Sprintf(`
+	resource "sci_group" "%s"{
+		display_name = "%s"
+		schemas = [
+			"urn:ietf:params:scim:schemas:core:2.0:Group",
+			"urn:sap:cloud:scim:schemas:extension:custom:2.0:Group",
+			"urn:test:terraform:1.0:Group"
+		]
+		custom_schemas = "%s"
+	}
+	`, resoureName, displayName, customSchemas)
+}
+
 func ResourceGroupWithoutSchemas(resoureName string, displayName string) string {
 	return fmt.Sprintf(`
 	resource "sci_group" "%s"{
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
@@ -11,9 +12,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -24,6 +28,7 @@ var (
 		types.StringValue("urn:ietf:params:scim:schemas:core:2.0:Schema"),
 	}
 	attributeDataTypes        = []string{"string", "boolean", "decimal", "integer", "dateTime", "binary", "reference", "complex"}
+	subAttributeDataTypes     = []string{"string", "boolean", "decimal", "integer", "dateTime", "binary", "reference"}
 	attributeMutabilityValues = []string{"readOnly", "readWrite", "writeOnly", "immutable"}
 	attributeReturnValues     = []string{"always", "never", "default", "request"}
 	attributeUniquenessValues = []string{"none", "server", "global"}
@@ -37,6 +42,8 @@ type schemaResource struct {
 	cli *cli.SciClient
 }
 
+var _ resource.ResourceWithValidateConfig = &schemaResource{}
+
 func (r *schemaResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -56,17 +63,24 @@ func (r *schemaResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"id": schema.StringAttribute{
 				MarkdownDescription: "A unique id by which the schema can be referenced in other entities. The ID must follow the `urn:<namespace-identifier>:<resource-type>` pattern.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "A unique name for the schema",
 				Required:            true,
 			},
 			"attributes": schema.ListNestedAttribute{
-				MarkdownDescription: "The list of attribites that comprise the schema",
-				Required:            true,
+				MarkdownDescription: "The list of attribites that comprise the schema. Attributes can be added and their description, canonical values and other settings changed in place. " +
+					"Removing an attribute or changing its `type` or `multivalued` setting requires the replacement of the schema, which removes the values of its attributes from all users.",
+				Required: true,
 				Validators: []validator.List{
 					listvalidator.SizeAtMost(20),
 				},
+				PlanModifiers: []planmodifier.List{
+					schemaAttributesReplaceModifier{},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
@@ -131,6 +145,79 @@ func (r *schemaResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							Optional:            true,
 							MarkdownDescription: "A brief description for the attribute",
 						},
+						"sub_attributes": schema.ListNestedAttribute{
+							Optional:            true,
+							MarkdownDescription: "The sub-attributes of an attribute of type `complex`, which must be configured for such attributes only. Sub-attributes cannot be nested further.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "The sub-attribute name. Only alphanumeric characters and underscores are allowed.",
+										Validators: []validator.String{
+											stringvalidator.LengthBetween(2, 30),
+											utils.ValidAttributeName(),
+										},
+									},
+									"type": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "The sub-attribute data type. " + utils.ValidValuesString(subAttributeDataTypes),
+										Validators: []validator.String{
+											stringvalidator.OneOf(subAttributeDataTypes...),
+										},
+									},
+									"mutability": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "Control the Read or Write access of the sub-attribute. " + utils.ValidValuesString(attributeMutabilityValues),
+										Validators: []validator.String{
+											stringvalidator.OneOf(attributeMutabilityValues...),
+										},
+									},
+									"returned": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "Configure how the sub-attribute's value must be returned. " + utils.ValidValuesString(attributeReturnValues),
+										Validators: []validator.String{
+											stringvalidator.OneOf(attributeReturnValues...),
+										},
+									},
+									"uniqueness": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "Define the context in which the sub-attribute must be unique. " + utils.ValidValuesString(attributeUniquenessValues),
+										Validators: []validator.String{
+											stringvalidator.OneOf(attributeUniquenessValues...),
+										},
+									},
+									"multivalued": schema.BoolAttribute{
+										Required:            true,
+										MarkdownDescription: "Configure if the sub-attribute can have more than one value.",
+									},
+									"required": schema.BoolAttribute{
+										Optional:            true,
+										Computed:            true,
+										MarkdownDescription: "Configure if the sub-attribute must be mandatory or not.",
+									},
+									"case_exact": schema.BoolAttribute{
+										Optional:            true,
+										Computed:            true,
+										MarkdownDescription: "Configure if the sub-attribute must be case-sensitive or not.",
+									},
+									"canonical_values": schema.ListAttribute{
+										ElementType:         types.StringType,
+										Optional:            true,
+										MarkdownDescription: "A collection of suggested canonical values that may be used",
+										Validators: []validator.List{
+											listvalidator.SizeAtLeast(1),
+										},
+									},
+									"description": schema.StringAttribute{
+										Optional:            true,
+										MarkdownDescription: "A brief description for the sub-attribute",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -159,6 +246,48 @@ func (r *schemaResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+func (r *schemaResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var config schemaData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.Attributes.IsNull() || config.Attributes.IsUnknown() {
+		return
+	}
+
+	var attributes []attributesData
+	diags = config.Attributes.ElementsAs(ctx, &attributes, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the sub-attributes are mandatory for complex attributes and not allowed for any other type
+	for i, attribute := range attributes {
+		if attribute.Type.IsUnknown() || attribute.Type.IsNull() || attribute.SubAttributes.IsUnknown() {
+			continue
+		}
+
+		attributePath := path.Root("attributes").AtListIndex(i)
+
+		if attribute.Type.ValueString() == "complex" && attribute.SubAttributes.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				attributePath.AtName("sub_attributes"),
+				"Missing sub-attributes",
+				fmt.Sprintf("The attribute %s is of type complex, at least one sub-attribute must be configured.", attribute.Name.ValueString()),
+			)
+		}
+
+		if attribute.Type.ValueString() != "complex" && !attribute.SubAttributes.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				attributePath.AtName("sub_attributes"),
+				"Invalid sub-attributes",
+				fmt.Sprintf("The attribute %s is of type %s, only attributes of type complex can have sub-attributes.", attribute.Name.ValueString(), attribute.Type.ValueString()),
+			)
+		}
+	}
+}
+
 func (r *schemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 
 	var config schemaData
@@ -216,7 +345,34 @@ func (r *schemaResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *schemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Invalid Operation", "The resource \"Schema\" does not support updates.")
+
+	var plan schemaData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	args, diags := getSchemaRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, _, err := r.cli.Schema.Update(ctx, args)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating schema", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags := schemaValueFrom(ctx, res)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *schemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -239,3 +395,55 @@ func (r *schemaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 }
+
+func (r *schemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// schemaAttributesReplaceModifier requires the replacement of the schema if the attributes are changed in a way which cannot be applied in place
+type schemaAttributesReplaceModifier struct{}
+
+func (m schemaAttributesReplaceModifier) Description(_ context.Context) string {
+	return "Requires the replacement of the schema if an attribute is removed or its type or multivalued setting is changed."
+}
+
+func (m schemaAttributesReplaceModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m schemaAttributesReplaceModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+
+	// nothing to compare when the schema is created or destroyed, or if the attributes are not known yet
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var stateAttributes, planAttributes []attributesData
+
+	diags := req.StateValue.ElementsAs(ctx, &stateAttributes, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.PlanValue.ElementsAs(ctx, &planAttributes, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	breakingChanges, diags := getSchemaBreakingChanges(ctx, stateAttributes, planAttributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(breakingChanges) == 0 {
+		return
+	}
+
+	resp.RequiresReplace = true
+	resp.Diagnostics.AddAttributeWarning(
+		req.Path,
+		"Schema must be replaced",
+		fmt.Sprintf("The schema cannot be updated in place, as the following changes are not backward compatible :\n- %s\n"+
+			"The schema will be deleted and created again, which removes the values of its attributes from all users.",
+			strings.Join(breakingChanges, "\n- ")),
+	)
+}
@@ -1,14 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/schemas"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestResourceSchema(t *testing.T) {
@@ -262,6 +268,96 @@ func TestResourceSchema(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - schema attributes of type complex require sub_attributes", func(t *testing.T) {
+
+		schema.Attributes = []schemas.Attribute{
+			{
+				Name:       "test_attribute",
+				Type:       "complex",
+				Mutability: "readWrite",
+				Returned:   "default",
+				Uniqueness: "none",
+			},
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceSchema("testSchema", schema),
+					ExpectError: regexp.MustCompile("Missing sub-attributes"),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - schema sub_attributes are only allowed for attributes of type complex", func(t *testing.T) {
+
+		schema.Attributes = []schemas.Attribute{
+			{
+				Name:       "test_attribute",
+				Type:       "string",
+				Mutability: "readWrite",
+				Returned:   "default",
+				Uniqueness: "none",
+				SubAttributes: []schemas.Attribute{
+					{
+						Name:       "test_sub_attribute",
+						Type:       "string",
+						Mutability: "readWrite",
+						Returned:   "default",
+						Uniqueness: "none",
+					},
+				},
+			},
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceSchema("testSchema", schema),
+					ExpectError: regexp.MustCompile("Invalid sub-attributes"),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - schema sub_attributes cannot be of type complex", func(t *testing.T) {
+
+		schema.Attributes = []schemas.Attribute{
+			{
+				Name:       "test_attribute",
+				Type:       "complex",
+				Mutability: "readWrite",
+				Returned:   "default",
+				Uniqueness: "none",
+				SubAttributes: []schemas.Attribute{
+					{
+						Name:       "test_sub_attribute",
+						Type:       "complex",
+						Mutability: "readWrite",
+						Returned:   "default",
+						Uniqueness: "none",
+					},
+				},
+			},
+		}
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceSchema("testSchema", schema),
+					ExpectError: regexp.MustCompile(`Attribute attributes\[0].sub_attributes\[0].type value must be one of`),
+				},
+			},
+		})
+	})
+
 	t.Run("error path - schema attributes.uniqueness must be a valid value", func(t *testing.T) {
 
 		schema.Attributes = []schemas.Attribute{
@@ -288,6 +384,275 @@ func TestResourceSchema(t *testing.T) {
 
 }
 
+func TestResourceSchema_AttributesReplaceModifier(t *testing.T) {
+
+	current := schemas.Schema{
+		Id:   "urn:ietf:scim:schemas:Terraform",
+		Name: "Terraform",
+		Attributes: []schemas.Attribute{
+			{
+				Name:        "test_attribute",
+				Type:        "string",
+				Mutability:  "readWrite",
+				Returned:    "default",
+				Uniqueness:  "none",
+				Multivalued: false,
+			},
+			{
+				Name:        "other_attribute",
+				Type:        "integer",
+				Mutability:  "readWrite",
+				Returned:    "default",
+				Uniqueness:  "none",
+				Multivalued: true,
+			},
+		},
+	}
+
+	modify := func(t *testing.T, attributes []schemas.Attribute) *planmodifier.ListResponse {
+		t.Helper()
+
+		state, diags := schemaValueFrom(context.TODO(), current)
+		assert.False(t, diags.HasError())
+
+		updated := current
+		updated.Attributes = attributes
+		plan, diags := schemaValueFrom(context.TODO(), updated)
+		assert.False(t, diags.HasError())
+
+		resp := &planmodifier.ListResponse{PlanValue: plan.Attributes}
+		schemaAttributesReplaceModifier{}.PlanModifyList(context.TODO(), planmodifier.ListRequest{
+			Path:       path.Root("attributes"),
+			StateValue: state.Attributes,
+			PlanValue:  plan.Attributes,
+		}, resp)
+
+		return resp
+	}
+
+	t.Run("additive changes are applied in place", func(t *testing.T) {
+
+		attributes := slices.Clone(current.Attributes)
+		attributes[0].Description = "Updated description"
+		attributes[0].CanonicalValues = []string{"value"}
+		attributes = append(attributes, schemas.Attribute{
+			Name:       "new_attribute",
+			Type:       "boolean",
+			Mutability: "readWrite",
+			Returned:   "default",
+			Uniqueness: "none",
+		})
+
+		resp := modify(t, attributes)
+
+		assert.False(t, resp.RequiresReplace)
+		assert.Empty(t, resp.Diagnostics)
+	})
+
+	t.Run("breaking changes require the replacement of the schema", func(t *testing.T) {
+
+		attributes := slices.Clone(current.Attributes[:1])
+		attributes[0].Type = "integer"
+		attributes[0].Multivalued = true
+
+		resp := modify(t, attributes)
+
+		assert.True(t, resp.RequiresReplace)
+		assert.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "Schema must be replaced", resp.Diagnostics[0].Summary())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "the attribute other_attribute is removed")
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "the type of the attribute test_attribute is changed from string to integer")
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "the attribute test_attribute is changed from multivalued=false to multivalued=true")
+	})
+
+	t.Run("breaking changes of sub-attributes require the replacement of the schema", func(t *testing.T) {
+
+		complexAttribute := schemas.Attribute{
+			Name:       "address",
+			Type:       "complex",
+			Mutability: "readWrite",
+			Returned:   "default",
+			Uniqueness: "none",
+			SubAttributes: []schemas.Attribute{
+				{
+					Name:       "street",
+					Type:       "string",
+					Mutability: "readWrite",
+					Returned:   "default",
+					Uniqueness: "none",
+				},
+				{
+					Name:       "zip",
+					Type:       "string",
+					Mutability: "readWrite",
+					Returned:   "default",
+					Uniqueness: "none",
+				},
+			},
+		}
+
+		previous := current.Attributes
+		current.Attributes = append(slices.Clone(previous), complexAttribute)
+		defer func() { current.Attributes = previous }()
+
+		updated := complexAttribute
+		updated.SubAttributes = slices.Clone(complexAttribute.SubAttributes[1:])
+		updated.SubAttributes[0].Type = "integer"
+
+		resp := modify(t, append(slices.Clone(previous), updated))
+
+		assert.True(t, resp.RequiresReplace)
+		assert.Len(t, resp.Diagnostics, 1)
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "the attribute address.street is removed")
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "the type of the attribute address.zip is changed from string to integer")
+
+		// new sub-attributes are added in place
+		updated.SubAttributes = append(slices.Clone(complexAttribute.SubAttributes), schemas.Attribute{
+			Name:       "city",
+			Type:       "string",
+			Mutability: "readWrite",
+			Returned:   "default",
+			Uniqueness: "none",
+		})
+
+		resp = modify(t, append(slices.Clone(previous), updated))
+
+		assert.False(t, resp.RequiresReplace)
+		assert.Empty(t, resp.Diagnostics)
+	})
+
+	t.Run("no replacement when the schema is created", func(t *testing.T) {
+
+		plan, _ := schemaValueFrom(context.TODO(), current)
+
+		resp := &planmodifier.ListResponse{PlanValue: plan.Attributes}
+		schemaAttributesReplaceModifier{}.PlanModifyList(context.TODO(), planmodifier.ListRequest{
+			Path:       path.Root("attributes"),
+			StateValue: types.ListNull(attributeObjType),
+			PlanValue:  plan.Attributes,
+		}, resp)
+
+		assert.False(t, resp.RequiresReplace)
+		assert.Empty(t, resp.Diagnostics)
+	})
+}
+
+func TestResourceSchema_ValueFrom(t *testing.T) {
+
+	t.Run("server defaults are normalised", func(t *testing.T) {
+
+		res := schemas.Schema{
+			Id:   "urn:ietf:scim:schemas:Terraform",
+			Name: "Terraform",
+			Attributes: []schemas.Attribute{
+				{
+					Name: "test_attribute",
+					Type: "string",
+				},
+			},
+		}
+
+		state, diags := schemaValueFrom(context.TODO(), res)
+		assert.False(t, diags.HasError())
+
+		var attributes []attributesData
+		diags = state.Attributes.ElementsAs(context.TODO(), &attributes, false)
+		assert.False(t, diags.HasError())
+
+		assert.Equal(t, types.SetValueMust(types.StringType, defaultSchemaSchemas), state.Schemas)
+		assert.True(t, state.Description.IsNull())
+		assert.Len(t, attributes, 1)
+		assert.Equal(t, "readWrite", attributes[0].Mutability.ValueString())
+		assert.Equal(t, "default", attributes[0].Returned.ValueString())
+		assert.Equal(t, "none", attributes[0].Uniqueness.ValueString())
+		assert.False(t, attributes[0].Multivalued.ValueBool())
+		assert.True(t, attributes[0].CanonicalValues.IsNull())
+		assert.True(t, attributes[0].Description.IsNull())
+	})
+
+	t.Run("sub-attributes of complex attributes", func(t *testing.T) {
+
+		res := schemas.Schema{
+			Id:      "urn:ietf:scim:schemas:Terraform",
+			Name:    "Terraform",
+			Schemas: []string{"urn:ietf:params:scim:schemas:core:2.0:Schema"},
+			Attributes: []schemas.Attribute{
+				{
+					Name:        "address",
+					Type:        "complex",
+					Mutability:  "readWrite",
+					Returned:    "default",
+					Uniqueness:  "none",
+					Multivalued: true,
+					SubAttributes: []schemas.Attribute{
+						{
+							Name:       "street",
+							Type:       "string",
+							Mutability: "readWrite",
+							Returned:   "default",
+							Uniqueness: "none",
+						},
+						{
+							Name: "zip",
+							Type: "integer",
+						},
+					},
+				},
+			},
+		}
+
+		state, diags := schemaValueFrom(context.TODO(), res)
+		assert.False(t, diags.HasError())
+
+		var attributes []attributesData
+		diags = state.Attributes.ElementsAs(context.TODO(), &attributes, false)
+		assert.False(t, diags.HasError())
+
+		var subAttributes []subAttributesData
+		diags = attributes[0].SubAttributes.ElementsAs(context.TODO(), &subAttributes, false)
+		assert.False(t, diags.HasError())
+
+		assert.Len(t, subAttributes, 2)
+		assert.Equal(t, "zip", subAttributes[1].Name.ValueString())
+		assert.Equal(t, "readWrite", subAttributes[1].Mutability.ValueString())
+
+		// the server defaults of the sub-attributes are sent explicitly
+		res.Attributes[0].SubAttributes[1].Mutability = "readWrite"
+		res.Attributes[0].SubAttributes[1].Returned = "default"
+		res.Attributes[0].SubAttributes[1].Uniqueness = "none"
+
+		request, diags := getSchemaRequest(context.TODO(), state)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, res, *request)
+	})
+
+	t.Run("values returned by the server are kept", func(t *testing.T) {
+
+		res := schemas.Schema{
+			Id:      "urn:ietf:scim:schemas:Terraform",
+			Name:    "Terraform",
+			Schemas: []string{"urn:ietf:params:scim:schemas:core:2.0:Schema"},
+			Attributes: []schemas.Attribute{
+				{
+					Name:            "test_attribute",
+					Type:            "string",
+					Mutability:      "immutable",
+					Returned:        "never",
+					Uniqueness:      "global",
+					CanonicalValues: []string{"value"},
+				},
+			},
+		}
+
+		state, diags := schemaValueFrom(context.TODO(), res)
+		assert.False(t, diags.HasError())
+
+		request, diags := getSchemaRequest(context.TODO(), state)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, res, *request)
+	})
+}
+
 func ResourceSchema(resourceName string, schema schemas.Schema) string {
 	return fmt.Sprintf(`
 	resource "sci_schema" "%s"{
@@ -351,6 +716,11 @@ func getSchemaAttributes(schemaAttributes []schemas.Attribute) string {
 			`, val)
 		}
 
+		var subAttributes string
+		if len(attr.SubAttributes) > 0 {
+			subAttributes = fmt.Sprintf("sub_attributes = [%s]", getSchemaAttributes(attr.SubAttributes))
+		}
+
 		fmt.Fprintf(&attributes, `{
 			name = "%s"
 			mutability = "%s"
@@ -362,8 +732,9 @@ func getSchemaAttributes(schemaAttributes []schemas.Attribute) string {
 			description = "%s"
 			required = %t
 			case_exact = %t
+			%s
 		},`, attr.Name, attr.Mutability, attr.Returned, attr.Type, attr.Uniqueness,
-			canonicalValues.String(), attr.Multivalued, attr.Description, attr.Required, attr.CaseExact)
+			canonicalValues.String(), attr.Multivalued, attr.Description, attr.Required, attr.CaseExact, subAttributes)
 	}
 	return attributes.String()
 }
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
@@ -15,11 +16,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -32,9 +35,10 @@ var (
 		types.StringValue("urn:ietf:params:scim:schemas:extension:sap:2.0:User"),
 	}
 
-	emailTypeValues = []string{"work", "home", "other"}
-	userTypeValues  = []string{"public", "partner", "customer", "external", "onboardee", "employee"}
-	activeValues    = []string{"active", "inactive", "new"}
+	emailTypeValues    = []string{"work", "home", "other"}
+	userTypeValues     = []string{"public", "partner", "customer", "external", "onboardee", "employee"}
+	activeValues       = []string{"active", "inactive", "new"}
+	deletionModeValues = []string{"delete", "deactivate", "retain"}
 )
 
 func newUserResource() resource.Resource {
@@ -59,7 +63,9 @@ func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest,
 
 func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: `Creates a user in the SAP Cloud Identity Services.`,
+		MarkdownDescription: `Creates a user in the SAP Cloud Identity Services.
+
+If the tenant supports ETags, the user is only updated or deleted if it has not been modified outside of Terraform since it was last read.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "ID of the user.",
@@ -214,6 +220,24 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"deletion_mode": schema.StringAttribute{
+				MarkdownDescription: "Determines how the user is handled when the resource is destroyed. The default value for the attribute is `delete`. " + utils.ValidValuesString(deletionModeValues) +
+					"\n\t - `delete` : the user is permanently deleted from the tenant" +
+					"\n\t - `deactivate` : the user is set to inactive and retained in the tenant, along with its audit history" +
+					"\n\t - `retain` : the user is only removed from the Terraform state",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("delete"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(deletionModeValues...),
+				},
+			},
+			"force_delete": schema.BoolAttribute{
+				MarkdownDescription: "Determines whether the user can be deleted while still being a member of groups. Only applicable if `deletion_mode` is `delete`. The default value for the attribute is false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"groups": schema.ListNestedAttribute{
 				MarkdownDescription: "The list of Groups that the user belongs to.",
 				Computed:            true,
@@ -246,20 +270,21 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			"meta": metaResourceSchema("user"),
 		},
 	}
 }
 
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 
-	var plan userData
+	var plan userResourceData
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	args, customSchemas, diags := getUserRequest(ctx, plan)
+	args, customSchemas, diags := getUserRequest(ctx, plan.userData)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -271,28 +296,40 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	state, diags := userValueFrom(ctx, res, customSchemas)
+	user, diags := userValueFrom(ctx, res, customSchemas)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// the initial password is not returned in the response, hence it must be read from the plan
-	state.InitialPassword = plan.InitialPassword
+	user.InitialPassword = plan.InitialPassword
 
-	diags = userStateModify(ctx, plan, &state)
+	diags = userStateModify(ctx, plan.userData, &user)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
 	}
 
+	state := userResourceData{
+		userData:     user,
+		DeletionMode: plan.DeletionMode,
+		ForceDelete:  plan.ForceDelete,
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 
-	var config userData
+	var config userResourceData
 	diags := req.State.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -305,29 +342,49 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	state, diags := userValueFrom(ctx, res, customSchemasRes)
+	user, diags := userValueFrom(ctx, res, customSchemasRes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// the initial password is not returned in the response, hence it must be read from the state
-	state.InitialPassword = config.InitialPassword
+	user.InitialPassword = config.InitialPassword
+
+	state := userResourceData{
+		userData:     user,
+		DeletionMode: config.DeletionMode,
+		ForceDelete:  config.ForceDelete,
+	}
+
+	// the attributes are not part of the API, hence the default values are set when the user is imported
+	if state.DeletionMode.IsNull() {
+		state.DeletionMode = types.StringValue("delete")
+	}
+	if state.ForceDelete.IsNull() {
+		state.ForceDelete = types.BoolValue(false)
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 
-	var plan userData
+	var plan userResourceData
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state userData
+	var state userResourceData
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -339,7 +396,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	args, diags := getUserUpdateRequest(ctx, plan, state)
+	args, diags := getUserUpdateRequest(ctx, plan.userData, state.userData)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -347,34 +404,61 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	customSchemas := plan.CustomSchemas.ValueString()
 
-	res, cS, err := r.cli.User.Update(ctx, state.Id.ValueString(), args, customSchemas)
+	version, diags := getPrivateVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res users.User
+	var cS string
+	var err error
+
+	// changes to attributes such as deletion_mode are not sent to the API, hence the user is only read in that case
+	if len(args) > 0 {
+		res, cS, err = r.cli.User.Update(ctx, state.Id.ValueString(), args, customSchemas, version)
+	} else {
+		res, cS, err = r.cli.User.GetByUserId(ctx, state.Id.ValueString(), true, customSchemas)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating user", fmt.Sprintf("%s", err))
+		resp.Diagnostics.Append(versionErrorDiagnostics("Error updating user", "user", err)...)
 		return
 	}
 
-	updatedState, diags := userValueFrom(ctx, res, cS)
+	user, diags := userValueFrom(ctx, res, cS)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// the initial password is not returned in the response, hence it must be read from the plan
-	updatedState.InitialPassword = plan.InitialPassword
+	user.InitialPassword = plan.InitialPassword
 
-	diags = userStateModify(ctx, plan, &updatedState)
+	diags = userStateModify(ctx, plan.userData, &user)
 	resp.Diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
 	}
 
+	updatedState := userResourceData{
+		userData:     user,
+		DeletionMode: plan.DeletionMode,
+		ForceDelete:  plan.ForceDelete,
+	}
+
 	diags = resp.State.Set(ctx, &updatedState)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setPrivateVersion(ctx, resp.Private, res.Meta.Version)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 
-	var config userData
+	var config userResourceData
 	diags := req.State.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -386,12 +470,56 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.cli.User.Delete(ctx, config.Id.ValueString())
-
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting user", fmt.Sprintf("%s", err))
+	version, diags := getPrivateVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	switch config.DeletionMode.ValueString() {
+	case "retain":
+		resp.Diagnostics.AddWarning("User retained", fmt.Sprintf("The user %s has been removed from the Terraform state, but has not been deleted from the tenant.", config.UserName.ValueString()))
+
+	case "deactivate":
+		args, diags := getUserDeactivateRequest()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		_, _, err := r.cli.User.Update(ctx, config.Id.ValueString(), args, "", version)
+		if err != nil {
+			resp.Diagnostics.Append(versionErrorDiagnostics("Error deactivating user", "user", err)...)
+			return
+		}
+
+	default:
+		// the memberships are read when the user is deleted, as memberships removed in the same run are removed beforehand
+		if !config.ForceDelete.ValueBool() {
+
+			current, _, err := r.cli.User.GetByUserId(ctx, config.Id.ValueString(), false, "")
+			// the user has already been deleted outside of Terraform
+			if cli.ScimErrorStatus(err) == http.StatusNotFound {
+				return
+			}
+			if err != nil {
+				resp.Diagnostics.AddError("Error retrieving user", fmt.Sprintf("%s", err))
+				return
+			}
+
+			resp.Diagnostics.Append(validateUserDeletion(config.UserName.ValueString(), current.Groups)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		err := r.cli.User.Delete(ctx, config.Id.ValueString(), version)
+
+		if err != nil {
+			resp.Diagnostics.Append(versionErrorDiagnostics("Error deleting user", "user", err)...)
+			return
+		}
+	}
 }
 
 func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -434,3 +562,34 @@ func userStateModify(ctx context.Context, plan userData, state *userData) diag.D
 
 	return nil
 }
+
+// metaResourceSchema describes the SCIM meta attributes maintained by the tenant for a user or group.
+// The attributes change with every modification of the resource, hence no plan modifiers are set.
+func metaResourceSchema(resourceName string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Metadata of the " + resourceName + " maintained by the tenant.",
+		Computed:            true,
+		Attributes: map[string]schema.Attribute{
+			"resource_type": schema.StringAttribute{
+				MarkdownDescription: "The SCIM resource type of the " + resourceName + ".",
+				Computed:            true,
+			},
+			"created": schema.StringAttribute{
+				MarkdownDescription: "The date and time the " + resourceName + " was created, in RFC3339 format.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "The date and time the " + resourceName + " was last modified, in RFC3339 format.",
+				Computed:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "The URI of the " + resourceName + ".",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The version of the " + resourceName + ".",
+				Computed:            true,
+			},
+		},
+	}
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newUserGroupMembershipsResource() resource.Resource {
+	return &userGroupMembershipsResource{}
+}
+
+type userGroupMembershipsResource struct {
+	cli *cli.SciClient
+}
+
+func (r *userGroupMembershipsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (r *userGroupMembershipsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_group_memberships"
+}
+
+func (r *userGroupMembershipsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Assign and manage the groups a user is a direct member of in the SAP Cloud Identity Services tenant.
+
+The memberships are managed from the side of the user, each change is applied as a PATCH request on the affected group.
+
+### Conflict Warning
+If the resource is **authoritative**, it manages all direct memberships of the user. The user must then not be managed as member by the **sci_group**, **sci_group_assignment** or authoritative **sci_group_members** resources.
+
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique ID of the resource, which is the ID of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Unique ID of the user.",
+				Validators: []validator.String{
+					utils.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Unique IDs of the groups the user is assigned to.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(utils.ValidUUID()),
+				},
+			},
+			"authoritative": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Set to `true` to remove the user from all groups which are not part of `group_ids`. If `false`, only the memberships assigned by the resource are managed. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func (r *userGroupMembershipsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var plan userGroupMembershipsData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// no memberships are managed yet, so that a non-authoritative resource leaves the existing memberships untouched
+	diags = r.apply(ctx, plan, []string{}, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *userGroupMembershipsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var config userGroupMembershipsData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, _, err := r.cli.User.GetByUserId(ctx, config.UserId.ValueString(), false, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving user", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// the groups are not set after an import, in which case all direct memberships of the user are adopted
+	var managedIds []string
+	if !config.GroupIds.IsNull() {
+		managedIds = []string{}
+		diags = config.GroupIds.ElementsAs(ctx, &managedIds, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	state, diags := userGroupMembershipsValueFrom(ctx, res, managedIds, config.Authoritative.ValueBool())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *userGroupMembershipsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var plan, state userGroupMembershipsData
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var managedIds []string
+	diags = state.GroupIds.ElementsAs(ctx, &managedIds, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.apply(ctx, plan, managedIds, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *userGroupMembershipsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	var config userGroupMembershipsData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var managedIds []string
+	diags = config.GroupIds.ElementsAs(ctx, &managedIds, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, _, err := r.cli.User.GetByUserId(ctx, config.UserId.ValueString(), false, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving user", fmt.Sprintf("%s", err))
+		return
+	}
+
+	// only the memberships managed by the resource are removed, even if the resource is authoritative
+	_, remove := getGroupMembersDiff(groupMembersOf(directGroupIds(user)), []string{}, managedIds, false)
+
+	_, err = applyUserGroupMembershipsDiff(ctx, r.cli, user.Id, nil, remove)
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing group memberships", fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (r *userGroupMembershipsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), req.ID)...)
+}
+
+// apply reads the memberships of the user once, applies the difference to the planned groups and sets the resulting state
+// if only some of the changes have been applied, the state reflects them before the error is returned
+func (r *userGroupMembershipsResource) apply(ctx context.Context, plan userGroupMembershipsData, managedIds []string, state *tfsdk.State) diag.Diagnostics {
+
+	var diagnostics diag.Diagnostics
+
+	var plannedIds []string
+	diags := plan.GroupIds.ElementsAs(ctx, &plannedIds, false)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	user, _, err := r.cli.User.GetByUserId(ctx, plan.UserId.ValueString(), false, "")
+	if err != nil {
+		diagnostics.AddError("Error retrieving user", fmt.Sprintf("%s", err))
+		return diagnostics
+	}
+
+	add, remove := getGroupMembersDiff(groupMembersOf(directGroupIds(user)), plannedIds, managedIds, plan.Authoritative.ValueBool())
+
+	applied, err := applyUserGroupMembershipsDiff(ctx, r.cli, user.Id, add, remove)
+	if err != nil && !applied {
+		diagnostics.AddError("Error updating group memberships", fmt.Sprintf("%s", err))
+		return diagnostics
+	}
+	applyErr := err
+
+	// the user is read again, as the groups of the user are not part of the responses of the group updates
+	user, _, err = r.cli.User.GetByUserId(ctx, plan.UserId.ValueString(), false, "")
+	if err != nil {
+		diagnostics.AddError("Error retrieving user", fmt.Sprintf("%s", err))
+		if applyErr != nil {
+			diagnostics.AddError("Error updating group memberships", fmt.Sprintf("%s", applyErr))
+		}
+		return diagnostics
+	}
+
+	// after a failure, the memberships assigned by a previous apply are still managed if they have not been removed yet
+	updatedState, diags := userGroupMembershipsValueFrom(ctx, user, append(slices.Clone(managedIds), plannedIds...), plan.Authoritative.ValueBool())
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	diags = state.Set(ctx, &updatedState)
+	diagnostics.Append(diags...)
+
+	if applyErr != nil {
+		diagnostics.AddError("Error updating group memberships", fmt.Sprintf("%s", applyErr))
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceUserGroupMemberships(t *testing.T) {
+	t.Parallel()
+
+	mockUuid := "af2f7963-358d-4336-bc51-57099394dee7"
+
+	t.Run("happy path", func(t *testing.T) {
+		requireCassette(t, "fixtures/resource_user_group_memberships")
+		rec, user := setupVCR(t, "fixtures/resource_user_group_memberships")
+		defer stopQuietly(rec)
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(rec.GetDefaultClient()),
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig("", user) + userGroupMembershipsTestConfig(false, "sci_group.group1.id"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrPair("sci_user_group_memberships.testMemberships", "user_id", "sci_user.testUser", "id"),
+						resource.TestCheckResourceAttr("sci_user_group_memberships.testMemberships", "group_ids.#", "1"),
+						resource.TestCheckTypeSetElemAttrPair("sci_user_group_memberships.testMemberships", "group_ids.*", "sci_group.group1", "id"),
+						resource.TestCheckResourceAttr("sci_user_group_memberships.testMemberships", "authoritative", "false"),
+						resource.TestCheckResourceAttr("data.sci_user_memberships.testMemberships", "groups.#", "1"),
+						resource.TestCheckResourceAttrPair("data.sci_user_memberships.testMemberships", "groups.0.group_id", "sci_group.group1", "id"),
+						resource.TestCheckResourceAttr("data.sci_user_memberships.testMemberships", "groups.0.direct", "true"),
+					),
+				},
+				{
+					ResourceName:      "sci_user_group_memberships.testMemberships",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: providerConfig("", user) + userGroupMembershipsTestConfig(true, "sci_group.group1.id", "sci_group.group2.id"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("sci_user_group_memberships.testMemberships", "group_ids.#", "2"),
+						resource.TestCheckTypeSetElemAttrPair("sci_user_group_memberships.testMemberships", "group_ids.*", "sci_group.group1", "id"),
+						resource.TestCheckTypeSetElemAttrPair("sci_user_group_memberships.testMemberships", "group_ids.*", "sci_group.group2", "id"),
+						resource.TestCheckResourceAttr("sci_user_group_memberships.testMemberships", "authoritative", "true"),
+						resource.TestCheckResourceAttr("data.sci_user_memberships.testMemberships", "groups.#", "2"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - user_id needs to be a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceUserGroupMemberships("testMemberships", "not-a-valid-uuid", mockUuid),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: not-a-valid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - group_ids need to be valid UUIDs", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceUserGroupMemberships("testMemberships", mockUuid, "not-a-valid-uuid"),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: not-a-valid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("value - only managed memberships are kept", func(t *testing.T) {
+
+		user := users.User{
+			Id: "user-id",
+			Groups: []users.Group{
+				{Value: "managed", Type: "direct"},
+				{Value: "unmanaged", Type: "direct"},
+				{Value: "nested", Type: "indirect"},
+			},
+		}
+
+		state, diags := userGroupMembershipsValueFrom(context.TODO(), user, []string{"managed"}, false)
+		assert.False(t, diags.HasError())
+		assert.Len(t, state.GroupIds.Elements(), 1)
+
+		state, diags = userGroupMembershipsValueFrom(context.TODO(), user, []string{"managed"}, true)
+		assert.False(t, diags.HasError())
+		assert.Len(t, state.GroupIds.Elements(), 2)
+	})
+
+	t.Run("apply - each affected group is patched", func(t *testing.T) {
+
+		patched := []string{}
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			groupId := strings.TrimPrefix(r.URL.Path, "/scim/Groups/")
+
+			if r.Method == http.MethodPatch {
+				var req groups.PatchRequestBody
+				_ = json.NewDecoder(r.Body).Decode(&req)
+
+				assert.Len(t, req.Operations, 1)
+				patched = append(patched, req.Operations[0].Op+" "+groupId)
+			}
+
+			_ = json.NewEncoder(w).Encode(groups.Group{Id: groupId})
+		}))
+		defer srv.Close()
+
+		applied, err := applyUserGroupMembershipsDiff(context.TODO(), client, "user-id", []string{"group-1", "group-2"}, []string{"group-3"})
+
+		assert.NoError(t, err)
+		assert.True(t, applied)
+
+		sort.Strings(patched)
+		assert.Equal(t, []string{"add group-1", "add group-2", "remove group-3"}, patched)
+	})
+
+	t.Run("apply - the partially applied memberships are kept in the state", func(t *testing.T) {
+
+		user := users.User{
+			Id:     "user-id",
+			Groups: []users.Group{{Value: "unmanaged", Type: "direct"}},
+		}
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			groupId := strings.TrimPrefix(r.URL.Path, "/scim/Groups/")
+
+			switch {
+			case r.Method == http.MethodPatch && groupId == "group-1":
+				user.Groups = append(user.Groups, users.Group{Value: groupId, Type: "direct"})
+				_ = json.NewEncoder(w).Encode(groups.Group{Id: groupId})
+			case r.Method == http.MethodPatch:
+				w.WriteHeader(http.StatusInternalServerError)
+			default:
+				_ = json.NewEncoder(w).Encode(user)
+			}
+		}))
+		defer srv.Close()
+
+		r := &userGroupMembershipsResource{cli: client}
+
+		var schemaResp tfresource.SchemaResponse
+		r.Schema(context.TODO(), tfresource.SchemaRequest{}, &schemaResp)
+
+		groupIds, _ := types.SetValueFrom(context.TODO(), types.StringType, []string{"group-1", "group-2"})
+		plan := userGroupMembershipsData{
+			Id:            types.StringUnknown(),
+			UserId:        types.StringValue("user-id"),
+			GroupIds:      groupIds,
+			Authoritative: types.BoolValue(false),
+		}
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		diags := r.apply(context.TODO(), plan, []string{}, &state)
+
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags.Errors()[0].Detail(), "adding the user to group group-2 failed")
+
+		var updatedState userGroupMembershipsData
+		assert.False(t, state.Get(context.TODO(), &updatedState).HasError())
+
+		var stateGroupIds []string
+		updatedState.GroupIds.ElementsAs(context.TODO(), &stateGroupIds, false)
+		assert.Equal(t, []string{"group-1"}, stateGroupIds)
+	})
+
+	t.Run("user name - the user name is escaped in the filter", func(t *testing.T) {
+
+		filters := []string{}
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			filters = append(filters, r.URL.Query().Get("filter"))
+			_ = json.NewEncoder(w).Encode(users.UsersResponse{Resources: []users.User{{Id: "user-id"}}})
+		}))
+		defer srv.Close()
+
+		user, err := getUserByIdOrName(context.TODO(), client, "", `jane" or userName pr or userName eq "\`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user-id", user.Id)
+		assert.Equal(t, []string{`userName eq "jane\" or userName pr or userName eq \"\\"`}, filters)
+	})
+}
+
+func ResourceUserGroupMemberships(resourceName string, userId string, groupId string) string {
+	return fmt.Sprintf(`
+	resource "sci_user_group_memberships" "%s" {
+		user_id = "%s"
+		group_ids = ["%s"]
+	}
+	`, resourceName, userId, groupId)
+}
+
+// userGroupMembershipsTestConfig creates a user and two groups, the groups are passed as references to the groups
+// the members of the groups are ignored by the group resources, as they are managed by the sci_user_group_memberships resource
+func userGroupMembershipsTestConfig(authoritative bool, groupIds ...string) string {
+	return fmt.Sprintf(`
+	resource "sci_user" "testUser" {
+		user_name = "tf_user_group_memberships"
+		emails = [{ value = "tf.user.group.memberships@test.com", type = "work", primary = true }]
+	}
+
+	resource "sci_group" "group1" {
+		display_name = "Terraform User Group Memberships 1"
+
+		lifecycle {
+			ignore_changes = [group_members]
+		}
+	}
+
+	resource "sci_group" "group2" {
+		display_name = "Terraform User Group Memberships 2"
+
+		lifecycle {
+			ignore_changes = [group_members]
+		}
+	}
+
+	resource "sci_user_group_memberships" "testMemberships" {
+		user_id = sci_user.testUser.id
+		group_ids = [%s]
+		authoritative = %t
+	}
+
+	data "sci_user_memberships" "testMemberships" {
+		user_id = sci_user.testUser.id
+
+		depends_on = [sci_user_group_memberships.testMemberships]
+	}
+	`, strings.Join(groupIds, ", "), authoritative)
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var userPasswordPath = path.Root("password_wo")
+
+func newUserPasswordResource() resource.Resource {
+	return &userPasswordResource{}
+}
+
+type userPasswordResource struct {
+	cli *cli.SciClient
+}
+
+func (r *userPasswordResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (r *userPasswordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_password"
+}
+
+func (r *userPasswordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Sets the password of an existing user in the SAP Cloud Identity Services.
+
+The password is configured as a write-only attribute and is never stored in the Terraform state. Since changes to a write-only attribute cannot be detected, the password is only set again when the value of ` + "`rotation_trigger`" + ` or ` + "`force_change_on_next_logon`" + ` changes.
+
+Destroying the resource removes it from the Terraform state only, the password of the user is not modified.
+
+~> **NOTE:** Write-only attributes are supported in Terraform 1.11 and later.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user whose password is managed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user whose password must be set.",
+				Required:            true,
+				Validators: []validator.String{
+					utils.ValidUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "The password to be set for the user. The value must satisfy the password policy of the tenant.",
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary value, which when changed, sets the password of the user again. For example, a date or a version number can be configured to rotate the password periodically.",
+				Optional:            true,
+			},
+			"force_change_on_next_logon": schema.BoolAttribute{
+				MarkdownDescription: "Determines whether the user must change the password at the next logon. The default value for the attribute is false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *userPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var plan userPasswordData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// write-only attributes are only available in the configuration
+	var password types.String
+	diags = req.Config.GetAttribute(ctx, userPasswordPath, &password)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.cli.User.SetPassword(ctx, plan.UserId.ValueString(), password.ValueString(), plan.ForceChangeOnNextLogon.ValueBool())
+	if err != nil {
+		resp.Diagnostics.Append(userPasswordErrorDiagnostics("Error setting user password", err)...)
+		return
+	}
+
+	plan.Id = plan.UserId
+	plan.PasswordWo = types.StringNull()
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *userPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var state userPasswordData
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the password is never returned by the API, hence only the existence of the user is verified
+	_, _, err := r.cli.User.GetByUserId(ctx, state.UserId.ValueString(), false, "")
+	// the user has been deleted outside of Terraform, along with its password
+	if cli.ScimErrorStatus(err) == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving user", fmt.Sprintf("%s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *userPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var plan userPasswordData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state userPasswordData
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var password types.String
+	diags = req.Config.GetAttribute(ctx, userPasswordPath, &password)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.cli.User.SetPassword(ctx, state.UserId.ValueString(), password.ValueString(), plan.ForceChangeOnNextLogon.ValueBool())
+	if err != nil {
+		resp.Diagnostics.Append(userPasswordErrorDiagnostics("Error updating user password", err)...)
+		return
+	}
+
+	plan.Id = state.Id
+	plan.PasswordWo = types.StringNull()
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *userPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// a password cannot be removed from a user, hence the resource is only removed from the state
+}
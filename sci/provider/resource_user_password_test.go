@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceUserPassword(t *testing.T) {
+	t.Parallel()
+
+	mockUuid := "af2f7963-358d-4336-bc51-57099394dee7"
+
+	t.Run("error path - user_id must be a valid UUID", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceUserPassword("testPassword", "not-a-valid-uuid", "Test1234!", "2026-Q1", false),
+					ExpectError: regexp.MustCompile(`value must be a valid UUID, got: not-a-valid-uuid`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - password_wo must not be empty", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceUserPassword("testPassword", mockUuid, "", "2026-Q1", false),
+					ExpectError: regexp.MustCompile(`Attribute password_wo string length must be at least 1`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - password policy violation", func(t *testing.T) {
+
+		diags := userPasswordErrorDiagnostics("Error setting user password", cli.ScimResponseError{
+			Status: "400",
+			Detail: "Password must contain at least 8 characters",
+		})
+
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Password rejected by the password policy", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "Password must contain at least 8 characters")
+	})
+
+	t.Run("error path - other errors", func(t *testing.T) {
+
+		diags := userPasswordErrorDiagnostics("Error setting user password", fmt.Errorf("SCIM error 404 \nuser not found"))
+
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Error setting user password", diags[0].Summary())
+
+		// bad requests which do not refer to the password are passed through unchanged
+		diags = userPasswordErrorDiagnostics("Error setting user password", cli.ScimResponseError{
+			Status:   "400",
+			ScimType: "invalidSyntax",
+			Detail:   "Request body is not a valid patch request",
+		})
+
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Error setting user password", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "Request body is not a valid patch request")
+	})
+
+	t.Run("read - the resource is removed if the user has been deleted", func(t *testing.T) {
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":"404","detail":"user not found"}`))
+		}))
+		defer srv.Close()
+
+		r := &userPasswordResource{cli: client}
+
+		var schemaResp tfresource.SchemaResponse
+		r.Schema(context.TODO(), tfresource.SchemaRequest{}, &schemaResp)
+
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.TODO()), nil),
+		}
+		assert.False(t, state.SetAttribute(context.TODO(), path.Root("user_id"), mockUuid).HasError())
+
+		resp := tfresource.ReadResponse{State: state}
+		r.Read(context.TODO(), tfresource.ReadRequest{State: state}, &resp)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.True(t, resp.State.Raw.IsNull())
+	})
+}
+
+func ResourceUserPassword(resourceName string, userId string, password string, rotationTrigger string, forceChange bool) string {
+	return fmt.Sprintf(`
+	resource "sci_user_password" "%s" {
+		user_id                    = "%s"
+		password_wo                = "%s"
+		rotation_trigger           = "%s"
+		force_change_on_next_logon = %t
+	}
+	`, resourceName, userId, password, rotationTrigger, forceChange)
+}
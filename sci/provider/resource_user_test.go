@@ -1,17 +1,42 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
+// testPrivateState stores the private state of a resource in memory
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(p, key)
+	} else {
+		p[key] = value
+	}
+	return nil
+}
+
 func TestResourceUser(t *testing.T) {
 
 	sciUser := users.User{
@@ -366,6 +391,35 @@ func TestResourceUser(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - deletion mode must be a valid value", func(t *testing.T) {
+
+		deletionMode := "this-is-not-a-valid-deletion-mode"
+
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceUserWithDeletionMode("testUser", sciUser, deletionMode),
+					ExpectError: regexp.MustCompile(fmt.Sprintf("Attribute deletion_mode value must be one of: \\[\"delete\" \"deactivate\"\n\"retain\"\\], got: \"%s\"", deletionMode)),
+				},
+			},
+		})
+	})
+
+	t.Run("validation - deletion of a user which is a member of groups", func(t *testing.T) {
+
+		diags := validateUserDeletion(sciUser.UserName, nil)
+		assert.False(t, diags.HasError())
+
+		diags = validateUserDeletion(sciUser.UserName, []users.Group{
+			{Value: "7a2d9e4c-1f3b-4c8a-9e6d-2b5f8c1a3d7e", Display: "Admins"},
+			{Value: "3c8e1a5f-9d2b-4e7c-8a1f-6b3d9e2c5a8f"},
+		})
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags.Errors()[0].Detail(), "Admins, 3c8e1a5f-9d2b-4e7c-8a1f-6b3d9e2c5a8f")
+	})
+
 	t.Run("error path - custom schemas must be a valid json string", func(t *testing.T) {
 
 		sciUser.Schemas = []string{
@@ -388,6 +442,75 @@ func TestResourceUser(t *testing.T) {
 		})
 	})
 
+	t.Run("error path - user modified outside of Terraform", func(t *testing.T) {
+
+		diags := versionErrorDiagnostics("Error updating user", "user", cli.ScimResponseError{
+			Status: "412",
+			Detail: "version mismatch",
+		})
+
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Error updating user: user modified outside of Terraform", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "Refresh the state")
+
+		diags = versionErrorDiagnostics("Error updating user", "user", fmt.Errorf("SCIM error 400 \nupdate failed"))
+
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Error updating user", diags[0].Summary())
+	})
+
+	t.Run("delete - a user which has already been deleted is no error", func(t *testing.T) {
+
+		requests := []string{}
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method)
+
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":"404","detail":"user not found"}`))
+		}))
+		defer srv.Close()
+
+		r := &userResource{cli: client}
+
+		var schemaResp tfresource.SchemaResponse
+		r.Schema(context.TODO(), tfresource.SchemaRequest{}, &schemaResp)
+
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.TODO()), nil),
+		}
+		assert.False(t, state.SetAttribute(context.TODO(), path.Root("id"), "user-id").HasError())
+		assert.False(t, state.SetAttribute(context.TODO(), path.Root("deletion_mode"), "delete").HasError())
+		assert.False(t, state.SetAttribute(context.TODO(), path.Root("force_delete"), false).HasError())
+
+		var resp tfresource.DeleteResponse
+		r.Delete(context.TODO(), tfresource.DeleteRequest{State: state}, &resp)
+
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.Equal(t, []string{http.MethodGet}, requests)
+	})
+
+	t.Run("version private state", func(t *testing.T) {
+
+		private := testPrivateState{}
+
+		version, diags := getPrivateVersion(context.TODO(), private)
+		assert.False(t, diags.HasError())
+		assert.Empty(t, version)
+
+		diags = setPrivateVersion(context.TODO(), private, `W/"1"`)
+		assert.False(t, diags.HasError())
+
+		version, diags = getPrivateVersion(context.TODO(), private)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, `W/"1"`, version)
+
+		diags = setPrivateVersion(context.TODO(), private, "")
+		assert.False(t, diags.HasError())
+		assert.NotContains(t, private, privateVersionKey)
+	})
+
 }
 
 func ResourceUser(resourceName string, user users.User) string {
@@ -499,6 +622,20 @@ func ResourceUserWithStatus(resourceName string, user users.User) string {
 	`, resourceName, user.UserName, user.Name.FamilyName, user.Name.GivenName, getEmails(user.Emails), user.SAPExtension.Status)
 }
 
+func ResourceUserWithDeletionMode(resourceName string, user users.User, deletionMode string) string {
+	return fmt.Sprintf(`
+	resource "sci_user" "%s"{
+		user_name = "%s"
+		name = {
+			family_name = "%s"
+			given_name = "%s"
+		}
+		emails = [%s]
+		deletion_mode = "%s"
+	}
+	`, resourceName, user.UserName, user.Name.FamilyName, user.Name.GivenName, getEmails(user.Emails), deletionMode)
+}
+
 func ResourceUserWithCustomSchemas(resourceName string, user users.User, customSchemas string) string {
 
 	var schemas strings.Builder
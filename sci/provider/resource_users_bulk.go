@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newUsersBulkResource() resource.Resource {
+	return &usersBulkResource{}
+}
+
+type usersBulkResource struct {
+	cli *cli.SciClient
+}
+
+var _ resource.ResourceWithValidateConfig = &usersBulkResource{}
+
+func (r *usersBulkResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.cli = req.ProviderData.(*cli.SciClient)
+}
+
+func (r *usersBulkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users_bulk"
+}
+
+func (r *usersBulkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages a large number of users in the SAP Cloud Identity Services as a single resource.
+
+The configured users are compared against the users of the tenant, which are read with a single paginated list call. The required creations, updates and deletions are applied in batches with the SCIM bulk endpoint. If the tenant does not support the bulk endpoint, the changes are applied with individual calls instead.
+
+Failures of individual users do not abort the apply, they are reported as warnings and retried with the next apply. On updates, users which could not be created or adopted are left out of the state and reported as an error, so that they are planned again. When the resource is destroyed, users which could not be deleted are reported as errors and the resource is kept in the state.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "SCIM filter expression restricting the users of the tenant which are compared against the configured users, for example `userName sw \"ext_\"`. If not configured, all users of the tenant are read.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"batch_size": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of operations sent in a single bulk request. If the tenant advertises a lower limit, the operations are split further. The default value for the attribute is 100.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(100),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of parallel calls, if the tenant does not support the bulk endpoint. The default value for the attribute is 5.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(5),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 20),
+				},
+			},
+			"adopt_existing_users": schema.BoolAttribute{
+				MarkdownDescription: "Determines whether configured users which already exist in the tenant are managed by the resource. Adopted users are deleted along with the resource. If not enabled, users which already exist are skipped with a warning. The default value for the attribute is false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "The users to be managed. The list can for example be loaded from a CSV file with `csvdecode`. The user name must be unique across the list.",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_name": schema.StringAttribute{
+							MarkdownDescription: "Unique user name of the user.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Primary email of the user.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"given_name": schema.StringAttribute{
+							MarkdownDescription: "First name of the user.",
+							Optional:            true,
+						},
+						"family_name": schema.StringAttribute{
+							MarkdownDescription: "Last name of the user.",
+							Optional:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The name to be displayed for the user.",
+							Optional:            true,
+						},
+						"user_type": schema.StringAttribute{
+							MarkdownDescription: "Specifies the type of the user. " + utils.ValidValuesString(userTypeValues),
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(userTypeValues...),
+							},
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Determines whether the user is active or not.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"user_ids": schema.MapAttribute{
+				MarkdownDescription: "The IDs of the users managed by the resource, keyed by the user name.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *usersBulkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var config usersBulkData
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.Users.IsNull() || config.Users.IsUnknown() {
+		return
+	}
+
+	var configUsers []bulkUserData
+	diags = config.Users.ElementsAs(ctx, &configUsers, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userNames := make(map[string]int, len(configUsers))
+	for i, user := range configUsers {
+		if user.UserName.IsUnknown() || user.UserName.IsNull() {
+			continue
+		}
+
+		if first, found := userNames[user.UserName.ValueString()]; found {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("users").AtListIndex(i).AtName("user_name"),
+				"Duplicate user name",
+				fmt.Sprintf("The user name %s is already configured at index %d, the user name must be unique across the list.", user.UserName.ValueString(), first),
+			)
+			continue
+		}
+		userNames[user.UserName.ValueString()] = i
+	}
+}
+
+func (r *usersBulkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var plan usersBulkData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(rand.Text())
+
+	// the planned users are stored as is on creation, as a resource created with errors would be replaced,
+	// which deletes the users created, users which could not be created are left out by the next refresh instead
+	diags = r.apply(ctx, plan, map[string]string{}, false, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *usersBulkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var config usersBulkData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, _, err := r.cli.User.List(ctx, config.Filter.ValueString(), usersBulkPageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving users", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state, diags := usersBulkValueFrom(ctx, config, res.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *usersBulkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var plan usersBulkData
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state usersBulkData
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managedIds := map[string]string{}
+	diags = state.UserIds.ElementsAs(ctx, &managedIds, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+
+	diags = r.apply(ctx, plan, managedIds, true, &resp.State)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *usersBulkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	var config usersBulkData
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managedIds := map[string]string{}
+	diags = config.UserIds.ElementsAs(ctx, &managedIds, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	operations := make([]usersBulkOperation, 0, len(managedIds))
+	for userName, userId := range managedIds {
+		operations = append(operations, usersBulkOperation{
+			method:   http.MethodDelete,
+			userName: userName,
+			userId:   userId,
+		})
+	}
+
+	results := applyUsersBulkOperations(ctx, r.cli, operations, int(config.BatchSize.ValueInt64()), int(config.MaxConcurrency.ValueInt64()))
+
+	resp.Diagnostics.Append(usersBulkDeletionErrors(results)...)
+}
+
+// apply aligns the users of the tenant with the planned users and sets the resulting state
+// users which could not be created or adopted are left out of the stored users if dropPending is set, so that they are planned again
+// as Terraform only accepts a state which differs from the plan along with an error, the pending users are reported as an error
+func (r *usersBulkResource) apply(ctx context.Context, plan usersBulkData, managedIds map[string]string, dropPending bool, state *tfsdk.State) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	var planUsers []bulkUserData
+	diags = plan.Users.ElementsAs(ctx, &planUsers, true)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, _, err := r.cli.User.List(ctx, plan.Filter.ValueString(), usersBulkPageSize)
+	if err != nil {
+		diags.AddError("Error retrieving users", fmt.Sprintf("%s", err))
+		return diags
+	}
+
+	operations, diags := getUsersBulkOperations(planUsers, managedIds, res.Resources, plan.AdoptExisting.ValueBool())
+	if diags.HasError() {
+		return diags
+	}
+
+	results := applyUsersBulkOperations(ctx, r.cli, operations, int(plan.BatchSize.ValueInt64()), int(plan.MaxConcurrency.ValueInt64()))
+
+	managedIds, resultDiags := usersBulkResultsValue(managedIds, results)
+	diags.Append(resultDiags...)
+
+	userIds, mapDiags := types.MapValueFrom(ctx, types.StringType, managedIds)
+	diags.Append(mapDiags...)
+	plan.UserIds = userIds
+
+	appliedUsers, pending := usersBulkAppliedUsers(planUsers, managedIds)
+	if dropPending && len(pending) > 0 {
+		users, listDiags := types.ListValueFrom(ctx, bulkUserObjType, appliedUsers)
+		diags.Append(listDiags...)
+		plan.Users = users
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(state.Set(ctx, &plan)...)
+
+	if dropPending && len(pending) > 0 {
+		diags.AddError(
+			"Users not applied",
+			fmt.Sprintf("The users %s could not be created or adopted, see the warnings for the reasons. "+
+				"All other changes have been applied, the users are planned again with the next apply.", strings.Join(pending, ", ")),
+		)
+	}
+
+	return diags
+}
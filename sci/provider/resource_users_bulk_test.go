@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/bulk"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceUsersBulk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error path - users cannot be empty", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceUsersBulk("testUsers", []string{}),
+					ExpectError: regexp.MustCompile(`Attribute users list must contain at least 1 elements, got: 0`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - user names must be unique", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceUsersBulk("testUsers", []string{"jdoe", "jsmith", "jdoe"}),
+					ExpectError: regexp.MustCompile(`Duplicate user name`),
+				},
+			},
+		})
+	})
+
+	t.Run("error path - batch size must be within the limits", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest:               true,
+			ProtoV6ProviderFactories: getTestProviders(nil),
+			Steps: []resource.TestStep{
+				{
+					Config:      ResourceUsersBulkWithBatchSize("testUsers", []string{"jdoe"}, 0),
+					ExpectError: regexp.MustCompile(`Attribute batch_size value must be between 1 and 1000, got: 0`),
+				},
+			},
+		})
+	})
+
+	t.Run("diff - users are created, updated and deleted", func(t *testing.T) {
+
+		plan := []bulkUserData{
+			bulkUser("new_user", "new@test.com"),
+			bulkUser("existing_user", "changed@test.com"),
+			bulkUser("unchanged_user", "unchanged@test.com"),
+		}
+
+		tenantUsers := []users.User{
+			{Id: "existing-id", UserName: "existing_user", Emails: []users.Email{{Value: "existing@test.com", Primary: true}}},
+			{Id: "unchanged-id", UserName: "unchanged_user", Emails: []users.Email{{Value: "unchanged@test.com", Primary: true}}},
+			{Id: "removed-id", UserName: "removed_user", Emails: []users.Email{{Value: "removed@test.com", Primary: true}}},
+			{Id: "unmanaged-id", UserName: "unmanaged_user", Emails: []users.Email{{Value: "unmanaged@test.com", Primary: true}}},
+		}
+
+		managedIds := map[string]string{
+			"existing_user":  "existing-id",
+			"unchanged_user": "unchanged-id",
+			"removed_user":   "removed-id",
+		}
+
+		operations, diags := getUsersBulkOperations(plan, managedIds, tenantUsers, false)
+
+		assert.False(t, diags.HasError())
+		assert.Len(t, operations, 4)
+
+		assert.Equal(t, http.MethodPost, operations[0].method)
+		assert.Equal(t, "new_user", operations[0].user.UserName)
+
+		assert.Equal(t, http.MethodPatch, operations[1].method)
+		assert.Equal(t, "existing-id", operations[1].userId)
+		assert.Len(t, operations[1].patch, 1)
+		assert.Equal(t, "emails", operations[1].patch[0].Path)
+
+		assert.Equal(t, http.MethodPatch, operations[2].method)
+		assert.Empty(t, operations[2].patch)
+
+		assert.Equal(t, http.MethodDelete, operations[3].method)
+		assert.Equal(t, "removed-id", operations[3].userId)
+	})
+
+	t.Run("diff - existing users are only adopted if enabled", func(t *testing.T) {
+
+		plan := []bulkUserData{bulkUser("existing_user", "existing@test.com")}
+		tenantUsers := []users.User{
+			{Id: "existing-id", UserName: "existing_user", Emails: []users.Email{{Value: "existing@test.com", Primary: true}}},
+		}
+
+		// the existing user is skipped with a warning
+		operations, diags := getUsersBulkOperations(plan, map[string]string{}, tenantUsers, false)
+		assert.False(t, diags.HasError())
+		assert.Empty(t, operations)
+		assert.Equal(t, "User already exists", diags.Warnings()[0].Summary())
+
+		operations, diags = getUsersBulkOperations(plan, map[string]string{}, tenantUsers, true)
+		assert.False(t, diags.HasError())
+		assert.Len(t, operations, 1)
+		assert.Equal(t, "existing-id", operations[0].userId)
+	})
+
+	t.Run("apply - bulk endpoint with per-user failures", func(t *testing.T) {
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/scim/ServiceProviderConfig":
+				_, _ = w.Write([]byte(`{"bulk":{"supported":true,"maxOperations":2}}`))
+
+			case "/scim/Bulk":
+				var req bulk.BulkRequest
+				_ = json.NewDecoder(r.Body).Decode(&req)
+
+				res := bulk.BulkResponse{}
+				for _, operation := range req.Operations {
+					switch operation.Method {
+					case http.MethodPost:
+						res.Operations = append(res.Operations, bulk.OperationResponse{
+							Method:   operation.Method,
+							BulkId:   operation.BulkId,
+							Status:   "201",
+							Location: "https://example.com/scim/Users/" + operation.BulkId + "-id",
+						})
+					default:
+						res.Operations = append(res.Operations, bulk.OperationResponse{
+							Method:   operation.Method,
+							BulkId:   operation.BulkId,
+							Status:   "409",
+							Response: map[string]any{"status": "409", "detail": "user is locked"},
+						})
+					}
+				}
+				_ = json.NewEncoder(w).Encode(res)
+			}
+		}))
+		defer srv.Close()
+
+		operations := []usersBulkOperation{
+			{method: http.MethodPost, userName: "new_user", user: &users.User{UserName: "new_user"}},
+			{method: http.MethodPatch, userName: "unchanged_user", userId: "unchanged-id"},
+			{method: http.MethodPatch, userName: "existing_user", userId: "existing-id", patch: []generic.PatchRequest{{Op: "replace", Path: "displayName", Value: "User"}}},
+			{method: http.MethodDelete, userName: "removed_user", userId: "removed-id"},
+		}
+
+		results := applyUsersBulkOperations(context.TODO(), client, operations, 100, 2)
+
+		managedIds, diags := usersBulkResultsValue(map[string]string{"existing_user": "existing-id", "removed_user": "removed-id"}, results)
+
+		assert.Equal(t, map[string]string{
+			"new_user":       "operation-0-id",
+			"unchanged_user": "unchanged-id",
+			"existing_user":  "existing-id",
+			"removed_user":   "removed-id",
+		}, managedIds)
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 2, diags.WarningsCount())
+		// on destroy, the users which could not be deleted are errors
+		diags = usersBulkDeletionErrors(applyUsersBulkOperations(context.TODO(), client, operations[3:], 100, 2))
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, "Error deleting user removed_user", diags.Errors()[0].Summary())
+	})
+
+	t.Run("apply - fallback to individual calls with per-user failures", func(t *testing.T) {
+
+		var mu sync.Mutex
+		calls := map[string]int{}
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls[r.Method+" "+r.URL.Path]++
+			mu.Unlock()
+
+			switch {
+			case r.URL.Path == "/scim/ServiceProviderConfig":
+				_, _ = w.Write([]byte(`{"bulk":{"supported":false}}`))
+
+			case r.Method == http.MethodPost:
+				var user users.User
+				_ = json.NewDecoder(r.Body).Decode(&user)
+
+				if user.UserName == "invalid_user" {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"status":"400","detail":"invalid email"}`))
+					return
+				}
+
+				user.Id = user.UserName + "-id"
+				_ = json.NewEncoder(w).Encode(user)
+
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"status":"404","detail":"user not found"}`))
+			}
+		}))
+		defer srv.Close()
+
+		operations := []usersBulkOperation{
+			{method: http.MethodPost, userName: "new_user", user: &users.User{UserName: "new_user"}},
+			{method: http.MethodPost, userName: "invalid_user", user: &users.User{UserName: "invalid_user"}},
+			{method: http.MethodDelete, userName: "removed_user", userId: "removed-id"},
+		}
+
+		results := applyUsersBulkOperations(context.TODO(), client, operations, 100, 2)
+
+		managedIds, diags := usersBulkResultsValue(map[string]string{"removed_user": "removed-id"}, results)
+
+		assert.Equal(t, 1, calls["GET /scim/ServiceProviderConfig"])
+		assert.Equal(t, 0, calls["POST /scim/Bulk"])
+		assert.Equal(t, 2, calls["POST /scim/Users/"])
+		assert.Equal(t, map[string]string{"new_user": "new_user-id"}, managedIds)
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "Error applying changes to user invalid_user", diags.Warnings()[0].Summary())
+		// users which have already been deleted are no errors on destroy
+		results = applyUsersBulkOperations(context.TODO(), client, operations[2:], 100, 2)
+		assert.False(t, usersBulkDeletionErrors(results).HasError())
+	})
+
+	t.Run("apply - users which could not be created or adopted are left out of the state", func(t *testing.T) {
+
+		client, srv := usersBulkTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/scim/ServiceProviderConfig":
+				_, _ = w.Write([]byte(`{"bulk":{"supported":false}}`))
+
+			case r.Method == http.MethodGet:
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"totalResults": 2,
+					"Resources": []users.User{
+						{Id: "existing-id", UserName: "existing_user", Emails: []users.Email{{Value: "existing@test.com", Primary: true}}},
+						{Id: "managed-id", UserName: "managed_user", Emails: []users.Email{{Value: "managed@test.com", Primary: true}}},
+					},
+				})
+
+			case r.Method == http.MethodPost:
+				var user users.User
+				_ = json.NewDecoder(r.Body).Decode(&user)
+
+				if user.UserName == "invalid_user" {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"status":"400","detail":"invalid email"}`))
+					return
+				}
+
+				user.Id = user.UserName + "-id"
+				_ = json.NewEncoder(w).Encode(user)
+			}
+		}))
+		defer srv.Close()
+
+		r := &usersBulkResource{cli: client}
+
+		var schemaResp tfresource.SchemaResponse
+		r.Schema(context.TODO(), tfresource.SchemaRequest{}, &schemaResp)
+
+		planUsers, _ := types.ListValueFrom(context.TODO(), bulkUserObjType, []bulkUserData{
+			bulkUser("new_user", "new@test.com"),
+			bulkUser("invalid_user", "invalid@test.com"),
+			bulkUser("existing_user", "existing@test.com"),
+			bulkUser("managed_user", "managed@test.com"),
+		})
+
+		plan := usersBulkData{
+			Id:             types.StringValue("id"),
+			Filter:         types.StringNull(),
+			BatchSize:      types.Int64Value(100),
+			MaxConcurrency: types.Int64Value(2),
+			AdoptExisting:  types.BoolValue(false),
+			Users:          planUsers,
+			UserIds:        types.MapUnknown(types.StringType),
+		}
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		diags := r.apply(context.TODO(), plan, map[string]string{"managed_user": "managed-id"}, true, &state)
+
+		assert.Equal(t, 2, diags.WarningsCount())
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, "Users not applied", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "invalid_user, existing_user")
+
+		var updatedState usersBulkData
+		assert.False(t, state.Get(context.TODO(), &updatedState).HasError())
+
+		var stateUsers []bulkUserData
+		updatedState.Users.ElementsAs(context.TODO(), &stateUsers, true)
+		assert.Equal(t, []bulkUserData{bulkUser("new_user", "new@test.com"), bulkUser("managed_user", "managed@test.com")}, stateUsers)
+
+		stateIds := map[string]string{}
+		updatedState.UserIds.ElementsAs(context.TODO(), &stateIds, true)
+		assert.Equal(t, map[string]string{"new_user": "new_user-id", "managed_user": "managed-id"}, stateIds)
+	})
+}
+
+func bulkUser(userName string, email string) bulkUserData {
+	return bulkUserData{
+		UserName:    types.StringValue(userName),
+		Email:       types.StringValue(email),
+		GivenName:   types.StringNull(),
+		FamilyName:  types.StringNull(),
+		DisplayName: types.StringNull(),
+		UserType:    types.StringNull(),
+		Active:      types.BoolNull(),
+	}
+}
+
+func usersBulkTestClient(handler http.HandlerFunc) (*cli.SciClient, *httptest.Server) {
+	srv := httptest.NewServer(handler)
+	srvUrl, _ := url.Parse(srv.URL)
+
+	return cli.NewSciClient(cli.NewClient(srv.Client(), srvUrl)), srv
+}
+
+func ResourceUsersBulk(resourceName string, userNames []string) string {
+	return fmt.Sprintf(`
+	resource "sci_users_bulk" "%s" {
+		users = [%s]
+	}
+	`, resourceName, getBulkUsers(userNames))
+}
+
+func ResourceUsersBulkWithBatchSize(resourceName string, userNames []string, batchSize int) string {
+	return fmt.Sprintf(`
+	resource "sci_users_bulk" "%s" {
+		batch_size = %d
+		users = [%s]
+	}
+	`, resourceName, batchSize, getBulkUsers(userNames))
+}
+
+func getBulkUsers(userNames []string) string {
+
+	var bulkUsers strings.Builder
+	for _, userName := range userNames {
+		fmt.Fprintf(&bulkUsers, `
+			{
+				user_name = "%s"
+				email = "%s@test.com"
+			},
+		`, userName, userName)
+	}
+
+	return bulkUsers.String()
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"reflect"
 	"regexp"
 
@@ -34,6 +35,8 @@ type authenticationSchemaData struct {
 
 type AppSaml2ConfigData struct {
 	SamlMetadataUrl           types.String `tfsdk:"saml_metadata_url" json:"samlMetadataUrl"`
+	MetadataXml               types.String `tfsdk:"metadata_xml" json:"-"`
+	MetadataSigningCert       types.String `tfsdk:"metadata_signing_certificate" json:"-"`
 	AcsEndpoints              types.List   `tfsdk:"acs_endpoints" json:"acsEndpoints"`
 	SloEndpoints              types.List   `tfsdk:"slo_endpoints" json:"sloEndpoints"`
 	CertificatesForSigning    types.List   `tfsdk:"signing_certificates" json:"certificatesForSigning"`
@@ -46,6 +49,7 @@ type AppSaml2ConfigData struct {
 	SignAssertions            types.Bool   `tfsdk:"sign_assertions" json:"signAssertions"`
 	SignAuthnResponses        types.Bool   `tfsdk:"sign_auth_responses" json:"signAuthnResponses"`
 	DigestAlgorithm           types.String `tfsdk:"digest_algorithm" json:"digestAlgorithm"`
+	ProxyAuthnRequest         types.Object `tfsdk:"proxy_authn_request" json:"proxyAuthnRequest"`
 }
 
 type AcsSsoEndpointData struct {
@@ -106,6 +110,11 @@ type tokenPolicyData struct {
 	AccessTokenFormat            types.String `tfsdk:"access_token_format" json:"accessTokenFormat"`
 }
 
+type proxyAuthnRequestData struct {
+	AuthnContext     types.String `tfsdk:"authn_context"`
+	IssuerNameSuffix types.String `tfsdk:"issuer_name_suffix"`
+}
+
 type proxyConfigData struct {
 	Acrs types.Set `tfsdk:"acrs"`
 }
@@ -145,9 +154,54 @@ type applicationData struct {
 	ParentApplicationId  types.String `tfsdk:"parent_application_id" json:"parentApplicationId"`
 	MultiTenantApp       types.Bool   `tfsdk:"multi_tenant_app" json:"multiTenantApp"`
 	AuthenticationSchema types.Object `tfsdk:"authentication_schema" json:"urn:sap:identity:application:schemas:extension:sci:1.0:Authentication"`
+	OverrideInherited    types.Set    `tfsdk:"override_inherited"`
+	OidcClient           types.Object `tfsdk:"oidc_client"`
+	Saml2Idp             types.Object `tfsdk:"saml2_idp"`
+	ApiCertificates      types.List   `tfsdk:"api_certificates"`
 	Meta                 types.Object `tfsdk:"meta"`
 }
 
+// applicationApiCertificateData is a certificate the application authenticates with at the APIs of the tenant
+// the certificates are managed in the tenant, the provider only reads them to expose their details and to warn about their expiry
+type applicationApiCertificateData struct {
+	Id                string `tfsdk:"id"`
+	Dn                string `tfsdk:"dn"`
+	Description       string `tfsdk:"description"`
+	Base64Certificate string `tfsdk:"base64_certificate"`
+	corporateidps.CertificateDetails
+}
+
+var applicationApiCertificateObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                 types.StringType,
+		"dn":                 types.StringType,
+		"description":        types.StringType,
+		"base64_certificate": types.StringType,
+		"subject_dn":         types.StringType,
+		"sha1_fingerprint":   types.StringType,
+		"sha256_fingerprint": types.StringType,
+		"not_before":         types.StringType,
+		"not_after":          types.StringType,
+	},
+}
+
+// applicationApiCertificatesValueFrom returns the API certificates of the application with the details read from the certificates
+func applicationApiCertificatesValueFrom(ctx context.Context, certificates []applications.ApiCertificateData) (types.List, diag.Diagnostics) {
+
+	apiCertificates := []applicationApiCertificateData{}
+	for _, certificate := range certificates {
+		apiCertificates = append(apiCertificates, applicationApiCertificateData{
+			Id:                 certificate.Id,
+			Dn:                 certificate.Dn,
+			Description:        certificate.Description,
+			Base64Certificate:  certificate.Base64Certificate,
+			CertificateDetails: utils.CertificateDetailsOf(certificate.Base64Certificate),
+		})
+	}
+
+	return listValueOrNull(ctx, applicationApiCertificateObjType, apiCertificates)
+}
+
 func applicationValueFrom(ctx context.Context, a applications.Application) (applicationData, diag.Diagnostics) {
 
 	var diagnostics, diags diag.Diagnostics
@@ -157,9 +211,12 @@ func applicationValueFrom(ctx context.Context, a applications.Application) (appl
 
 	// Id, Name, Multi Tenant App and Global Account
 	application := applicationData{
-		Id:             types.StringValue(a.Id),
-		Name:           types.StringValue(a.Name),
-		MultiTenantApp: types.BoolValue(a.MultiTenantApp),
+		Id:              types.StringValue(a.Id),
+		Name:            types.StringValue(a.Name),
+		MultiTenantApp:  types.BoolValue(a.MultiTenantApp),
+		OidcClient:      types.ObjectNull(applicationOidcClientObjType),
+		Saml2Idp:        types.ObjectNull(applicationSaml2IdpObjType),
+		ApiCertificates: types.ListNull(applicationApiCertificateObjType),
 	}
 
 	// DisplayName from branding
@@ -278,47 +335,10 @@ func applicationValueFrom(ctx context.Context, a applications.Application) (appl
 	}
 
 	// Authentication Schema Conditional Authentication
-	// the mapping is done manually in order to handle the null values
-	if len(a.AuthenticationSchema.ConditionalAuthentication) > 0 {
-
-		authRules := []authenticationRulesData{}
-
-		for _, rule := range a.AuthenticationSchema.ConditionalAuthentication {
-
-			authRule := authenticationRulesData{}
-
-			if len(rule.UserType) > 0 {
-				authRule.UserType = types.StringValue(rule.UserType)
-			}
-
-			if len(rule.UserGroup) > 0 {
-				authRule.UserGroup = types.StringValue(rule.UserGroup)
-			}
-
-			if len(rule.UserEmailDomain) > 0 {
-				authRule.UserEmailDomain = types.StringValue(rule.UserEmailDomain)
-			}
-
-			if len(rule.IdentityProviderId) > 0 {
-				authRule.IdentityProviderId = types.StringValue(rule.IdentityProviderId)
-			}
-
-			if len(rule.IpNetworkRange) > 0 {
-				authRule.IpNetworkRange = types.StringValue(rule.IpNetworkRange)
-			}
-
-			authRules = append(authRules, authRule)
-		}
-
-		authRulesData, diags := types.ListValueFrom(ctx, authenticationRulesObjType, authRules)
-		diagnostics.Append(diags...)
-		if diagnostics.HasError() {
-			return application, diagnostics
-		}
-
-		authenticationSchema.AuthenticationRules = authRulesData
-	} else {
-		authenticationSchema.AuthenticationRules = types.ListNull(authenticationRulesObjType)
+	authenticationSchema.AuthenticationRules, diags = authenticationRulesValueFrom(ctx, a.AuthenticationSchema.ConditionalAuthentication)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return application, diagnostics
 	}
 
 	// Authentication Schema Rest API Authentication
@@ -467,6 +487,10 @@ func applicationValueFrom(ctx context.Context, a applications.Application) (appl
 
 		// SAML2 Signing Certificates
 		if len(saml2Res.CertificatesForSigning) > 0 {
+			for i, certificate := range saml2Res.CertificatesForSigning {
+				saml2Res.CertificatesForSigning[i].CertificateDetails = utils.CertificateDetailsOf(certificate.Base64Certificate)
+			}
+
 			certificates, diags := types.ListValueFrom(ctx, saml2SigningCertificateObjType, saml2Res.CertificatesForSigning)
 			diagnostics.Append(diags...)
 
@@ -481,6 +505,8 @@ func applicationValueFrom(ctx context.Context, a applications.Application) (appl
 
 		//SAML2 Encryption Certificate
 		if saml2Res.CertificateForEncryption != nil {
+			saml2Res.CertificateForEncryption.CertificateDetails = utils.CertificateDetailsOf(saml2Res.CertificateForEncryption.Base64Certificate)
+
 			encryptionCertificate, diags := types.ObjectValueFrom(ctx, saml2EncryptionCertificateObjType.AttrTypes, saml2Res.CertificateForEncryption)
 			diagnostics.Append(diags...)
 
@@ -497,6 +523,28 @@ func applicationValueFrom(ctx context.Context, a applications.Application) (appl
 			return application, diagnostics
 		}
 
+		// SAML2 Proxy AuthnRequest
+		if saml2Res.ProxyAuthnRequest != nil {
+			proxyAuthnRequest := proxyAuthnRequestData{}
+
+			if len(saml2Res.ProxyAuthnRequest.AuthenticationContext) > 0 {
+				proxyAuthnRequest.AuthnContext = types.StringValue(saml2Res.ProxyAuthnRequest.AuthenticationContext)
+			}
+
+			if len(saml2Res.ProxyAuthnRequest.IssuerNameSuffix) > 0 {
+				proxyAuthnRequest.IssuerNameSuffix = types.StringValue(saml2Res.ProxyAuthnRequest.IssuerNameSuffix)
+			}
+
+			saml2Config.ProxyAuthnRequest, diags = types.ObjectValueFrom(ctx, proxyAuthnRequestObjType, proxyAuthnRequest)
+			diagnostics.Append(diags...)
+
+			if diagnostics.HasError() {
+				return application, diagnostics
+			}
+		} else {
+			saml2Config.ProxyAuthnRequest = types.ObjectNull(proxyAuthnRequestObjType)
+		}
+
 		authenticationSchema.Saml2Configuration, diags = types.ObjectValueFrom(ctx, appSaml2ConfigObjType.AttrTypes, saml2Config)
 		diagnostics.Append(diags...)
 
@@ -551,6 +599,13 @@ func applicationValueFrom(ctx context.Context, a applications.Application) (appl
 	application.AuthenticationSchema, diags = types.ObjectValueFrom(ctx, authenticationSchemaObjType, authenticationSchema)
 	diagnostics.Append(diags...)
 
+	// Properties whose inherited values are disabled
+	application.OverrideInherited, diags = setValueOrNull(ctx, overriddenInheritedProperties(a.AuthenticationSchema.DisabledInheritedProperties))
+	diagnostics.Append(diags...)
+
+	application.ApiCertificates, diags = applicationApiCertificatesValueFrom(ctx, a.AuthenticationSchema.ApiCertificates)
+	diagnostics.Append(diags...)
+
 	if a.Meta != nil {
 		meta := metaData{
 			Type: types.StringValue(a.Meta.Type),
@@ -570,12 +625,13 @@ func applicationValueFrom(ctx context.Context, a applications.Application) (appl
 	return application, diagnostics
 }
 
-func applicationsValueFrom(ctx context.Context, a applications.ApplicationsResponse) []applicationData {
+func applicationsValueFrom(ctx context.Context, tenantUrl *url.URL, a applications.ApplicationsResponse) []applicationData {
 	apps := []applicationData{}
 
 	for _, appRes := range a.Applications {
 
 		app, _ := applicationValueFrom(ctx, appRes)
+		app.OidcClient, app.Saml2Idp, _ = applicationEndpointsValueFrom(ctx, tenantUrl, appRes.AuthenticationSchema.SsoType, applicationClientId(appRes))
 		apps = append(apps, app)
 
 	}
@@ -1495,6 +1551,26 @@ func getApplicationUpdateRequest(ctx context.Context, plan applicationData, stat
 				}
 				reqs = append(reqs, patchReq)
 			}
+
+			if !planSaml2Schema.ProxyAuthnRequest.Equal(stateSaml2Schema.ProxyAuthnRequest) {
+				val := applications.ProxyAuthnRequest{}
+
+				if !planSaml2Schema.ProxyAuthnRequest.IsNull() {
+					diags = planSaml2Schema.ProxyAuthnRequest.As(ctx, &val, basetypes.ObjectAsOptions{
+						UnhandledNullAsEmpty:    true,
+						UnhandledUnknownAsEmpty: true,
+					})
+					if diags.HasError() {
+						return reqs, diags
+					}
+				}
+
+				patchReq, diags := utils.GetPatchRequest("ProxyAuthnRequest", samlPath, val, argsType)
+				if diags.HasError() {
+					return reqs, diags
+				}
+				reqs = append(reqs, patchReq)
+			}
 		}
 	}
 
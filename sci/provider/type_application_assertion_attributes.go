@@ -0,0 +1,536 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/schemas"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type applicationAssertionAttributesData struct {
+	Id                                           types.String `tfsdk:"id"`
+	ApplicationId                                types.String `tfsdk:"application_id"`
+	AssertionAttributes                          types.List   `tfsdk:"assertion_attributes"`
+	AdvancedAssertionAttributes                  types.List   `tfsdk:"advanced_assertion_attributes"`
+	DisabledInheritedAssertionAttributes         types.Set    `tfsdk:"disabled_inherited_assertion_attributes"`
+	DisabledInheritedAdvancedAssertionAttributes types.Set    `tfsdk:"disabled_inherited_advanced_assertion_attributes"`
+	InheritedAssertionAttributes                 types.List   `tfsdk:"inherited_assertion_attributes"`
+	InheritedAdvancedAssertionAttributes         types.List   `tfsdk:"inherited_advanced_assertion_attributes"`
+}
+
+type assertionAttributeMappingData struct {
+	AttributeName  types.String `tfsdk:"attribute_name"`
+	AttributeValue types.String `tfsdk:"attribute_value"`
+}
+
+type advancedAssertionAttributeMappingData struct {
+	Source         types.String `tfsdk:"source"`
+	AttributeName  types.String `tfsdk:"attribute_name"`
+	AttributeValue types.String `tfsdk:"attribute_value"`
+}
+
+var assertionAttributeMappingObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"attribute_name":  types.StringType,
+		"attribute_value": types.StringType,
+	},
+}
+
+var advancedAssertionAttributeMappingObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"source":          types.StringType,
+		"attribute_name":  types.StringType,
+		"attribute_value": types.StringType,
+	},
+}
+
+var (
+	// regex for attribute values whose sources are the corporate IDP
+	corporateIdPAttributeValue = regexp.MustCompile(`^\$\{corporateIdP\.([^\}]+)\}$`)
+
+	// the scopes which can be referenced in the expressions of advanced assertion attributes
+	expressionScopes = []string{"user", "corporateIdP"}
+
+	// the user attributes of the Identity Directory which are not defined by the SCIM schemas of the tenant
+	identityDirectoryUserAttributes = []string{
+		"userUuid", "uid", "mail", "firstName", "lastName", "middleName", "displayName", "loginName", "personnelNumber",
+		"groups", "personGroups", "companyGroups", "telephone", "company", "companyRelationship", "country", "city", "zip",
+		"street", "state", "department", "costCenter", "division", "employeeNumber", "salutation", "title", "language",
+		"locale", "timeZone", "type", "status", "validFrom", "validTo",
+	}
+)
+
+// expressionReference is a reference to an attribute of a scope, written as ${<scope>.<attribute>} in an expression
+type expressionReference struct {
+	Scope     string
+	Attribute string
+}
+
+func applicationAssertionAttributesValueFrom(ctx context.Context, a applications.Application) (applicationAssertionAttributesData, diag.Diagnostics) {
+
+	var diagnostics, diags diag.Diagnostics
+
+	data := applicationAssertionAttributesData{
+		Id:            types.StringValue(a.Id),
+		ApplicationId: types.StringValue(a.Id),
+	}
+
+	authSchema := applications.AuthenticationSchema{}
+	if a.AuthenticationSchema != nil {
+		authSchema = *a.AuthenticationSchema
+	}
+
+	// the attributes inherited from the parent application are kept apart from the attributes of the application
+	// so that they are neither removed from nor added to the configuration of the application
+	var attributes, inheritedAttributes []assertionAttributeMappingData
+	for _, attribute := range authSchema.AssertionAttributes {
+
+		attributeData := assertionAttributeMappingData{
+			AttributeName:  types.StringValue(attribute.AssertionAttributeName),
+			AttributeValue: types.StringValue(attribute.UserAttributeName),
+		}
+
+		if attribute.Inherited {
+			inheritedAttributes = append(inheritedAttributes, attributeData)
+		} else {
+			attributes = append(attributes, attributeData)
+		}
+	}
+
+	var advancedAttributes, inheritedAdvancedAttributes []advancedAssertionAttributeMappingData
+	for _, attribute := range authSchema.AdvancedAssertionAttributes {
+
+		attributeData := advancedAssertionAttributeMappingValueFrom(attribute)
+
+		if attribute.Inherited {
+			inheritedAdvancedAttributes = append(inheritedAdvancedAttributes, attributeData)
+		} else {
+			advancedAttributes = append(advancedAttributes, attributeData)
+		}
+	}
+
+	data.AssertionAttributes, diags = listValueOrNull(ctx, assertionAttributeMappingObjType, attributes)
+	diagnostics.Append(diags...)
+
+	data.InheritedAssertionAttributes, diags = listValueOrNull(ctx, assertionAttributeMappingObjType, inheritedAttributes)
+	diagnostics.Append(diags...)
+
+	data.AdvancedAssertionAttributes, diags = listValueOrNull(ctx, advancedAssertionAttributeMappingObjType, advancedAttributes)
+	diagnostics.Append(diags...)
+
+	data.InheritedAdvancedAssertionAttributes, diags = listValueOrNull(ctx, advancedAssertionAttributeMappingObjType, inheritedAdvancedAttributes)
+	diagnostics.Append(diags...)
+
+	disabledAttributes, disabledAdvancedAttributes := disabledInheritedAttributeNames(authSchema.DisabledInheritedProperties)
+
+	data.DisabledInheritedAssertionAttributes, diags = setValueOrNull(ctx, disabledAttributes)
+	diagnostics.Append(diags...)
+
+	data.DisabledInheritedAdvancedAssertionAttributes, diags = setValueOrNull(ctx, disabledAdvancedAttributes)
+	diagnostics.Append(diags...)
+
+	return data, diagnostics
+}
+
+// advancedAssertionAttributeMappingValueFrom derives the source of an advanced assertion attribute from its value
+// the values of attributes from the corporate IDP are sent as ${corporateIdP.<attribute>}, all other values are expressions
+func advancedAssertionAttributeMappingValueFrom(attribute applications.AdvancedAssertionAttribute) advancedAssertionAttributeMappingData {
+
+	attributeData := advancedAssertionAttributeMappingData{
+		AttributeName: types.StringValue(attribute.AttributeName),
+	}
+
+	if match := corporateIdPAttributeValue.FindStringSubmatch(attribute.AttributeValue); match != nil {
+		attributeData.Source = types.StringValue(sourceValues[1])
+		attributeData.AttributeValue = types.StringValue(match[1])
+	} else {
+		attributeData.Source = types.StringValue(sourceValues[2])
+		attributeData.AttributeValue = types.StringValue(attribute.AttributeValue)
+	}
+
+	return attributeData
+}
+
+func listValueOrNull[T any](ctx context.Context, elemType types.ObjectType, values []T) (types.List, diag.Diagnostics) {
+
+	if len(values) == 0 {
+		return types.ListNull(elemType), nil
+	}
+
+	return types.ListValueFrom(ctx, elemType, values)
+}
+
+func setValueOrNull(ctx context.Context, values []string) (types.Set, diag.Diagnostics) {
+
+	if len(values) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, values)
+}
+
+// disabledInheritedAttributeNames returns the sorted names of the disabled inherited assertion attributes and advanced assertion attributes
+func disabledInheritedAttributeNames(properties *applications.DisabledInheritedProperties) ([]string, []string) {
+
+	if properties == nil {
+		return nil, nil
+	}
+
+	var attributes, advancedAttributes []string
+
+	for _, attribute := range properties.AssertionAttributes {
+		attributes = append(attributes, attribute.AssertionAttributeName)
+	}
+
+	for _, attribute := range properties.AdvancedAssertionAttributes {
+		advancedAttributes = append(advancedAttributes, attribute.AttributeName)
+	}
+
+	slices.Sort(attributes)
+	slices.Sort(advancedAttributes)
+
+	return attributes, advancedAttributes
+}
+
+// getAssertionAttributesArgs maps the planned attributes to the authentication schema of the application
+// only the attributes of the application itself are sent, the inherited attributes are maintained by the parent application
+func getAssertionAttributesArgs(ctx context.Context, plan applicationAssertionAttributesData) (applications.AuthenticationSchema, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+
+	args := applications.AuthenticationSchema{
+		AssertionAttributes:         []applications.AssertionAttribute{},
+		AdvancedAssertionAttributes: []applications.AdvancedAssertionAttribute{},
+	}
+
+	if !plan.AssertionAttributes.IsNull() {
+
+		var attributes []assertionAttributeMappingData
+		diagnostics.Append(plan.AssertionAttributes.ElementsAs(ctx, &attributes, false)...)
+		if diagnostics.HasError() {
+			return args, diagnostics
+		}
+
+		for _, attribute := range attributes {
+			args.AssertionAttributes = append(args.AssertionAttributes, applications.AssertionAttribute{
+				AssertionAttributeName: attribute.AttributeName.ValueString(),
+				UserAttributeName:      attribute.AttributeValue.ValueString(),
+			})
+		}
+	}
+
+	if !plan.AdvancedAssertionAttributes.IsNull() {
+
+		var attributes []advancedAssertionAttributeMappingData
+		diagnostics.Append(plan.AdvancedAssertionAttributes.ElementsAs(ctx, &attributes, false)...)
+		if diagnostics.HasError() {
+			return args, diagnostics
+		}
+
+		for _, attribute := range attributes {
+
+			advancedAttribute := applications.AdvancedAssertionAttribute{
+				AttributeName:  attribute.AttributeName.ValueString(),
+				AttributeValue: attribute.AttributeValue.ValueString(),
+			}
+
+			if attribute.Source.ValueString() == sourceValues[1] {
+				advancedAttribute.AttributeValue = "${corporateIdP." + attribute.AttributeValue.ValueString() + "}"
+			}
+
+			args.AdvancedAssertionAttributes = append(args.AdvancedAssertionAttributes, advancedAttribute)
+		}
+	}
+
+	var disabledAttributes, disabledAdvancedAttributes []string
+
+	if !plan.DisabledInheritedAssertionAttributes.IsNull() {
+		diagnostics.Append(plan.DisabledInheritedAssertionAttributes.ElementsAs(ctx, &disabledAttributes, false)...)
+	}
+
+	if !plan.DisabledInheritedAdvancedAssertionAttributes.IsNull() {
+		diagnostics.Append(plan.DisabledInheritedAdvancedAssertionAttributes.ElementsAs(ctx, &disabledAdvancedAttributes, false)...)
+	}
+
+	if diagnostics.HasError() {
+		return args, diagnostics
+	}
+
+	if len(disabledAttributes) > 0 || len(disabledAdvancedAttributes) > 0 {
+
+		args.DisabledInheritedProperties = &applications.DisabledInheritedProperties{}
+
+		for _, name := range disabledAttributes {
+			args.DisabledInheritedProperties.AssertionAttributes = append(args.DisabledInheritedProperties.AssertionAttributes, applications.AssertionAttribute{
+				AssertionAttributeName: name,
+				Inherited:              true,
+			})
+		}
+
+		for _, name := range disabledAdvancedAttributes {
+			args.DisabledInheritedProperties.AdvancedAssertionAttributes = append(args.DisabledInheritedProperties.AdvancedAssertionAttributes, applications.AdvancedAssertionAttribute{
+				AttributeName: name,
+				Inherited:     true,
+			})
+		}
+	}
+
+	return args, diagnostics
+}
+
+// getAssertionAttributesPatch computes the operations which turn the current attributes of the application into the planned attributes
+// the lists are compared without the inherited attributes, and each list which differs is replaced as a whole
+func getAssertionAttributesPatch(current *applications.AuthenticationSchema, planned applications.AuthenticationSchema) ([]generic.PatchRequest, diag.Diagnostics) {
+
+	var reqs []generic.PatchRequest
+
+	authSchemaPath, diags := utils.GetAttributeTag("AuthenticationSchema", reflect.TypeFor[applicationData]())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if current == nil {
+		current = &applications.AuthenticationSchema{}
+	}
+
+	currentAttributes := slices.DeleteFunc(slices.Clone(current.AssertionAttributes), func(attribute applications.AssertionAttribute) bool {
+		return attribute.Inherited
+	})
+
+	if !slices.Equal(currentAttributes, planned.AssertionAttributes) {
+
+		attributesPath, diags := getAuthenticationSchemaFieldPath(authSchemaPath, "AssertionAttributes")
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		reqs = append(reqs, utils.GenerateReplacePatchRequest(attributesPath, planned.AssertionAttributes))
+	}
+
+	currentAdvancedAttributes := slices.DeleteFunc(slices.Clone(current.AdvancedAssertionAttributes), func(attribute applications.AdvancedAssertionAttribute) bool {
+		return attribute.Inherited
+	})
+
+	if !slices.Equal(currentAdvancedAttributes, planned.AdvancedAssertionAttributes) {
+
+		advancedAttributesPath, diags := getAuthenticationSchemaFieldPath(authSchemaPath, "AdvancedAssertionAttributes")
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		reqs = append(reqs, utils.GenerateReplacePatchRequest(advancedAttributesPath, planned.AdvancedAssertionAttributes))
+	}
+
+	currentDisabled, currentDisabledAdvanced := disabledInheritedAttributeNames(current.DisabledInheritedProperties)
+	plannedDisabled, plannedDisabledAdvanced := disabledInheritedAttributeNames(planned.DisabledInheritedProperties)
+
+	if !slices.Equal(currentDisabled, plannedDisabled) || !slices.Equal(currentDisabledAdvanced, plannedDisabledAdvanced) {
+
+		disabledPath, diags := getAuthenticationSchemaFieldPath(authSchemaPath, "DisabledInheritedProperties")
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		disabled := planned.DisabledInheritedProperties
+		if disabled == nil {
+			disabled = &applications.DisabledInheritedProperties{}
+		}
+
+		reqs = append(reqs, utils.GenerateReplacePatchRequest(disabledPath, disabled))
+	}
+
+	return reqs, nil
+}
+
+func getAuthenticationSchemaFieldPath(authSchemaPath string, fieldName string) (string, diag.Diagnostics) {
+
+	tag, diags := utils.GetAttributeTag(fieldName, reflect.TypeFor[applications.AuthenticationSchema]())
+	if diags.HasError() {
+		return "", diags
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return fmt.Sprintf("/%s/%s", authSchemaPath, name), nil
+}
+
+// parseAssertionExpression returns the references of an expression, which is a text with references written as ${<scope>.<attribute>}
+func parseAssertionExpression(expression string) ([]expressionReference, error) {
+
+	var references []expressionReference
+
+	rest := expression
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			return references, nil
+		}
+
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("the reference starting with %q is not closed", rest[start:])
+		}
+
+		content := rest[start+2 : start+end]
+		if strings.Contains(content, "${") {
+			return nil, fmt.Errorf("the reference %q contains a nested reference", rest[start:start+end+1])
+		}
+
+		scope, attribute, found := strings.Cut(content, ".")
+		if !found || len(scope) == 0 || len(attribute) == 0 {
+			return nil, fmt.Errorf("the reference %q must be written as ${<scope>.<attribute>}", rest[start:start+end+1])
+		}
+
+		references = append(references, expressionReference{
+			Scope:     scope,
+			Attribute: attribute,
+		})
+
+		rest = rest[start+end+1:]
+	}
+}
+
+// knownUserAttributesFrom returns the user attributes which can be mapped, those of the Identity Directory and those defined by the schemas of the tenant
+// the attributes of a schema can be referenced by their name, or qualified with the ID of the schema, sub-attributes are appended to the attribute with a dot
+func knownUserAttributesFrom(tenantSchemas []schemas.Schema) map[string]bool {
+
+	known := map[string]bool{}
+
+	for _, name := range identityDirectoryUserAttributes {
+		known[name] = true
+	}
+
+	for i := 1; i <= 10; i++ {
+		known[fmt.Sprintf("customAttribute%d", i)] = true
+	}
+
+	for _, schema := range tenantSchemas {
+		for _, attribute := range schema.Attributes {
+
+			names := []string{attribute.Name}
+			for _, subAttribute := range attribute.SubAttributes {
+				names = append(names, attribute.Name+"."+subAttribute.Name)
+			}
+
+			for _, name := range names {
+				known[name] = true
+				known[schema.Id+":"+name] = true
+			}
+		}
+	}
+
+	return known
+}
+
+// validateAssertionAttributes checks the planned attributes against the schemas of the tenant
+// unknown user attributes are reported as warnings, as the Identity Directory may provide attributes which are not described by a schema
+func validateAssertionAttributes(ctx context.Context, client *cli.SciClient, attributes []assertionAttributeMappingData, advancedAttributes []advancedAssertionAttributeMappingData) diag.Diagnostics {
+
+	var diagnostics diag.Diagnostics
+
+	var known map[string]bool
+
+	res, _, err := client.Schema.Get(ctx)
+	if err != nil {
+		diagnostics.AddWarning("Unable to validate assertion attributes", fmt.Sprintf("The schemas of the tenant could not be retrieved, the user attributes are not validated: %s", err))
+	} else {
+		known = knownUserAttributesFrom(res.Resources)
+	}
+
+	diagnostics.Append(validateAssertionAttributeMappings(known, attributes, advancedAttributes)...)
+
+	return diagnostics
+}
+
+// validateAssertionAttributeMappings validates the user attributes and expressions of the planned attributes
+// the user attributes are only validated if the known attributes are given
+func validateAssertionAttributeMappings(known map[string]bool, attributes []assertionAttributeMappingData, advancedAttributes []advancedAssertionAttributeMappingData) diag.Diagnostics {
+
+	var diagnostics diag.Diagnostics
+
+	names := map[string]path.Path{}
+
+	checkName := func(name types.String, attrPath path.Path) {
+		if name.IsNull() || name.IsUnknown() {
+			return
+		}
+		if previous, ok := names[name.ValueString()]; ok {
+			diagnostics.AddAttributeError(attrPath, "Duplicate Assertion Attribute", fmt.Sprintf("The assertion attribute %s is already defined at %s.", name.ValueString(), previous))
+			return
+		}
+		names[name.ValueString()] = attrPath
+	}
+
+	checkUserAttribute := func(userAttribute string, attrPath path.Path) {
+		if known != nil && !known[userAttribute] {
+			diagnostics.AddAttributeWarning(attrPath, "Unknown User Attribute", fmt.Sprintf("The user attribute %s is neither an attribute of the Identity Directory nor defined by a schema of the tenant.", userAttribute))
+		}
+	}
+
+	for i, attribute := range attributes {
+
+		attrPath := path.Root("assertion_attributes").AtListIndex(i)
+		checkName(attribute.AttributeName, attrPath.AtName("attribute_name"))
+
+		if !attribute.AttributeValue.IsNull() && !attribute.AttributeValue.IsUnknown() {
+			checkUserAttribute(attribute.AttributeValue.ValueString(), attrPath.AtName("attribute_value"))
+		}
+	}
+
+	for i, attribute := range advancedAttributes {
+
+		attrPath := path.Root("advanced_assertion_attributes").AtListIndex(i)
+		checkName(attribute.AttributeName, attrPath.AtName("attribute_name"))
+
+		if attribute.AttributeValue.IsNull() || attribute.AttributeValue.IsUnknown() || attribute.Source.IsUnknown() {
+			continue
+		}
+
+		value := attribute.AttributeValue.ValueString()
+		valuePath := attrPath.AtName("attribute_value")
+
+		// the attribute of the corporate IDP is wrapped in a reference when sent
+		if attribute.Source.ValueString() == sourceValues[1] {
+			if strings.ContainsAny(value, "${}") {
+				diagnostics.AddAttributeError(valuePath, "Invalid Corporate Identity Provider Attribute", fmt.Sprintf("The attribute %s must be the name of an attribute of the corporate identity provider, without a reference.", value))
+			}
+			continue
+		}
+
+		// an expression consisting of a single corporate IDP reference is read back with the source Corporate Identity Provider
+		if corporateIdPAttributeValue.MatchString(value) {
+			diagnostics.AddAttributeError(valuePath, "Invalid Expression", fmt.Sprintf("The expression %s only references an attribute of the corporate identity provider, use the source %s with the attribute name instead.", value, sourceValues[1]))
+			continue
+		}
+
+		references, err := parseAssertionExpression(value)
+		if err != nil {
+			diagnostics.AddAttributeError(valuePath, "Invalid Expression", fmt.Sprintf("The expression %s is invalid: %s.", value, err))
+			continue
+		}
+
+		for _, reference := range references {
+			switch {
+			case !slices.Contains(expressionScopes, reference.Scope):
+				diagnostics.AddAttributeWarning(valuePath, "Unknown Expression Scope", fmt.Sprintf("The expression %s references the scope %s, the known scopes are: %s.", value, reference.Scope, strings.Join(expressionScopes, ", ")))
+			case reference.Scope == "user":
+				checkUserAttribute(reference.Attribute, valuePath)
+			}
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,355 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type applicationAuthenticationRulesData struct {
+	Id            types.String `tfsdk:"id"`
+	ApplicationId types.String `tfsdk:"application_id"`
+	Rules         types.List   `tfsdk:"rules"`
+}
+
+type authenticationRuleEvaluationData struct {
+	ApplicationId      types.String `tfsdk:"application_id"`
+	UserType           types.String `tfsdk:"user_type"`
+	UserGroupIds       types.Set    `tfsdk:"user_group_ids"`
+	Email              types.String `tfsdk:"email"`
+	IpAddress          types.String `tfsdk:"ip_address"`
+	MatchedRule        types.Int64  `tfsdk:"matched_rule"`
+	IdentityProviderId types.String `tfsdk:"identity_provider_id"`
+	Rules              types.List   `tfsdk:"rules"`
+}
+
+type authenticationRuleResultData struct {
+	IdentityProviderId types.String `tfsdk:"identity_provider_id"`
+	Matches            types.Bool   `tfsdk:"matches"`
+	FailedConditions   types.List   `tfsdk:"failed_conditions"`
+}
+
+var authenticationRuleResultObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"identity_provider_id": types.StringType,
+		"matches":              types.BoolType,
+		"failed_conditions":    types.ListType{ElemType: types.StringType},
+	},
+}
+
+// authenticationRuleSample is a user whose authentication is evaluated against the rules of an application
+type authenticationRuleSample struct {
+	UserType     string
+	UserGroupIds []string
+	Email        string
+	IpAddress    string
+}
+
+// authenticationRulesValueFrom maps the conditional authentication rules of an application
+// the mapping is done manually in order to handle the null values
+func authenticationRulesValueFrom(ctx context.Context, rules []applications.AuthenicationRule) (types.List, diag.Diagnostics) {
+
+	if len(rules) == 0 {
+		return types.ListNull(authenticationRulesObjType), nil
+	}
+
+	authRules := []authenticationRulesData{}
+
+	for _, rule := range rules {
+
+		authRule := authenticationRulesData{}
+
+		if len(rule.UserType) > 0 {
+			authRule.UserType = types.StringValue(rule.UserType)
+		}
+
+		if len(rule.UserGroup) > 0 {
+			authRule.UserGroup = types.StringValue(rule.UserGroup)
+		}
+
+		if len(rule.UserEmailDomain) > 0 {
+			authRule.UserEmailDomain = types.StringValue(rule.UserEmailDomain)
+		}
+
+		if len(rule.IdentityProviderId) > 0 {
+			authRule.IdentityProviderId = types.StringValue(rule.IdentityProviderId)
+		}
+
+		if len(rule.IpNetworkRange) > 0 {
+			authRule.IpNetworkRange = types.StringValue(rule.IpNetworkRange)
+		}
+
+		authRules = append(authRules, authRule)
+	}
+
+	return types.ListValueFrom(ctx, authenticationRulesObjType, authRules)
+}
+
+func applicationAuthenticationRulesValueFrom(ctx context.Context, a applications.Application) (applicationAuthenticationRulesData, diag.Diagnostics) {
+
+	var rules []applications.AuthenicationRule
+	if a.AuthenticationSchema != nil {
+		rules = a.AuthenticationSchema.ConditionalAuthentication
+	}
+
+	rulesData, diags := authenticationRulesValueFrom(ctx, rules)
+
+	return applicationAuthenticationRulesData{
+		Id:            types.StringValue(a.Id),
+		ApplicationId: types.StringValue(a.Id),
+		Rules:         rulesData,
+	}, diags
+}
+
+// getAuthenticationRulesPath returns the path of the conditional authentication rules in the PATCH requests of an application
+func getAuthenticationRulesPath() (string, diag.Diagnostics) {
+
+	authSchemaPath, diags := utils.GetAttributeTag("AuthenticationSchema", reflect.TypeFor[applicationData]())
+	if diags.HasError() {
+		return "", diags
+	}
+
+	rulesPath, diags := utils.GetAttributeTag("AuthenticationRules", reflect.TypeFor[authenticationSchemaData]())
+	if diags.HasError() {
+		return "", diags
+	}
+
+	return fmt.Sprintf("/%s/%s", authSchemaPath, rulesPath), nil
+}
+
+// getAuthenticationRulesPatch computes the operations which turn the current rules into the planned rules, applied in order
+// the rules of the longest common subsequence are left untouched, so that inserting or removing a rule does not rewrite the rules after it
+func getAuthenticationRulesPatch(rulesPath string, current []applications.AuthenicationRule, planned []applications.AuthenicationRule) []generic.PatchRequest {
+
+	// lcs[i][j] is the length of the longest common subsequence of current[i:] and planned[j:]
+	lcs := make([][]int, len(current)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(planned)+1)
+	}
+
+	for i := len(current) - 1; i >= 0; i-- {
+		for j := len(planned) - 1; j >= 0; j-- {
+			if current[i] == planned[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	reqs := []generic.PatchRequest{}
+
+	// position is the index of the rule in the list as modified by the operations so far
+	i, j, position := 0, 0, 0
+	for i < len(current) || j < len(planned) {
+
+		rulePath := fmt.Sprintf("%s/%d", rulesPath, position)
+
+		switch {
+		case i < len(current) && j < len(planned) && current[i] == planned[j]:
+			i, j, position = i+1, j+1, position+1
+		case i < len(current) && j < len(planned) && lcs[i+1][j+1] == lcs[i][j]:
+			reqs = append(reqs, utils.GenerateReplacePatchRequest(rulePath, planned[j]))
+			i, j, position = i+1, j+1, position+1
+		case i < len(current) && (j == len(planned) || lcs[i+1][j] >= lcs[i][j+1]):
+			reqs = append(reqs, utils.GenerateDeletePatchRequest(rulePath))
+			i++
+		default:
+			reqs = append(reqs, utils.GenerateAddPatchRequest(rulePath, planned[j]))
+			j, position = j+1, position+1
+		}
+	}
+
+	return reqs
+}
+
+// validateAuthenticationRuleReferences checks that the groups and corporate IdPs referenced by the rules exist in the tenant
+// IdPs are only checked if they are referenced by a UUID, other IDs refer to the identity providers of the tenant itself
+// if the references cannot be read, a warning is returned instead of failing the plan
+func validateAuthenticationRuleReferences(ctx context.Context, client *cli.SciClient, rules []authenticationRulesData, rulesPath path.Path) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if client == nil {
+		return diags
+	}
+
+	var idpIds map[string]bool
+
+	groupIds := map[string]bool{}
+
+	for i, rule := range rules {
+
+		idpId := rule.IdentityProviderId.ValueString()
+		if utils.UuidRegexp.MatchString(idpId) {
+
+			if idpIds == nil {
+				res, _, err := client.CorporateIdP.Get(ctx)
+				if err != nil {
+					diags.AddAttributeWarning(rulesPath, "Unable to validate authentication rules", fmt.Sprintf("%s", err))
+					return diags
+				}
+
+				idpIds = make(map[string]bool, len(res.IdentityProviders))
+				for _, idp := range res.IdentityProviders {
+					idpIds[idp.Id] = true
+				}
+			}
+
+			if !idpIds[idpId] {
+				diags.AddAttributeError(
+					rulesPath.AtListIndex(i).AtName("identity_provider_id"),
+					"Unknown Corporate Identity Provider",
+					fmt.Sprintf("The corporate identity provider %s referenced by the rule does not exist in the tenant.", idpId),
+				)
+			}
+		}
+
+		groupId := rule.UserGroup.ValueString()
+		if len(groupId) > 0 {
+
+			found, checked := groupIds[groupId]
+			if !checked {
+				_, _, err := client.Group.GetByGroupId(ctx, groupId)
+				if err != nil && cli.ScimErrorStatus(err) != http.StatusNotFound {
+					diags.AddAttributeWarning(rulesPath, "Unable to validate authentication rules", fmt.Sprintf("%s", err))
+					return diags
+				}
+
+				found = err == nil
+				groupIds[groupId] = found
+			}
+
+			if !found {
+				diags.AddAttributeError(
+					rulesPath.AtListIndex(i).AtName("user_group"),
+					"Unknown Group",
+					fmt.Sprintf("The group %s referenced by the rule does not exist in the tenant.", groupId),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+// authenticationRuleEvaluationValueFrom evaluates the rules of the application in order for the user
+// the user is authenticated by the identity provider of the first matching rule, or by the default identity provider of the application if no rule matches
+func authenticationRuleEvaluationValueFrom(ctx context.Context, a applications.Application, user authenticationRuleSample, config authenticationRuleEvaluationData) (authenticationRuleEvaluationData, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+
+	var rules []applications.AuthenicationRule
+	var defaultIdpId string
+	if a.AuthenticationSchema != nil {
+		rules = a.AuthenticationSchema.ConditionalAuthentication
+		defaultIdpId = a.AuthenticationSchema.DefaultAuthenticatingIdpId
+	}
+
+	evaluation := config
+	evaluation.MatchedRule = types.Int64Null()
+	evaluation.IdentityProviderId = types.StringNull()
+	if len(defaultIdpId) > 0 {
+		evaluation.IdentityProviderId = types.StringValue(defaultIdpId)
+	}
+
+	results := []authenticationRuleResultData{}
+
+	for i, rule := range rules {
+
+		failed := getAuthenticationRuleFailedConditions(rule, user)
+
+		failedConditions, diags := types.ListValueFrom(ctx, types.StringType, failed)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return evaluation, diagnostics
+		}
+
+		results = append(results, authenticationRuleResultData{
+			IdentityProviderId: types.StringValue(rule.IdentityProviderId),
+			Matches:            types.BoolValue(len(failed) == 0),
+			FailedConditions:   failedConditions,
+		})
+
+		if len(failed) == 0 && evaluation.MatchedRule.IsNull() {
+			evaluation.MatchedRule = types.Int64Value(int64(i))
+			evaluation.IdentityProviderId = types.StringValue(rule.IdentityProviderId)
+		}
+	}
+
+	var diags diag.Diagnostics
+	evaluation.Rules, diags = types.ListValueFrom(ctx, authenticationRuleResultObjType, results)
+	diagnostics.Append(diags...)
+
+	return evaluation, diagnostics
+}
+
+// getAuthenticationRuleFailedConditions returns the conditions of the rule which are not fulfilled by the user
+// a rule matches the user if all of its conditions are fulfilled
+func getAuthenticationRuleFailedConditions(rule applications.AuthenicationRule, user authenticationRuleSample) []string {
+
+	failed := []string{}
+
+	if len(rule.UserType) > 0 && !strings.EqualFold(rule.UserType, user.UserType) {
+		failed = append(failed, "user_type")
+	}
+
+	if len(rule.UserGroup) > 0 && !slices.Contains(user.UserGroupIds, rule.UserGroup) {
+		failed = append(failed, "user_group")
+	}
+
+	if len(rule.UserEmailDomain) > 0 && !emailDomainMatches(rule.UserEmailDomain, user.Email) {
+		failed = append(failed, "user_email_domain")
+	}
+
+	if len(rule.IpNetworkRange) > 0 && !ipNetworkRangeContains(rule.IpNetworkRange, user.IpAddress) {
+		failed = append(failed, "ip_network_range")
+	}
+
+	return failed
+}
+
+// emailDomainMatches checks the domain of the email against the domain of a rule
+// a domain starting with "*." matches all of its subdomains
+func emailDomainMatches(domain string, email string) bool {
+
+	_, emailDomain, found := strings.Cut(email, "@")
+	if !found {
+		return false
+	}
+
+	domain, emailDomain = strings.ToLower(domain), strings.ToLower(emailDomain)
+
+	if suffix, wildcard := strings.CutPrefix(domain, "*"); wildcard {
+		return strings.HasSuffix(emailDomain, suffix)
+	}
+
+	return domain == emailDomain
+}
+
+// ipNetworkRangeContains checks whether the IP address is part of the network range in CIDR notation
+func ipNetworkRangeContains(networkRange string, ipAddress string) bool {
+
+	_, network, err := net.ParseCIDR(networkRange)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(ipAddress)
+
+	return ip != nil && network.Contains(ip)
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"slices"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type applicationOidcClientData struct {
+	ClientId              types.String `tfsdk:"client_id"`
+	Issuer                types.String `tfsdk:"issuer"`
+	AuthorizationEndpoint types.String `tfsdk:"authorization_endpoint"`
+	TokenEndpoint         types.String `tfsdk:"token_endpoint"`
+	JwksUri               types.String `tfsdk:"jwks_uri"`
+	LogoutEndpoint        types.String `tfsdk:"logout_endpoint"`
+}
+
+type applicationSaml2IdpData struct {
+	EntityId types.String `tfsdk:"entity_id"`
+	SsoUrl   types.String `tfsdk:"sso_url"`
+	SloUrl   types.String `tfsdk:"slo_url"`
+}
+
+var applicationOidcClientObjType = map[string]attr.Type{
+	"client_id":              types.StringType,
+	"issuer":                 types.StringType,
+	"authorization_endpoint": types.StringType,
+	"token_endpoint":         types.StringType,
+	"jwks_uri":               types.StringType,
+	"logout_endpoint":        types.StringType,
+}
+
+var applicationSaml2IdpObjType = map[string]attr.Type{
+	"entity_id": types.StringType,
+	"sso_url":   types.StringType,
+	"slo_url":   types.StringType,
+}
+
+var (
+	// the sso types of applications signing in users with OpenID Connect and SAML 2.0
+	oidcSsoTypes  = []string{"openIdConnect", "saml2oidc"}
+	saml2SsoTypes = []string{"saml2", "saml2oidc"}
+)
+
+// applicationClientId returns the OpenID Connect client ID of the application, which is the ID of the application unless the tenant returns a different one
+func applicationClientId(a applications.Application) string {
+
+	if a.AuthenticationSchema != nil && len(a.AuthenticationSchema.ClientId) > 0 {
+		return a.AuthenticationSchema.ClientId
+	}
+
+	return a.Id
+}
+
+// applicationEndpointsValueFrom maps the client registration of the application and the endpoints of the tenant it signs in users with
+// the endpoints are derived from the tenant URL, the client is only set for OpenID Connect applications and the identity provider only for SAML 2.0 applications
+func applicationEndpointsValueFrom(ctx context.Context, tenantUrl *url.URL, ssoType string, clientId string) (types.Object, types.Object, diag.Diagnostics) {
+
+	var diagnostics, diags diag.Diagnostics
+
+	oidcClient := types.ObjectNull(applicationOidcClientObjType)
+	saml2Idp := types.ObjectNull(applicationSaml2IdpObjType)
+
+	if tenantUrl == nil {
+		return oidcClient, saml2Idp, diagnostics
+	}
+
+	// the endpoints are served from the root of the tenant, regardless of the path of the configured tenant URL
+	tenant := &url.URL{
+		Scheme: tenantUrl.Scheme,
+		Host:   tenantUrl.Host,
+	}
+
+	if slices.Contains(oidcSsoTypes, ssoType) {
+
+		client := applicationOidcClientData{
+			ClientId:              types.StringValue(clientId),
+			Issuer:                types.StringValue(tenant.String()),
+			AuthorizationEndpoint: types.StringValue(tenant.JoinPath("oauth2", "authorize").String()),
+			TokenEndpoint:         types.StringValue(tenant.JoinPath("oauth2", "token").String()),
+			JwksUri:               types.StringValue(tenant.JoinPath("oauth2", "certs").String()),
+			LogoutEndpoint:        types.StringValue(tenant.JoinPath("oauth2", "logout").String()),
+		}
+
+		oidcClient, diags = types.ObjectValueFrom(ctx, applicationOidcClientObjType, client)
+		diagnostics.Append(diags...)
+	}
+
+	if slices.Contains(saml2SsoTypes, ssoType) {
+
+		idp := applicationSaml2IdpData{
+			EntityId: types.StringValue(tenant.Host),
+			SsoUrl:   types.StringValue(tenant.JoinPath("saml2", "idp", "sso").String()),
+			SloUrl:   types.StringValue(tenant.JoinPath("saml2", "idp", "slo").String()),
+		}
+
+		saml2Idp, diags = types.ObjectValueFrom(ctx, applicationSaml2IdpObjType, idp)
+		diagnostics.Append(diags...)
+	}
+
+	return oidcClient, saml2Idp, diagnostics
+}
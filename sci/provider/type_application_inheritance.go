@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/applications"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// applicationResourceValueFrom maps the application to the state of the resource, which only holds the configuration of the application itself
+// the values inherited from the parent application are left out, so that changes of the parent application do not show up as differences of the child application
+func applicationResourceValueFrom(ctx context.Context, a applications.Application, plan applicationData) (applicationData, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+
+	own, inheriting := withoutInheritedValues(a)
+
+	state, diags := applicationValueFrom(ctx, own)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return state, diagnostics
+	}
+
+	diags = stateModify(ctx, plan, &state)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return state, diagnostics
+	}
+
+	state.OverrideInherited, diags = overrideInheritedValueFrom(ctx, plan.OverrideInherited, overriddenInheritedProperties(own.AuthenticationSchema.DisabledInheritedProperties), inheriting)
+	diagnostics.Append(diags...)
+
+	return state, diagnostics
+}
+
+// withoutInheritedValues returns a copy of the application without the values inherited from the parent application,
+// along with the properties which inherit values
+func withoutInheritedValues(a applications.Application) (applications.Application, []string) {
+
+	if a.AuthenticationSchema == nil {
+		return a, nil
+	}
+
+	var inheriting []string
+
+	authSchema := *a.AuthenticationSchema
+
+	authSchema.AssertionAttributes = slices.DeleteFunc(slices.Clone(authSchema.AssertionAttributes), func(attribute applications.AssertionAttribute) bool {
+		return attribute.Inherited
+	})
+	if len(authSchema.AssertionAttributes) < len(a.AuthenticationSchema.AssertionAttributes) {
+		inheriting = append(inheriting, overrideInheritedValues[0])
+	}
+
+	authSchema.AdvancedAssertionAttributes = slices.DeleteFunc(slices.Clone(authSchema.AdvancedAssertionAttributes), func(attribute applications.AdvancedAssertionAttribute) bool {
+		return attribute.Inherited
+	})
+	if len(authSchema.AdvancedAssertionAttributes) < len(a.AuthenticationSchema.AdvancedAssertionAttributes) {
+		inheriting = append(inheriting, overrideInheritedValues[1])
+	}
+
+	a.AuthenticationSchema = &authSchema
+
+	return a, inheriting
+}
+
+// overriddenInheritedProperties returns the properties for which inherited values are disabled
+func overriddenInheritedProperties(disabled *applications.DisabledInheritedProperties) []string {
+
+	if disabled == nil {
+		return nil
+	}
+
+	var properties []string
+
+	if len(disabled.AssertionAttributes) > 0 {
+		properties = append(properties, overrideInheritedValues[0])
+	}
+
+	if len(disabled.AdvancedAssertionAttributes) > 0 {
+		properties = append(properties, overrideInheritedValues[1])
+	}
+
+	return properties
+}
+
+// overrideInheritedValueFrom returns the properties for which the tenant disables inherited values
+// a property which inherits values again, e.g. as attributes were added to the parent application, is left out so that the override is planned again
+func overrideInheritedValueFrom(ctx context.Context, planned types.Set, overridden []string, inheriting []string) (types.Set, diag.Diagnostics) {
+
+	properties := []string{}
+	for _, property := range overridden {
+		if !slices.Contains(inheriting, property) {
+			properties = append(properties, property)
+		}
+	}
+
+	// an empty value is only kept if overrides are configured, so that they are planned again
+	if len(properties) == 0 && (planned.IsNull() || planned.IsUnknown()) {
+		return types.SetNull(types.StringType), nil
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, properties)
+}
+
+// getOverrideInheritedPatch computes the operations which disable the values inherited by the overridden properties and enable those of all other properties
+// the values are disabled by their names, which are taken from the values currently inherited by the application
+// only the lists of the properties whose override changes are replaced, so that the other disabled inherited properties are kept
+func getOverrideInheritedPatch(ctx context.Context, current applications.Application, planned types.Set) ([]generic.PatchRequest, diag.Diagnostics) {
+
+	var overridden []string
+	if !planned.IsNull() && !planned.IsUnknown() {
+		diags := planned.ElementsAs(ctx, &overridden, false)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	authSchema := applications.AuthenticationSchema{}
+	if current.AuthenticationSchema != nil {
+		authSchema = *current.AuthenticationSchema
+	}
+
+	currentDisabled := applications.DisabledInheritedProperties{}
+	if authSchema.DisabledInheritedProperties != nil {
+		currentDisabled = *authSchema.DisabledInheritedProperties
+	}
+
+	authSchemaPath, diags := utils.GetAttributeTag("AuthenticationSchema", reflect.TypeFor[applicationData]())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	disabledPath, diags := getAuthenticationSchemaFieldPath(authSchemaPath, "DisabledInheritedProperties")
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var reqs []generic.PatchRequest
+
+	attributes := slices.Clone(currentDisabled.AssertionAttributes)
+	for _, attribute := range authSchema.AssertionAttributes {
+		if attribute.Inherited {
+			attributes = append(attributes, attribute)
+		}
+	}
+
+	req, diags := getDisabledInheritedListPatch(disabledPath, "AssertionAttributes", currentDisabled.AssertionAttributes, attributes, slices.Contains(overridden, overrideInheritedValues[0]))
+	if diags.HasError() {
+		return nil, diags
+	}
+	reqs = append(reqs, req...)
+
+	advancedAttributes := slices.Clone(currentDisabled.AdvancedAssertionAttributes)
+	for _, attribute := range authSchema.AdvancedAssertionAttributes {
+		if attribute.Inherited {
+			advancedAttributes = append(advancedAttributes, attribute)
+		}
+	}
+
+	req, diags = getDisabledInheritedListPatch(disabledPath, "AdvancedAssertionAttributes", currentDisabled.AdvancedAssertionAttributes, advancedAttributes, slices.Contains(overridden, overrideInheritedValues[1]))
+	if diags.HasError() {
+		return nil, diags
+	}
+	reqs = append(reqs, req...)
+
+	return reqs, nil
+}
+
+// getDisabledInheritedListPatch computes the operation which replaces a single list of the disabled inherited properties
+// an overridden list is replaced if further values are inherited, a list which is no longer overridden is emptied if values are disabled
+func getDisabledInheritedListPatch[T any](disabledPath string, fieldName string, current []T, planned []T, overridden bool) ([]generic.PatchRequest, diag.Diagnostics) {
+
+	if overridden && len(planned) == len(current) || !overridden && len(current) == 0 {
+		return nil, nil
+	}
+
+	tag, diags := utils.GetAttributeTag(fieldName, reflect.TypeFor[applications.DisabledInheritedProperties]())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	listPath := fmt.Sprintf("%s/%s", disabledPath, name)
+
+	if !overridden {
+		planned = []T{}
+	}
+
+	return []generic.PatchRequest{utils.GenerateReplacePatchRequest(listPath, planned)}, nil
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// certificateDetailsResourceSchema adds the details read from the X.509 certificate in base64_certificate to the attributes of a certificate
+// the details are planned from the certificate, they are null if the certificate cannot be parsed
+func certificateDetailsResourceSchema(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+
+	details := map[string]string{
+		"subject_dn":         "The subject distinguished name read from the certificate.",
+		"sha1_fingerprint":   "The SHA-1 fingerprint of the certificate.",
+		"sha256_fingerprint": "The SHA-256 fingerprint of the certificate.",
+		"not_before":         "The date from which the certificate is valid, read from the certificate.",
+		"not_after":          "The date until which the certificate is valid, read from the certificate. Plans carry a warning if the certificate expires within `certificate_expiry_warning_days`.",
+	}
+
+	for name, description := range details {
+		attributes[name] = schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				certificateDetailValue(name),
+			},
+		}
+	}
+
+	return attributes
+}
+
+// certificateDetailModifier plans a detail of the certificate from the planned base64_certificate
+type certificateDetailModifier struct {
+	// the name of the detail attribute
+	detail string
+}
+
+func certificateDetailValue(detail string) planmodifier.String {
+	return certificateDetailModifier{detail: detail}
+}
+
+func (m certificateDetailModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m certificateDetailModifier) MarkdownDescription(_ context.Context) string {
+	return "The value is read from the planned certificate."
+}
+
+func (m certificateDetailModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+
+	// the value has already been planned, e.g. derived from SAML metadata, or the certificate is unchanged
+	if !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var certificate types.String
+	diags := req.Plan.GetAttribute(ctx, req.Path.ParentPath().AtName("base64_certificate"), &certificate)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || certificate.IsUnknown() {
+		return
+	}
+
+	details := utils.CertificateDetailsOf(certificate.ValueString())
+
+	var value *string
+	switch m.detail {
+	case "subject_dn":
+		value = details.SubjectDn
+	case "sha1_fingerprint":
+		value = details.Sha1Fingerprint
+	case "sha256_fingerprint":
+		value = details.Sha256Fingerprint
+	case "not_before":
+		value = details.NotBefore
+	case "not_after":
+		value = details.NotAfter
+	}
+
+	resp.PlanValue = types.StringPointerValue(value)
+}
+
+// certificateListExpiryWarnings warns about the planned certificates of the list which expire within the given number of days
+func certificateListExpiryWarnings(ctx context.Context, plan tfsdk.Plan, certificatesPath path.Path, days int, now time.Time) diag.Diagnostics {
+
+	var certificates types.List
+	diags := plan.GetAttribute(ctx, certificatesPath, &certificates)
+	if diags.HasError() || certificates.IsNull() || certificates.IsUnknown() {
+		return diags
+	}
+
+	for i := range certificates.Elements() {
+		diags.Append(certificateExpiryWarnings(ctx, plan, certificatesPath.AtListIndex(i), days, now)...)
+	}
+
+	return diags
+}
+
+// certificateExpiryWarnings warns about the planned certificate if it expires within the given number of days
+func certificateExpiryWarnings(ctx context.Context, plan tfsdk.Plan, certificatePath path.Path, days int, now time.Time) diag.Diagnostics {
+
+	var certificate types.String
+	diags := plan.GetAttribute(ctx, certificatePath.AtName("base64_certificate"), &certificate)
+	if diags.HasError() || certificate.IsNull() || certificate.IsUnknown() {
+		return diags
+	}
+
+	diags.Append(certificateExpiryWarning(certificatePath, certificate.ValueString(), days, now)...)
+	return diags
+}
+
+// certificateExpiryWarning returns a warning if the certificate has expired or expires within the given number of days
+// no warning is returned if the warnings are disabled or the certificate cannot be parsed
+func certificateExpiryWarning(certificatePath path.Path, certificate string, days int, now time.Time) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if days <= 0 {
+		return diags
+	}
+
+	parsed, err := utils.ParseCertificate(certificate)
+	if err != nil {
+		return diags
+	}
+
+	notAfter := parsed.NotAfter.UTC().Format(time.RFC3339)
+
+	switch {
+	case now.After(parsed.NotAfter):
+		diags.AddAttributeWarning(certificatePath, "Certificate Expired", fmt.Sprintf("The certificate %s expired at %s.", parsed.Subject, notAfter))
+	case now.AddDate(0, 0, days).After(parsed.NotAfter):
+		diags.AddAttributeWarning(certificatePath, "Certificate Expires Soon", fmt.Sprintf("The certificate %s expires at %s, within %d days.", parsed.Subject, notAfter, days))
+	}
+
+	return diags
+}
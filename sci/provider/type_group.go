@@ -30,6 +30,8 @@ type groupData struct {
 	DisplayName    types.String `tfsdk:"display_name" json:"displayName"`
 	GroupMembers   types.Set    `tfsdk:"group_members" json:"members"`
 	GroupExtension types.Object `tfsdk:"group_extension" json:"urn:sap:cloud:scim:schemas:extension:custom:2.0:Group"`
+	CustomSchemas  types.String `tfsdk:"custom_schemas"`
+	Meta           types.Object `tfsdk:"meta"`
 }
 
 type groupBaseData struct {
@@ -37,13 +39,15 @@ type groupBaseData struct {
 	Schemas        types.Set    `tfsdk:"schemas" json:"schemas"`
 	DisplayName    types.String `tfsdk:"display_name" json:"displayName"`
 	GroupExtension types.Object `tfsdk:"group_extension" json:"urn:sap:cloud:scim:schemas:extension:custom:2.0:Group"`
+	CustomSchemas  types.String `tfsdk:"custom_schemas"`
+	Meta           types.Object `tfsdk:"meta"`
 }
 
 type groupsData struct {
 	Values types.List `tfsdk:"values"`
 }
 
-func groupValueFrom(ctx context.Context, g groups.Group) (groupData, diag.Diagnostics) {
+func groupValueFrom(ctx context.Context, g groups.Group, cS string) (groupData, diag.Diagnostics) {
 
 	var diagnostics, diags diag.Diagnostics
 
@@ -96,17 +100,23 @@ func groupValueFrom(ctx context.Context, g groups.Group) (groupData, diag.Diagno
 	}
 	diagnostics.Append(diags...)
 
+	group.CustomSchemas = customSchemasValueFrom(cS)
+
+	// Meta
+	group.Meta, diags = metaValueFrom(ctx, g.Meta)
+	diagnostics.Append(diags...)
+
 	return group, diagnostics
 }
 
-func groupsValueFrom(ctx context.Context, g groups.GroupsResponse) ([]groupData, diag.Diagnostics) {
+func groupsValueFrom(ctx context.Context, g groups.GroupsResponse, customSchemas map[int]string) ([]groupData, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
 
 	groups := []groupData{}
 
-	for _, groupRes := range g.Resources {
+	for i, groupRes := range g.Resources {
 
-		group, diags := groupValueFrom(ctx, groupRes)
+		group, diags := groupValueFrom(ctx, groupRes, customSchemas[i])
 		groups = append(groups, group)
 		diagnostics.Append(diags...)
 
@@ -115,7 +125,7 @@ func groupsValueFrom(ctx context.Context, g groups.GroupsResponse) ([]groupData,
 	return groups, diagnostics
 }
 
-func (r *groupResource) GetGroupRequest(ctx context.Context, plan groupData) (*groups.Group, diag.Diagnostics) {
+func (r *groupResource) GetGroupRequest(ctx context.Context, plan groupData) (*groups.Group, string, diag.Diagnostics) {
 
 	var diagnostics diag.Diagnostics
 
@@ -123,7 +133,7 @@ func (r *groupResource) GetGroupRequest(ctx context.Context, plan groupData) (*g
 	diags := plan.Schemas.ElementsAs(ctx, &schemas, true)
 	diagnostics.Append(diags...)
 	if diagnostics.HasError() {
-		return nil, diagnostics
+		return nil, "", diagnostics
 	}
 
 	args := &groups.Group{
@@ -137,7 +147,7 @@ func (r *groupResource) GetGroupRequest(ctx context.Context, plan groupData) (*g
 		diags = plan.GroupMembers.ElementsAs(ctx, &members, true)
 		diagnostics.Append(diags...)
 		if diagnostics.HasError() {
-			return nil, diagnostics
+			return nil, "", diagnostics
 		}
 
 		// the mapping is done manually in order to carry out the member validation
@@ -150,7 +160,7 @@ func (r *groupResource) GetGroupRequest(ctx context.Context, plan groupData) (*g
 					fmt.Sprintf("%s", err),
 					"please provide a valid member UUID",
 				)
-				return nil, diagnostics
+				return nil, "", diagnostics
 			}
 
 			groupMember := groups.GroupMember{
@@ -174,13 +184,18 @@ func (r *groupResource) GetGroupRequest(ctx context.Context, plan groupData) (*g
 		})
 		diagnostics.Append(diags...)
 		if diagnostics.HasError() {
-			return nil, diagnostics
+			return nil, "", diagnostics
 		}
 
 		args.GroupExtension = &groupExtension
 	}
 
-	return args, diagnostics
+	var customSchemas string
+	if !plan.CustomSchemas.IsNull() {
+		customSchemas = plan.CustomSchemas.ValueString()
+	}
+
+	return args, customSchemas, diagnostics
 }
 
 func getGroupUpdateRequest(ctx context.Context, plan groupData, state groupData) ([]generic.PatchRequest, diag.Diagnostics) {
@@ -241,6 +256,7 @@ func getGroupUpdateRequest(ctx context.Context, plan groupData, state groupData)
 			})
 		}
 
+		// a replacement of more members than the chunk size is split into a replacement and additions of the remaining members
 		patchReq, diags := utils.GetScimPatchRequest("GroupMembers", "", scimMembers, argsType)
 		if diags.HasError() {
 			return reqs, diags
@@ -299,5 +315,19 @@ func getGroupUpdateRequest(ctx context.Context, plan groupData, state groupData)
 		}
 	}
 
+	customSchemasReqs, diags := getCustomSchemasUpdateRequest(plan.CustomSchemas, state.CustomSchemas)
+	if diags.HasError() {
+		return reqs, diags
+	}
+	reqs = append(reqs, customSchemasReqs...)
+
 	return reqs, diags
 }
+
+// customSchemasValueFrom returns the custom schemas of the response, which are null if the response does not have any
+func customSchemasValueFrom(cS string) types.String {
+	if len(cS) > 0 {
+		return types.StringValue(cS)
+	}
+	return types.StringNull()
+}
@@ -2,8 +2,10 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
@@ -12,7 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
-func groupBaseValueFrom(ctx context.Context, g groups.Group) (groupBaseData, diag.Diagnostics) {
+func groupBaseValueFrom(ctx context.Context, g groups.Group, cS string) (groupBaseData, diag.Diagnostics) {
 
 	var diagnostics, diags diag.Diagnostics
 
@@ -45,17 +47,22 @@ func groupBaseValueFrom(ctx context.Context, g groups.Group) (groupBaseData, dia
 
 	group.GroupExtension = groupExtObj
 
+	group.CustomSchemas = customSchemasValueFrom(cS)
+
+	group.Meta, diags = metaValueFrom(ctx, g.Meta)
+	diagnostics.Append(diags...)
+
 	return group, diagnostics
 }
 
-func groupBasesValueFrom(ctx context.Context, g groups.GroupsResponse) ([]groupBaseData, diag.Diagnostics) {
+func groupBasesValueFrom(ctx context.Context, g groups.GroupsResponse, customSchemas map[int]string) ([]groupBaseData, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
 
 	groups := []groupBaseData{}
 
-	for _, groupRes := range g.Resources {
+	for i, groupRes := range g.Resources {
 
-		group, diags := groupBaseValueFrom(ctx, groupRes)
+		group, diags := groupBaseValueFrom(ctx, groupRes, customSchemas[i])
 		groups = append(groups, group)
 		diagnostics.Append(diags...)
 
@@ -64,7 +71,7 @@ func groupBasesValueFrom(ctx context.Context, g groups.GroupsResponse) ([]groupB
 	return groups, diagnostics
 }
 
-func getGroupBaseRequest(ctx context.Context, plan groupBaseData) (*groups.Group, diag.Diagnostics) {
+func getGroupBaseRequest(ctx context.Context, plan groupBaseData) (*groups.Group, string, diag.Diagnostics) {
 
 	var diagnostics diag.Diagnostics
 
@@ -72,7 +79,7 @@ func getGroupBaseRequest(ctx context.Context, plan groupBaseData) (*groups.Group
 	diags := plan.Schemas.ElementsAs(ctx, &schemas, true)
 	diagnostics.Append(diags...)
 	if diagnostics.HasError() {
-		return nil, diagnostics
+		return nil, "", diagnostics
 	}
 
 	args := &groups.Group{
@@ -89,13 +96,18 @@ func getGroupBaseRequest(ctx context.Context, plan groupBaseData) (*groups.Group
 		})
 		diagnostics.Append(diags...)
 		if diagnostics.HasError() {
-			return nil, diagnostics
+			return nil, "", diagnostics
 		}
 
 		args.GroupExtension = &groupExtension
 	}
 
-	return args, diagnostics
+	var customSchemas string
+	if !plan.CustomSchemas.IsNull() {
+		customSchemas = plan.CustomSchemas.ValueString()
+	}
+
+	return args, customSchemas, diagnostics
 }
 
 func getGroupBaseUpdateRequest(ctx context.Context, plan groupBaseData, state groupBaseData) ([]generic.PatchRequest, diag.Diagnostics) {
@@ -186,5 +198,39 @@ func getGroupBaseUpdateRequest(ctx context.Context, plan groupBaseData, state gr
 		}
 	}
 
+	customSchemasReqs, diags := getCustomSchemasUpdateRequest(plan.CustomSchemas, state.CustomSchemas)
+	if diags.HasError() {
+		return reqs, diags
+	}
+	reqs = append(reqs, customSchemasReqs...)
+
 	return reqs, diags
 }
+
+// unmodifiedGroupBaseVersion reads the current version of the group after a failed precondition and reports whether
+// the attributes managed by sci_group_base are unchanged since the state was read.
+// The members of the group are not managed by the resource, hence assignments, e.g. by sci_group_assignment, are no conflict.
+func unmodifiedGroupBaseVersion(ctx context.Context, client *cli.SciClient, state groupBaseData) (string, bool, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+
+	res, customSchemasRes, err := client.Group.GetByGroupId(ctx, state.Id.ValueString())
+	if err != nil {
+		diagnostics.AddError("Error retrieving group", fmt.Sprintf("%s", err))
+		return "", false, diagnostics
+	}
+
+	current, diags := groupBaseValueFrom(ctx, res, customSchemasRes)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return "", false, diagnostics
+	}
+
+	changes, diags := getGroupBaseUpdateRequest(ctx, current, state)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return "", false, diagnostics
+	}
+
+	return res.Meta.Version, len(changes) == 0, diagnostics
+}
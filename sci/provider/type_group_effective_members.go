@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// default depth up to which nested groups are expanded
+const defaultGroupMaxDepth = 10
+
+type groupEffectiveMembersData struct {
+	GroupId   types.String `tfsdk:"group_id"`
+	MaxDepth  types.Int64  `tfsdk:"max_depth"`
+	UserIds   types.List   `tfsdk:"user_ids"`
+	Truncated types.Bool   `tfsdk:"truncated"`
+}
+
+// groupWalker reads the groups needed to walk nested memberships, every group is read at most once
+type groupWalker struct {
+	client *cli.SciClient
+	// the groups read so far, keyed by ID, IDs which do not belong to a group are stored with a nil value
+	groups map[string]*groups.Group
+}
+
+func newGroupWalker(client *cli.SciClient) *groupWalker {
+	return &groupWalker{
+		client: client,
+		groups: map[string]*groups.Group{},
+	}
+}
+
+// get returns the group with the given ID, or nil if the ID does not belong to a group
+func (w *groupWalker) get(ctx context.Context, groupId string) (*groups.Group, error) {
+
+	if group, found := w.groups[groupId]; found {
+		return group, nil
+	}
+
+	res, _, err := w.client.Group.GetByGroupId(ctx, groupId)
+	if err != nil {
+		if cli.ScimErrorStatus(err) != http.StatusNotFound {
+			return nil, err
+		}
+		w.groups[groupId] = nil
+		return nil, nil
+	}
+
+	w.groups[groupId] = &res
+	return &res, nil
+}
+
+// mayBeGroup reports whether the member has to be read to find out if it is a group
+func mayBeGroup(member groups.GroupMember) bool {
+	return len(member.Type) == 0 || member.Type == "Group"
+}
+
+// findGroupCycle checks whether adding the members to the group creates a cycle
+// the path of the cycle is returned, starting and ending with the group, or nil if no cycle is found
+func findGroupCycle(ctx context.Context, w *groupWalker, groupId string, members []groups.GroupMember) ([]string, error) {
+
+	visited := map[string]bool{}
+
+	var walk func(memberId string) ([]string, error)
+	walk = func(memberId string) ([]string, error) {
+
+		if memberId == groupId {
+			return []string{memberId}, nil
+		}
+
+		if visited[memberId] {
+			return nil, nil
+		}
+		visited[memberId] = true
+
+		group, err := w.get(ctx, memberId)
+		if err != nil || group == nil {
+			return nil, err
+		}
+
+		for _, member := range group.GroupMembers {
+			if !mayBeGroup(member) {
+				continue
+			}
+
+			cycle, err := walk(member.Value)
+			if err != nil || cycle != nil {
+				return append([]string{memberId}, cycle...), err
+			}
+		}
+
+		return nil, nil
+	}
+
+	for _, member := range members {
+		if !mayBeGroup(member) {
+			continue
+		}
+
+		cycle, err := walk(member.Value)
+		if err != nil {
+			return nil, err
+		}
+		if cycle != nil {
+			return append([]string{groupId}, cycle...), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// validateGroupCycles returns an error if adding the members to the group creates a cycle
+// failures to read the groups are reported as warnings, since they do not prove the existence of a cycle
+func validateGroupCycles(ctx context.Context, client *cli.SciClient, groupId string, members []groups.GroupMember, attributePath path.Path) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if client == nil || len(members) == 0 {
+		return diags
+	}
+
+	cycle, err := findGroupCycle(ctx, newGroupWalker(client), groupId, members)
+	if err != nil {
+		diags.AddAttributeWarning(attributePath, "Unable to validate nested group memberships", fmt.Sprintf("%s", err))
+		return diags
+	}
+
+	if cycle != nil {
+		diags.AddAttributeError(
+			attributePath,
+			"Group membership cycle",
+			fmt.Sprintf("The members of group %s cannot be applied, as they create the membership cycle : %s.\n"+
+				"A group cannot be a direct or nested member of itself.", groupId, strings.Join(cycle, " -> ")),
+		)
+	}
+
+	return diags
+}
+
+// getGroupEffectiveMembers expands the nested groups of the group up to the maximum depth
+// the IDs of the users are returned sorted and de-duplicated, together with a flag set if groups deeper than the maximum depth were not expanded
+func getGroupEffectiveMembers(ctx context.Context, w *groupWalker, groupId string, maxDepth int) ([]string, bool, error) {
+
+	users := map[string]bool{}
+	expanded := map[string]bool{groupId: true}
+	truncated := false
+
+	level := []string{groupId}
+
+	for depth := 1; len(level) > 0; depth++ {
+
+		next := []string{}
+
+		for _, id := range level {
+			group, err := w.get(ctx, id)
+			if err != nil {
+				return nil, false, err
+			}
+			if group == nil {
+				if id == groupId {
+					return nil, false, fmt.Errorf("group %s is not found", groupId)
+				}
+				// the member is not a group
+				users[id] = true
+				continue
+			}
+
+			for _, member := range group.GroupMembers {
+				switch {
+				case member.Type == "User":
+					users[member.Value] = true
+				case expanded[member.Value]:
+					// the group has already been expanded, possibly as part of a cycle
+				case depth >= maxDepth && member.Type == "Group":
+					truncated = true
+				case depth >= maxDepth:
+					// members of unknown type beyond the maximum depth are only reported if they are no groups
+					nested, err := w.get(ctx, member.Value)
+					if err != nil {
+						return nil, false, err
+					}
+					if nested == nil {
+						users[member.Value] = true
+					} else {
+						truncated = true
+					}
+				default:
+					expanded[member.Value] = true
+					next = append(next, member.Value)
+				}
+			}
+		}
+
+		level = next
+	}
+
+	userIds := make([]string, 0, len(users))
+	for id := range users {
+		userIds = append(userIds, id)
+	}
+	slices.Sort(userIds)
+
+	return userIds, truncated, nil
+}
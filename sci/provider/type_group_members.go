@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type groupMembersData struct {
+	Id            types.String `tfsdk:"id"`
+	GroupId       types.String `tfsdk:"group_id"`
+	MemberIds     types.Set    `tfsdk:"member_ids"`
+	Authoritative types.Bool   `tfsdk:"authoritative"`
+}
+
+// groupMembersValueFrom maps the members of the group to the state
+// an authoritative resource reflects all members of the group, otherwise only the managed members which are still assigned are kept
+// if managedIds is nil, e.g. after an import, all members of the group are adopted
+func groupMembersValueFrom(ctx context.Context, g groups.Group, managedIds []string, authoritative bool) (groupMembersData, diag.Diagnostics) {
+
+	memberIds := []string{}
+
+	for _, member := range g.GroupMembers {
+		if authoritative || managedIds == nil || slices.Contains(managedIds, member.Value) {
+			memberIds = append(memberIds, member.Value)
+		}
+	}
+
+	members, diags := types.SetValueFrom(ctx, types.StringType, memberIds)
+
+	return groupMembersData{
+		Id:            types.StringValue(g.Id),
+		GroupId:       types.StringValue(g.Id),
+		MemberIds:     members,
+		Authoritative: types.BoolValue(authoritative),
+	}, diags
+}
+
+// getGroupMembersDiff computes the members to be added to and removed from the group
+// plannedIds are the members to be assigned, managedIds the members assigned by a previous apply
+// unknown members are only removed if the resource is authoritative
+func getGroupMembersDiff(current []groups.GroupMember, plannedIds []string, managedIds []string, authoritative bool) (add []string, remove []string) {
+
+	currentIds := make(map[string]bool, len(current))
+	for _, member := range current {
+		currentIds[member.Value] = true
+	}
+
+	planned := make(map[string]bool, len(plannedIds))
+	for _, memberId := range plannedIds {
+		planned[memberId] = true
+
+		if !currentIds[memberId] {
+			add = append(add, memberId)
+		}
+	}
+
+	for _, member := range current {
+		if planned[member.Value] {
+			continue
+		}
+
+		if authoritative || slices.Contains(managedIds, member.Value) {
+			remove = append(remove, member.Value)
+		}
+	}
+
+	slices.Sort(add)
+	slices.Sort(remove)
+
+	return slices.Compact(add), slices.Compact(remove)
+}
+
+// applyGroupMembersDiff removes and adds the members, the client splits large changes into several requests
+// the group is returned as read after the last applied request, the flag reports whether any of the changes have been applied
+func applyGroupMembersDiff(ctx context.Context, client *cli.SciClient, group groups.Group, add []string, remove []string) (groups.Group, bool, error) {
+
+	applied := false
+
+	if len(remove) > 0 {
+		res, _, err := client.Group.RemoveMembers(ctx, group.Id, remove)
+		if errors.As(err, &cli.GroupPartiallyUpdatedError{}) {
+			return res, true, err
+		}
+		if err != nil {
+			return group, false, err
+		}
+		group, applied = res, true
+	}
+
+	if len(add) > 0 {
+		members := make([]groups.GroupMember, len(add))
+		for i, memberId := range add {
+			members[i] = groups.GroupMember{Value: memberId}
+		}
+
+		res, _, err := client.Group.AddMembers(ctx, group.Id, members)
+		if errors.As(err, &cli.GroupPartiallyUpdatedError{}) {
+			return res, true, err
+		}
+		if err != nil {
+			return group, applied, err
+		}
+		group, applied = res, true
+	}
+
+	return group, applied, nil
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// number of users read with a single request when all users of the tenant are listed
+const usersPageSize = 100
+
+type inactiveUsersData struct {
+	LastModifiedBefore types.String `tfsdk:"last_modified_before"`
+	Values             types.List   `tfsdk:"values"`
+}
+
+type inactiveUserData struct {
+	Id          types.String `tfsdk:"id"`
+	UserName    types.String `tfsdk:"user_name"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Active      types.Bool   `tfsdk:"active"`
+	Meta        types.Object `tfsdk:"meta"`
+}
+
+var inactiveUserObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":           types.StringType,
+		"user_name":    types.StringType,
+		"display_name": types.StringType,
+		"active":       types.BoolType,
+		"meta": types.ObjectType{
+			AttrTypes: scimMetaObjType,
+		},
+	},
+}
+
+// lastModifiedBefore reports whether the resource was last modified before the given time
+// resources without a modification time are never reported, as their age is unknown
+func lastModifiedBefore(meta users.Meta, before time.Time) (bool, error) {
+
+	if len(meta.LastModified) == 0 {
+		return false, nil
+	}
+
+	lastModified, err := time.Parse(time.RFC3339, meta.LastModified)
+	if err != nil {
+		return false, fmt.Errorf("invalid last modification time %q: %w", meta.LastModified, err)
+	}
+
+	return lastModified.Before(before), nil
+}
+
+func inactiveUsersValueFrom(ctx context.Context, tenantUsers []users.User, before time.Time) ([]inactiveUserData, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	inactiveUsers := []inactiveUserData{}
+
+	for _, u := range tenantUsers {
+
+		inactive, err := lastModifiedBefore(u.Meta, before)
+		if err != nil {
+			diagnostics.AddError("Error reading user", fmt.Sprintf("user %s: %s", u.Id, err))
+			continue
+		}
+
+		if !inactive {
+			continue
+		}
+
+		user := inactiveUserData{
+			Id:       types.StringValue(u.Id),
+			UserName: types.StringValue(u.UserName),
+			Active:   types.BoolValue(u.Active),
+		}
+
+		if len(u.DisplayName) > 0 {
+			user.DisplayName = types.StringValue(u.DisplayName)
+		}
+
+		meta, diags := metaValueFrom(ctx, u.Meta)
+		diagnostics.Append(diags...)
+		user.Meta = meta
+
+		inactiveUsers = append(inactiveUsers, user)
+	}
+
+	return inactiveUsers, diagnostics
+}
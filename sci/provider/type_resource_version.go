@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// privateVersionKey is the key of the private state holding the version of a user or group
+// the version is sent as precondition when the resource is updated or deleted, if the tenant supports ETags
+const privateVersionKey = "version"
+
+// privateStateGetter is implemented by the private state of the resource requests
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is implemented by the private state of the resource responses
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getPrivateVersion reads the version of the resource from the private state, an empty version is returned if none is stored
+func getPrivateVersion(ctx context.Context, private privateStateGetter) (string, diag.Diagnostics) {
+
+	value, diags := private.GetKey(ctx, privateVersionKey)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+
+	var version string
+	if err := json.Unmarshal(value, &version); err != nil {
+		diags.AddError("Error reading private state", fmt.Sprintf("%s", err))
+	}
+
+	return version, diags
+}
+
+// setPrivateVersion stores the version of the resource in the private state, an empty version removes the stored version
+func setPrivateVersion(ctx context.Context, private privateStateSetter, version string) diag.Diagnostics {
+
+	if len(version) == 0 {
+		return private.SetKey(ctx, privateVersionKey, nil)
+	}
+
+	value, err := json.Marshal(version)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error writing private state", fmt.Sprintf("%s", err))
+		return diags
+	}
+
+	return private.SetKey(ctx, privateVersionKey, value)
+}
+
+// versionErrorDiagnostics maps the error returned while updating or deleting a user or group to diagnostics
+// a failed precondition means that the resource has been modified since it was read by Terraform
+func versionErrorDiagnostics(summary string, resourceName string, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if cli.IsPreconditionFailed(err) {
+		diags.AddError(
+			fmt.Sprintf("%s: %s modified outside of Terraform", summary, resourceName),
+			fmt.Sprintf("The %s has been modified outside of Terraform since it was last read. Refresh the state, e.g. with terraform plan or terraform apply -refresh-only, and retry the operation.\n\n%s", resourceName, err),
+		)
+		return diags
+	}
+
+	diags.AddError(summary, fmt.Sprintf("%s", err))
+	return diags
+}
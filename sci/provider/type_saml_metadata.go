@@ -0,0 +1,552 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// saml2ConfigFromMetadata maps the values derived from the metadata of a service provider to the SAML configuration
+// endpoints with bindings which are not supported by the tenant are ignored, the default Name ID format is the first supported format
+func saml2ConfigFromMetadata(ctx context.Context, metadata utils.SamlMetadata) (AppSaml2ConfigData, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+
+	config := AppSaml2ConfigData{
+		AcsEndpoints:             types.ListNull(acsEndpointsObjType),
+		SloEndpoints:             types.ListNull(appSaml2SloEndpointObjType),
+		CertificatesForSigning:   types.ListNull(saml2SigningCertificateObjType),
+		CertificateForEncryption: types.ObjectNull(saml2EncryptionCertificateObjType.AttrTypes),
+		DefaultNameIdFormat:      types.StringNull(),
+	}
+
+	acsEndpoints := []AcsSsoEndpointData{}
+	for _, endpoint := range metadata.AcsEndpoints {
+		if slices.Contains(endpointBindingValues, endpoint.BindingName) {
+			acsEndpoints = append(acsEndpoints, AcsSsoEndpointData{
+				BindingName: types.StringValue(endpoint.BindingName),
+				Location:    types.StringValue(endpoint.Location),
+				Index:       types.Int32Value(endpoint.Index),
+				IsDefault:   types.BoolValue(endpoint.IsDefault),
+			})
+		}
+	}
+
+	if len(acsEndpoints) > 0 {
+		var diags diag.Diagnostics
+		config.AcsEndpoints, diags = types.ListValueFrom(ctx, acsEndpointsObjType, acsEndpoints)
+		diagnostics.Append(diags...)
+	}
+
+	sloEndpoints := []AppSloEndpointData{}
+	for _, endpoint := range metadata.SloEndpoints {
+		if slices.Contains(endpointBindingValues, endpoint.BindingName) {
+			sloEndpoint := AppSloEndpointData{
+				BindingName:      types.StringValue(endpoint.BindingName),
+				Location:         types.StringValue(endpoint.Location),
+				ResponseLocation: types.StringNull(),
+			}
+			if len(endpoint.ResponseLocation) > 0 {
+				sloEndpoint.ResponseLocation = types.StringValue(endpoint.ResponseLocation)
+			}
+			sloEndpoints = append(sloEndpoints, sloEndpoint)
+		}
+	}
+
+	if len(sloEndpoints) > 0 {
+		var diags diag.Diagnostics
+		config.SloEndpoints, diags = types.ListValueFrom(ctx, appSaml2SloEndpointObjType, sloEndpoints)
+		diagnostics.Append(diags...)
+	}
+
+	if len(metadata.CertificatesForSigning) > 0 {
+		var diags diag.Diagnostics
+		config.CertificatesForSigning, diags = types.ListValueFrom(ctx, saml2SigningCertificateObjType, metadata.CertificatesForSigning)
+		diagnostics.Append(diags...)
+	}
+
+	if metadata.CertificateForEncryption != nil {
+		var diags diag.Diagnostics
+		config.CertificateForEncryption, diags = types.ObjectValueFrom(ctx, saml2EncryptionCertificateObjType.AttrTypes, metadata.CertificateForEncryption)
+		diagnostics.Append(diags...)
+	}
+
+	for _, nameIdFormat := range metadata.NameIdFormats {
+		if slices.Contains(saml2AppNameIdFormatValues, nameIdFormat) {
+			config.DefaultNameIdFormat = types.StringValue(nameIdFormat)
+			break
+		}
+	}
+
+	return config, diagnostics
+}
+
+// saml2IdPConfigFromMetadata maps the values derived from the metadata of an identity provider to the SAML configuration of a corporate IdP
+// endpoints with bindings which are not supported by the tenant are ignored
+func saml2IdPConfigFromMetadata(ctx context.Context, metadata utils.SamlIdpMetadata) (saml2ConfigData, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+
+	config := saml2ConfigData{
+		SigningCertificates: types.ListNull(saml2SigningCertificateObjType),
+		SsoEndpoints:        types.ListNull(saml2SsoEndpointObjType),
+		SloEndpoints:        types.ListNull(saml2SloEndpointObjType),
+	}
+
+	ssoEndpoints := []corporateidps.SAML2SSOEndpoint{}
+	for _, endpoint := range metadata.SsoEndpoints {
+		if slices.Contains(endpointBindingValues, endpoint.BindingName) {
+			ssoEndpoints = append(ssoEndpoints, endpoint)
+		}
+	}
+
+	if len(ssoEndpoints) > 0 {
+		var diags diag.Diagnostics
+		config.SsoEndpoints, diags = types.ListValueFrom(ctx, saml2SsoEndpointObjType, ssoEndpoints)
+		diagnostics.Append(diags...)
+	}
+
+	sloEndpoints := []sloEndpointData{}
+	for _, endpoint := range metadata.SloEndpoints {
+		if slices.Contains(endpointBindingValues, endpoint.BindingName) {
+			sloEndpoint := sloEndpointData{
+				BindingName:      types.StringValue(endpoint.BindingName),
+				Location:         types.StringValue(endpoint.Location),
+				ResponseLocation: types.StringNull(),
+				Default:          types.BoolValue(endpoint.IsDefault),
+			}
+			if len(endpoint.ResponseLocation) > 0 {
+				sloEndpoint.ResponseLocation = types.StringValue(endpoint.ResponseLocation)
+			}
+			sloEndpoints = append(sloEndpoints, sloEndpoint)
+		}
+	}
+
+	if len(sloEndpoints) > 0 {
+		var diags diag.Diagnostics
+		config.SloEndpoints, diags = types.ListValueFrom(ctx, saml2SloEndpointObjType, sloEndpoints)
+		diagnostics.Append(diags...)
+	}
+
+	if len(metadata.CertificatesForSigning) > 0 {
+		var diags diag.Diagnostics
+		config.SigningCertificates, diags = types.ListValueFrom(ctx, saml2SigningCertificateObjType, metadata.CertificatesForSigning)
+		diagnostics.Append(diags...)
+	}
+
+	return config, diagnostics
+}
+
+// samlMetadataModifier plans the value of an attribute of a SAML configuration derived from the configured metadata
+// a configured value takes precedence, without metadata the attribute keeps the configured value
+type samlMetadataModifier struct {
+	// derive returns the derived values of the SAML configuration by attribute name
+	// metadataSet reports whether the metadata is configured, derived is nil if the metadata is unknown or invalid, which the validators of the metadata report
+	derive func(ctx context.Context, config tfsdk.Config, saml2ConfigPath path.Path) (derived map[string]attr.Value, metadataSet bool, diags diag.Diagnostics)
+}
+
+// samlMetadataValue derives the attribute from the metadata_xml of the SAML configuration of an application
+func samlMetadataValue() samlMetadataModifier {
+	return samlMetadataModifier{derive: derivedAppSaml2Config}
+}
+
+// idpSamlMetadataValue derives the attribute from the metadata_xml or metadata_file of the SAML configuration of a corporate IdP
+func idpSamlMetadataValue() samlMetadataModifier {
+	return samlMetadataModifier{derive: derivedIdPSaml2Config}
+}
+
+func (m samlMetadataModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m samlMetadataModifier) MarkdownDescription(_ context.Context) string {
+	return "The value is derived from the metadata of the SAML configuration, unless it is configured."
+}
+
+func derivedAppSaml2Config(ctx context.Context, config tfsdk.Config, saml2ConfigPath path.Path) (map[string]attr.Value, bool, diag.Diagnostics) {
+
+	var metadataXml, signingCertificate types.String
+	diags := config.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_xml"), &metadataXml)
+	diags.Append(config.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_signing_certificate"), &signingCertificate)...)
+	if diags.HasError() || metadataXml.IsNull() {
+		return nil, false, diags
+	}
+
+	if metadataXml.IsUnknown() || signingCertificate.IsUnknown() {
+		return nil, true, diags
+	}
+
+	// metadata which cannot be verified is reported when it is planned, see checkAppSamlMetadata
+	metadata, err := appSamlMetadataFrom(metadataXml.ValueString(), signingCertificate)
+	if err != nil {
+		return nil, true, diags
+	}
+
+	saml2Config, diags := saml2ConfigFromMetadata(ctx, metadata)
+	return map[string]attr.Value{
+		"acs_endpoints":          saml2Config.AcsEndpoints,
+		"slo_endpoints":          saml2Config.SloEndpoints,
+		"signing_certificates":   saml2Config.CertificatesForSigning,
+		"encryption_certificate": saml2Config.CertificateForEncryption,
+		"default_name_id_format": saml2Config.DefaultNameIdFormat,
+	}, true, diags
+}
+
+func derivedIdPSaml2Config(ctx context.Context, config tfsdk.Config, saml2ConfigPath path.Path) (map[string]attr.Value, bool, diag.Diagnostics) {
+
+	var metadataXml, metadataFile, signingCertificate types.String
+	diags := config.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_xml"), &metadataXml)
+	diags.Append(config.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_file"), &metadataFile)...)
+	diags.Append(config.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_signing_certificate"), &signingCertificate)...)
+	if diags.HasError() || (metadataXml.IsNull() && metadataFile.IsNull()) {
+		return nil, false, diags
+	}
+
+	document := idpSamlMetadataDocument(metadataXml, metadataFile)
+	if document.IsUnknown() || signingCertificate.IsUnknown() {
+		return nil, true, diags
+	}
+
+	// metadata which cannot be verified is reported when it is planned, see checkIdPSamlMetadata
+	metadata, err := idpSamlMetadataFrom(document.ValueString(), signingCertificate)
+	if err != nil {
+		return nil, true, diags
+	}
+
+	saml2Config, diags := saml2IdPConfigFromMetadata(ctx, metadata)
+	return map[string]attr.Value{
+		"sso_endpoints":        saml2Config.SsoEndpoints,
+		"slo_endpoints":        saml2Config.SloEndpoints,
+		"signing_certificates": saml2Config.SigningCertificates,
+	}, true, diags
+}
+
+// appSamlMetadataFrom parses the metadata of an application, with a signing certificate the values are derived from the verified EntityDescriptor only
+// the signature is verified as of the start of the validity of the certificate, as its expiry is only checked when the metadata is planned
+func appSamlMetadataFrom(document string, signingCertificate types.String) (utils.SamlMetadata, error) {
+
+	metadata, err := utils.ParseSamlMetadata(document)
+	if err != nil || signingCertificate.IsNull() {
+		return metadata, err
+	}
+
+	certificate, err := utils.ParseCertificate(signingCertificate.ValueString())
+	if err != nil {
+		return utils.SamlMetadata{}, err
+	}
+
+	return metadata.VerifySignature(certificate, certificate.NotBefore)
+}
+
+// idpSamlMetadataFrom parses the metadata of a corporate IdP, like appSamlMetadataFrom
+func idpSamlMetadataFrom(document string, signingCertificate types.String) (utils.SamlIdpMetadata, error) {
+
+	metadata, err := utils.ParseSamlIdpMetadata(document)
+	if err != nil || signingCertificate.IsNull() {
+		return metadata, err
+	}
+
+	certificate, err := utils.ParseCertificate(signingCertificate.ValueString())
+	if err != nil {
+		return utils.SamlIdpMetadata{}, err
+	}
+
+	return metadata.VerifySignature(certificate, certificate.NotBefore)
+}
+
+// idpSamlMetadataDocument returns the metadata of the SAML configuration of a corporate IdP, which is configured inline or as file
+// the value is unknown if the file cannot be read, which the validator of metadata_file reports
+func idpSamlMetadataDocument(metadataXml types.String, metadataFile types.String) types.String {
+
+	if !metadataXml.IsNull() || metadataFile.IsNull() || metadataFile.IsUnknown() {
+		return metadataXml
+	}
+
+	content, err := os.ReadFile(metadataFile.ValueString())
+	if err != nil {
+		return types.StringUnknown()
+	}
+
+	return types.StringValue(string(content))
+}
+
+func (m samlMetadataModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	derived, metadataSet, diags := m.derive(ctx, req.Config, req.Path.ParentPath())
+	resp.Diagnostics.Append(diags...)
+
+	if !metadataSet {
+		resp.PlanValue = req.ConfigValue
+		return
+	}
+
+	if derived == nil {
+		resp.PlanValue = types.ListUnknown(req.PlanValue.ElementType(ctx))
+		return
+	}
+
+	value, ok := derived[attributeName(req.Path)].(types.List)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(req.Path, "Unexpected attribute", fmt.Sprintf("The attribute %s is not derived from the SAML metadata.", req.Path))
+		return
+	}
+	resp.PlanValue = value
+}
+
+func (m samlMetadataModifier) PlanModifyObject(ctx context.Context, req planmodifier.ObjectRequest, resp *planmodifier.ObjectResponse) {
+
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	derived, metadataSet, diags := m.derive(ctx, req.Config, req.Path.ParentPath())
+	resp.Diagnostics.Append(diags...)
+
+	if !metadataSet {
+		resp.PlanValue = req.ConfigValue
+		return
+	}
+
+	if derived == nil {
+		resp.PlanValue = types.ObjectUnknown(req.PlanValue.AttributeTypes(ctx))
+		return
+	}
+
+	value, ok := derived[attributeName(req.Path)].(types.Object)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(req.Path, "Unexpected attribute", fmt.Sprintf("The attribute %s is not derived from the SAML metadata.", req.Path))
+		return
+	}
+	resp.PlanValue = value
+}
+
+func (m samlMetadataModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	// without a supported value in the metadata, the value is determined like without metadata
+	derived, _, diags := m.derive(ctx, req.Config, req.Path.ParentPath())
+	resp.Diagnostics.Append(diags...)
+
+	if value, ok := derived[attributeName(req.Path)].(types.String); ok && !value.IsNull() {
+		resp.PlanValue = value
+	}
+}
+
+// attributeName returns the name of the attribute the path points to
+func attributeName(p path.Path) string {
+	step, _ := p.Steps().LastStep()
+	if name, ok := step.(path.PathStepAttributeName); ok {
+		return string(name)
+	}
+	return ""
+}
+
+// samlMetadataChecks are the checks of parsed metadata, which depend on the time
+type samlMetadataChecks interface {
+	Signed() bool
+	CheckValidity(now time.Time) error
+}
+
+// samlMetadataPlanned reports whether one of the attributes of the SAML configuration is planned to change, or the resource is created
+// the metadata is only checked when it is planned, as the checks depend on the time and must not fail unrelated plans, e.g. to destroy the resource
+func samlMetadataPlanned(ctx context.Context, req resource.ModifyPlanRequest, saml2ConfigPath path.Path, attributes ...string) (bool, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	if req.State.Raw.IsNull() {
+		return true, diagnostics
+	}
+
+	for _, attribute := range attributes {
+		var planned, current attr.Value
+		diagnostics.Append(req.Plan.GetAttribute(ctx, saml2ConfigPath.AtName(attribute), &planned)...)
+		diagnostics.Append(req.State.GetAttribute(ctx, saml2ConfigPath.AtName(attribute), &current)...)
+		if diagnostics.HasError() {
+			return false, diagnostics
+		}
+
+		if !planned.Equal(current) {
+			return true, diagnostics
+		}
+	}
+
+	return false, diagnostics
+}
+
+// checkPlannedSamlMetadata checks metadata which is planned, it must not have expired and its certificates must be valid
+// metadata which has not been verified with a signing certificate is accepted with a warning, as it is unknown who issued it
+func checkPlannedSamlMetadata(metadata samlMetadataChecks, metadataPath path.Path, verified bool, now time.Time) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if err := metadata.CheckValidity(now); err != nil {
+		diagnostics.AddAttributeError(metadataPath, "Invalid SAML Metadata", err.Error())
+	}
+
+	if verified {
+		return diagnostics
+	}
+
+	if metadata.Signed() {
+		diagnostics.AddAttributeWarning(
+			metadataPath,
+			"SAML Metadata Signature Not Verified",
+			"The metadata is signed, but the signature is not verified without a trusted certificate. Configure the metadata_signing_certificate to verify who signed the metadata.",
+		)
+	} else {
+		diagnostics.AddAttributeWarning(
+			metadataPath,
+			"SAML Metadata Not Signed",
+			"The metadata is not signed, so it cannot be verified who issued it. Use signed metadata and configure the metadata_signing_certificate to only accept metadata signed with it.",
+		)
+	}
+
+	return diagnostics
+}
+
+// plannedSigningCertificate parses the signing certificate of planned metadata, nil is returned if no certificate is configured
+func plannedSigningCertificate(signingCertificate types.String, signingCertificatePath path.Path) (*x509.Certificate, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	if signingCertificate.IsNull() {
+		return nil, diagnostics
+	}
+
+	certificate, err := utils.ParseCertificate(signingCertificate.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(signingCertificatePath, "Invalid Certificate", err.Error())
+	}
+
+	return certificate, diagnostics
+}
+
+// checkAppSamlMetadata checks the metadata_xml of the SAML configuration of an application when it or its signing certificate are planned
+func checkAppSamlMetadata(ctx context.Context, req resource.ModifyPlanRequest, saml2ConfigPath path.Path, now time.Time) diag.Diagnostics {
+
+	var metadataXml, signingCertificate types.String
+	diags := req.Plan.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_xml"), &metadataXml)
+	diags.Append(req.Plan.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_signing_certificate"), &signingCertificate)...)
+	if diags.HasError() || metadataXml.IsNull() || metadataXml.IsUnknown() || signingCertificate.IsUnknown() {
+		return diags
+	}
+
+	planned, plannedDiags := samlMetadataPlanned(ctx, req, saml2ConfigPath, "metadata_xml", "metadata_signing_certificate")
+	diags.Append(plannedDiags...)
+	if diags.HasError() || !planned {
+		return diags
+	}
+
+	diags.Append(checkPlannedAppSamlMetadata(metadataXml.ValueString(), signingCertificate, saml2ConfigPath.AtName("metadata_xml"), saml2ConfigPath.AtName("metadata_signing_certificate"), now)...)
+	return diags
+}
+
+// checkPlannedAppSamlMetadata checks the planned metadata of an application, with a signing certificate it must be signed with it
+func checkPlannedAppSamlMetadata(document string, signingCertificate types.String, metadataPath path.Path, signingCertificatePath path.Path, now time.Time) diag.Diagnostics {
+
+	// invalid metadata is reported by the validator of metadata_xml
+	metadata, err := utils.ParseSamlMetadata(document)
+	if err != nil {
+		return nil
+	}
+
+	certificate, diags := plannedSigningCertificate(signingCertificate, signingCertificatePath)
+	if diags.HasError() {
+		return diags
+	}
+
+	if certificate != nil {
+		metadata, err = metadata.VerifySignature(certificate, now)
+		if err != nil {
+			diags.AddAttributeError(signingCertificatePath, "Invalid SAML Metadata Signature", err.Error())
+			return diags
+		}
+	}
+
+	diags.Append(checkPlannedSamlMetadata(metadata, metadataPath, certificate != nil, now)...)
+	return diags
+}
+
+// checkIdPSamlMetadata checks the metadata_xml or metadata_file of the SAML configuration of a corporate IdP when it or its signing certificate are planned
+// as the content of a metadata file is not kept in the state, a change of the derived configuration is a change of the metadata as well
+func checkIdPSamlMetadata(ctx context.Context, req resource.ModifyPlanRequest, saml2ConfigPath path.Path, now time.Time) diag.Diagnostics {
+
+	var metadataXml, metadataFile, signingCertificate types.String
+	diags := req.Plan.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_xml"), &metadataXml)
+	diags.Append(req.Plan.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_file"), &metadataFile)...)
+	diags.Append(req.Plan.GetAttribute(ctx, saml2ConfigPath.AtName("metadata_signing_certificate"), &signingCertificate)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	signingCertificatePath := saml2ConfigPath.AtName("metadata_signing_certificate")
+	if metadataXml.IsNull() && metadataFile.IsNull() {
+		if !signingCertificate.IsNull() {
+			diags.AddAttributeError(signingCertificatePath, "Missing SAML Metadata", "The metadata_signing_certificate requires the metadata_xml or the metadata_file.")
+		}
+		return diags
+	}
+
+	document := idpSamlMetadataDocument(metadataXml, metadataFile)
+	if document.IsUnknown() || signingCertificate.IsUnknown() {
+		return diags
+	}
+
+	planned, plannedDiags := samlMetadataPlanned(ctx, req, saml2ConfigPath, "metadata_xml", "metadata_file", "metadata_signing_certificate", "sso_endpoints", "slo_endpoints", "signing_certificates")
+	diags.Append(plannedDiags...)
+	if diags.HasError() || !planned {
+		return diags
+	}
+
+	metadataPath := saml2ConfigPath.AtName("metadata_xml")
+	if metadataXml.IsNull() {
+		metadataPath = saml2ConfigPath.AtName("metadata_file")
+	}
+
+	diags.Append(checkPlannedIdPSamlMetadata(document.ValueString(), signingCertificate, metadataPath, signingCertificatePath, now)...)
+	return diags
+}
+
+// checkPlannedIdPSamlMetadata checks the planned metadata of a corporate IdP, like checkPlannedAppSamlMetadata
+func checkPlannedIdPSamlMetadata(document string, signingCertificate types.String, metadataPath path.Path, signingCertificatePath path.Path, now time.Time) diag.Diagnostics {
+
+	// invalid metadata is reported by the validators of metadata_xml and metadata_file
+	metadata, err := utils.ParseSamlIdpMetadata(document)
+	if err != nil {
+		return nil
+	}
+
+	certificate, diags := plannedSigningCertificate(signingCertificate, signingCertificatePath)
+	if diags.HasError() {
+		return diags
+	}
+
+	if certificate != nil {
+		metadata, err = metadata.VerifySignature(certificate, now)
+		if err != nil {
+			diags.AddAttributeError(signingCertificatePath, "Invalid SAML Metadata Signature", err.Error())
+			return diags
+		}
+	}
+
+	diags.Append(checkPlannedSamlMetadata(metadata, metadataPath, certificate != nil, now)...)
+	return diags
+}
@@ -2,12 +2,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/schemas"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// defaults defined by RFC 7643, which apply when the characteristic is omitted from the response
+const (
+	defaultAttributeMutability = "readWrite"
+	defaultAttributeReturned   = "default"
+	defaultAttributeUniqueness = "none"
+)
+
 type attributesData struct {
 	Name            types.String `tfsdk:"name"`
 	Type            types.String `tfsdk:"type"`
@@ -19,6 +28,21 @@ type attributesData struct {
 	Mutability      types.String `tfsdk:"mutability"`
 	Returned        types.String `tfsdk:"returned"`
 	Uniqueness      types.String `tfsdk:"uniqueness"`
+	SubAttributes   types.List   `tfsdk:"sub_attributes"`
+}
+
+// sub-attributes of a complex attribute, which cannot have sub-attributes themselves
+type subAttributesData struct {
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	Multivalued     types.Bool   `tfsdk:"multivalued"`
+	Description     types.String `tfsdk:"description"`
+	Required        types.Bool   `tfsdk:"required"`
+	CanonicalValues types.List   `tfsdk:"canonical_values"`
+	CaseExact       types.Bool   `tfsdk:"case_exact"`
+	Mutability      types.String `tfsdk:"mutability"`
+	Returned        types.String `tfsdk:"returned"`
+	Uniqueness      types.String `tfsdk:"uniqueness"`
 }
 
 type schemaData struct {
@@ -41,32 +65,46 @@ func schemaValueFrom(ctx context.Context, s schemas.Schema) (schemaData, diag.Di
 		schema.Description = types.StringValue(s.Description)
 	}
 
-	schema.Schemas, diags = types.SetValueFrom(ctx, types.StringType, s.Schemas)
-	diagnostics.Append(diags...)
+	// the schemas are not returned by every tenant, in which case the defaults of the resource apply
+	if len(s.Schemas) > 0 {
+		schema.Schemas, diags = types.SetValueFrom(ctx, types.StringType, s.Schemas)
+		diagnostics.Append(diags...)
+	} else {
+		schema.Schemas = types.SetValueMust(types.StringType, defaultSchemaSchemas)
+	}
 
 	attributes := []attributesData{}
 
 	for _, attributeRes := range s.Attributes {
+		subAttribute, diags := subAttributeValueFrom(ctx, attributeRes)
+		diagnostics.Append(diags...)
+
 		attribute := attributesData{
-			Name:        types.StringValue(attributeRes.Name),
-			Type:        types.StringValue(attributeRes.Type),
-			Multivalued: types.BoolValue(attributeRes.Multivalued),
-			Required:    types.BoolValue(attributeRes.Required),
-			CaseExact:   types.BoolValue(attributeRes.CaseExact),
-			Mutability:  types.StringValue(attributeRes.Mutability),
-			Returned:    types.StringValue(attributeRes.Returned),
-			Uniqueness:  types.StringValue(attributeRes.Uniqueness),
+			Name:            subAttribute.Name,
+			Type:            subAttribute.Type,
+			Multivalued:     subAttribute.Multivalued,
+			Description:     subAttribute.Description,
+			Required:        subAttribute.Required,
+			CanonicalValues: subAttribute.CanonicalValues,
+			CaseExact:       subAttribute.CaseExact,
+			Mutability:      subAttribute.Mutability,
+			Returned:        subAttribute.Returned,
+			Uniqueness:      subAttribute.Uniqueness,
 		}
 
-		if len(attributeRes.Description) > 0 {
-			attribute.Description = types.StringValue(attributeRes.Description)
-		}
+		if len(attributeRes.SubAttributes) > 0 {
+			subAttributes := []subAttributesData{}
+			for _, subAttributeRes := range attributeRes.SubAttributes {
+				subAttribute, diags := subAttributeValueFrom(ctx, subAttributeRes)
+				diagnostics.Append(diags...)
+
+				subAttributes = append(subAttributes, subAttribute)
+			}
 
-		if len(attributeRes.CanonicalValues) > 0 {
-			attribute.CanonicalValues, diags = types.ListValueFrom(ctx, types.StringType, attributeRes.CanonicalValues)
+			attribute.SubAttributes, diags = types.ListValueFrom(ctx, subAttributeObjType, subAttributes)
 			diagnostics.Append(diags...)
 		} else {
-			attribute.CanonicalValues = types.ListNull(types.StringType)
+			attribute.SubAttributes = types.ListNull(subAttributeObjType)
 		}
 
 		attributes = append(attributes, attribute)
@@ -78,6 +116,136 @@ func schemaValueFrom(ctx context.Context, s schemas.Schema) (schemaData, diag.Di
 	return schema, diagnostics
 }
 
+// subAttributeValueFrom sets the characteristics shared by the attributes and the sub-attributes of a schema
+func subAttributeValueFrom(ctx context.Context, a schemas.Attribute) (subAttributesData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attribute := subAttributesData{
+		Name:        types.StringValue(a.Name),
+		Type:        types.StringValue(a.Type),
+		Multivalued: types.BoolValue(a.Multivalued),
+		Required:    types.BoolValue(a.Required),
+		CaseExact:   types.BoolValue(a.CaseExact),
+		Mutability:  types.StringValue(attributeValueOrDefault(a.Mutability, defaultAttributeMutability)),
+		Returned:    types.StringValue(attributeValueOrDefault(a.Returned, defaultAttributeReturned)),
+		Uniqueness:  types.StringValue(attributeValueOrDefault(a.Uniqueness, defaultAttributeUniqueness)),
+	}
+
+	if len(a.Description) > 0 {
+		attribute.Description = types.StringValue(a.Description)
+	}
+
+	if len(a.CanonicalValues) > 0 {
+		attribute.CanonicalValues, diags = types.ListValueFrom(ctx, types.StringType, a.CanonicalValues)
+	} else {
+		attribute.CanonicalValues = types.ListNull(types.StringType)
+	}
+
+	return attribute, diags
+}
+
+// attributeValueOrDefault returns the default of the characteristic if the server omitted it
+func attributeValueOrDefault(value string, defaultValue string) string {
+	if len(value) == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getSchemaBreakingChanges describes the changes to the attributes which cannot be applied to an existing schema
+// attributes are matched by name, removing an attribute or changing its type or multivalued setting invalidates the values stored for users
+func getSchemaBreakingChanges(ctx context.Context, stateAttributes []attributesData, planAttributes []attributesData) ([]string, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+
+	stateTopLevel := make([]subAttributesData, len(stateAttributes))
+	for i, attribute := range stateAttributes {
+		stateTopLevel[i] = toSubAttribute(attribute)
+	}
+
+	planTopLevel := make([]subAttributesData, len(planAttributes))
+	planned := make(map[string]attributesData, len(planAttributes))
+	for i, attribute := range planAttributes {
+		planTopLevel[i] = toSubAttribute(attribute)
+		planned[attribute.Name.ValueString()] = attribute
+	}
+
+	breakingChanges := getAttributesBreakingChanges("", stateTopLevel, planTopLevel)
+
+	// the same rules apply to the sub-attributes of the complex attributes which are kept
+	for _, current := range stateAttributes {
+		attribute, found := planned[current.Name.ValueString()]
+		if !found || current.SubAttributes.IsNull() || attribute.SubAttributes.IsUnknown() {
+			continue
+		}
+
+		var stateSubAttributes, planSubAttributes []subAttributesData
+
+		diags := current.SubAttributes.ElementsAs(ctx, &stateSubAttributes, true)
+		diagnostics.Append(diags...)
+
+		if !attribute.SubAttributes.IsNull() {
+			diags = attribute.SubAttributes.ElementsAs(ctx, &planSubAttributes, true)
+			diagnostics.Append(diags...)
+		}
+
+		if diagnostics.HasError() {
+			return nil, diagnostics
+		}
+
+		breakingChanges = append(breakingChanges, getAttributesBreakingChanges(current.Name.ValueString()+".", stateSubAttributes, planSubAttributes)...)
+	}
+
+	return breakingChanges, diagnostics
+}
+
+// getAttributesBreakingChanges compares the attributes or sub-attributes of the state with the planned ones
+func getAttributesBreakingChanges(prefix string, stateAttributes []subAttributesData, planAttributes []subAttributesData) []string {
+
+	breakingChanges := []string{}
+
+	planned := make(map[string]subAttributesData, len(planAttributes))
+	for _, attribute := range planAttributes {
+		planned[attribute.Name.ValueString()] = attribute
+	}
+
+	for _, current := range stateAttributes {
+		name := prefix + current.Name.ValueString()
+
+		attribute, found := planned[current.Name.ValueString()]
+		if !found {
+			breakingChanges = append(breakingChanges, fmt.Sprintf("the attribute %s is removed", name))
+			continue
+		}
+
+		if !attribute.Type.IsUnknown() && attribute.Type.ValueString() != current.Type.ValueString() {
+			breakingChanges = append(breakingChanges, fmt.Sprintf("the type of the attribute %s is changed from %s to %s", name, current.Type.ValueString(), attribute.Type.ValueString()))
+		}
+
+		if !attribute.Multivalued.IsUnknown() && attribute.Multivalued.ValueBool() != current.Multivalued.ValueBool() {
+			breakingChanges = append(breakingChanges, fmt.Sprintf("the attribute %s is changed from multivalued=%t to multivalued=%t", name, current.Multivalued.ValueBool(), attribute.Multivalued.ValueBool()))
+		}
+	}
+
+	return breakingChanges
+}
+
+// toSubAttribute returns the characteristics the attribute shares with sub-attributes
+func toSubAttribute(attribute attributesData) subAttributesData {
+	return subAttributesData{
+		Name:            attribute.Name,
+		Type:            attribute.Type,
+		Multivalued:     attribute.Multivalued,
+		Description:     attribute.Description,
+		Required:        attribute.Required,
+		CanonicalValues: attribute.CanonicalValues,
+		CaseExact:       attribute.CaseExact,
+		Mutability:      attribute.Mutability,
+		Returned:        attribute.Returned,
+		Uniqueness:      attribute.Uniqueness,
+	}
+}
+
 func schemasValueFrom(ctx context.Context, s schemas.SchemasResponse) ([]schemaData, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
 
@@ -123,40 +291,72 @@ func getSchemaRequest(ctx context.Context, plan schemaData) (*schemas.Schema, di
 
 	args.Attributes = []schemas.Attribute{}
 	for _, attribute := range attributes {
-		schemaAttribute := schemas.Attribute{
-			Name:       attribute.Name.ValueString(),
-			Type:       attribute.Type.ValueString(),
-			Mutability: attribute.Mutability.ValueString(),
-			Returned:   attribute.Returned.ValueString(),
-			Uniqueness: attribute.Uniqueness.ValueString(),
+		schemaAttribute, diags := getSchemaAttributeRequest(ctx, toSubAttribute(attribute))
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return nil, diagnostics
 		}
 
-		if !attribute.CanonicalValues.IsNull() {
-			diags := attribute.CanonicalValues.ElementsAs(ctx, &schemaAttribute.CanonicalValues, true)
+		if !attribute.SubAttributes.IsNull() && !attribute.SubAttributes.IsUnknown() {
+			var subAttributes []subAttributesData
+			diags = attribute.SubAttributes.ElementsAs(ctx, &subAttributes, true)
 			diagnostics.Append(diags...)
 			if diagnostics.HasError() {
 				return nil, diagnostics
 			}
-		}
 
-		if !attribute.Multivalued.IsNull() {
-			schemaAttribute.Multivalued = attribute.Multivalued.ValueBool()
-		}
+			for _, subAttribute := range subAttributes {
+				schemaSubAttribute, diags := getSchemaAttributeRequest(ctx, subAttribute)
+				diagnostics.Append(diags...)
+				if diagnostics.HasError() {
+					return nil, diagnostics
+				}
 
-		if !attribute.Description.IsNull() {
-			schemaAttribute.Description = attribute.Description.ValueString()
+				schemaAttribute.SubAttributes = append(schemaAttribute.SubAttributes, schemaSubAttribute)
+			}
 		}
 
-		if !attribute.Required.IsNull() {
-			schemaAttribute.Required = attribute.Required.ValueBool()
-		}
+		args.Attributes = append(args.Attributes, schemaAttribute)
+	}
+
+	return args, diagnostics
+}
+
+func getSchemaAttributeRequest(ctx context.Context, attribute subAttributesData) (schemas.Attribute, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
 
-		if !attribute.CaseExact.IsNull() {
-			schemaAttribute.CaseExact = attribute.CaseExact.ValueBool()
+	schemaAttribute := schemas.Attribute{
+		Name:       attribute.Name.ValueString(),
+		Type:       attribute.Type.ValueString(),
+		Mutability: attribute.Mutability.ValueString(),
+		Returned:   attribute.Returned.ValueString(),
+		Uniqueness: attribute.Uniqueness.ValueString(),
+	}
+
+	if !attribute.CanonicalValues.IsNull() {
+		diags := attribute.CanonicalValues.ElementsAs(ctx, &schemaAttribute.CanonicalValues, true)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return schemaAttribute, diagnostics
 		}
+	}
 
-		args.Attributes = append(args.Attributes, schemaAttribute)
+	if !attribute.Multivalued.IsNull() {
+		schemaAttribute.Multivalued = attribute.Multivalued.ValueBool()
 	}
 
-	return args, diagnostics
+	if !attribute.Description.IsNull() {
+		schemaAttribute.Description = attribute.Description.ValueString()
+	}
+
+	if !attribute.Required.IsNull() {
+		schemaAttribute.Required = attribute.Required.ValueBool()
+	}
+
+	if !attribute.CaseExact.IsNull() {
+		schemaAttribute.CaseExact = attribute.CaseExact.ValueBool()
+	}
+
+	return schemaAttribute, diagnostics
 }
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/discovery"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type serviceProviderConfigData struct {
+	DocumentationUri        types.String `tfsdk:"documentation_uri"`
+	PatchSupported          types.Bool   `tfsdk:"patch_supported"`
+	ChangePasswordSupported types.Bool   `tfsdk:"change_password_supported"`
+	SortSupported           types.Bool   `tfsdk:"sort_supported"`
+	EtagSupported           types.Bool   `tfsdk:"etag_supported"`
+	Bulk                    types.Object `tfsdk:"bulk"`
+	Filter                  types.Object `tfsdk:"filter"`
+	Pagination              types.Object `tfsdk:"pagination"`
+	AuthenticationSchemes   types.List   `tfsdk:"authentication_schemes"`
+}
+
+type resourceTypesData struct {
+	Values types.List `tfsdk:"values"`
+}
+
+var bulkConfigObjType = map[string]attr.Type{
+	"supported":        types.BoolType,
+	"max_operations":   types.Int64Type,
+	"max_payload_size": types.Int64Type,
+}
+
+var filterConfigObjType = map[string]attr.Type{
+	"supported":   types.BoolType,
+	"max_results": types.Int64Type,
+}
+
+var paginationConfigObjType = map[string]attr.Type{
+	"cursor":                    types.BoolType,
+	"index":                     types.BoolType,
+	"default_pagination_method": types.StringType,
+	"default_page_size":         types.Int64Type,
+	"max_page_size":             types.Int64Type,
+	"cursor_timeout":            types.Int64Type,
+}
+
+var authenticationSchemeObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"type":              types.StringType,
+		"name":              types.StringType,
+		"description":       types.StringType,
+		"spec_uri":          types.StringType,
+		"documentation_uri": types.StringType,
+		"primary":           types.BoolType,
+	},
+}
+
+var schemaExtensionObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"schema":   types.StringType,
+		"required": types.BoolType,
+	},
+}
+
+var resourceTypeObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":          types.StringType,
+		"name":        types.StringType,
+		"endpoint":    types.StringType,
+		"description": types.StringType,
+		"schema":      types.StringType,
+		"schema_extensions": types.ListType{
+			ElemType: schemaExtensionObjType,
+		},
+	},
+}
+
+type resourceTypeData struct {
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Endpoint         types.String `tfsdk:"endpoint"`
+	Description      types.String `tfsdk:"description"`
+	Schema           types.String `tfsdk:"schema"`
+	SchemaExtensions types.List   `tfsdk:"schema_extensions"`
+}
+
+func serviceProviderConfigValueFrom(ctx context.Context, c discovery.ServiceProviderConfig) (serviceProviderConfigData, diag.Diagnostics) {
+
+	var diagnostics, diags diag.Diagnostics
+
+	config := serviceProviderConfigData{
+		DocumentationUri:        types.StringValue(c.DocumentationUri),
+		PatchSupported:          types.BoolValue(c.Patch.Supported),
+		ChangePasswordSupported: types.BoolValue(c.ChangePassword.Supported),
+		SortSupported:           types.BoolValue(c.Sort.Supported),
+		EtagSupported:           types.BoolValue(c.Etag.Supported),
+	}
+
+	config.Bulk, diags = types.ObjectValueFrom(ctx, bulkConfigObjType, c.Bulk)
+	diagnostics.Append(diags...)
+
+	config.Filter, diags = types.ObjectValueFrom(ctx, filterConfigObjType, c.Filter)
+	diagnostics.Append(diags...)
+
+	// the pagination is only advertised by tenants supporting RFC 9865
+	if c.Pagination != nil {
+		config.Pagination, diags = types.ObjectValueFrom(ctx, paginationConfigObjType, c.Pagination)
+		diagnostics.Append(diags...)
+	} else {
+		config.Pagination = types.ObjectNull(paginationConfigObjType)
+	}
+
+	config.AuthenticationSchemes, diags = types.ListValueFrom(ctx, authenticationSchemeObjType, c.AuthenticationSchemes)
+	diagnostics.Append(diags...)
+
+	return config, diagnostics
+}
+
+func resourceTypesValueFrom(ctx context.Context, r []discovery.ResourceType) ([]resourceTypeData, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+	resourceTypes := []resourceTypeData{}
+
+	for _, resourceType := range r {
+
+		schemaExtensions, diags := types.ListValueFrom(ctx, schemaExtensionObjType, resourceType.SchemaExtensions)
+		diagnostics.Append(diags...)
+
+		resourceTypes = append(resourceTypes, resourceTypeData{
+			Id:               types.StringValue(resourceType.Id),
+			Name:             types.StringValue(resourceType.Name),
+			Endpoint:         types.StringValue(resourceType.Endpoint),
+			Description:      types.StringValue(resourceType.Description),
+			Schema:           types.StringValue(resourceType.Schema),
+			SchemaExtensions: schemaExtensions,
+		})
+	}
+
+	return resourceTypes, diagnostics
+}
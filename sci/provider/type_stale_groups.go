@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type staleGroupsData struct {
+	LastModifiedBefore types.String `tfsdk:"last_modified_before"`
+	Values             types.List   `tfsdk:"values"`
+}
+
+type staleGroupData struct {
+	Id          types.String `tfsdk:"id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Members     types.Int64  `tfsdk:"members"`
+	Meta        types.Object `tfsdk:"meta"`
+}
+
+var staleGroupObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":           types.StringType,
+		"display_name": types.StringType,
+		"members":      types.Int64Type,
+		"meta": types.ObjectType{
+			AttrTypes: scimMetaObjType,
+		},
+	},
+}
+
+func staleGroupsValueFrom(ctx context.Context, tenantGroups []groups.Group, before time.Time) ([]staleGroupData, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	staleGroups := []staleGroupData{}
+
+	for _, g := range tenantGroups {
+
+		stale, err := lastModifiedBefore(g.Meta, before)
+		if err != nil {
+			diagnostics.AddError("Error reading group", fmt.Sprintf("group %s: %s", g.Id, err))
+			continue
+		}
+
+		if !stale {
+			continue
+		}
+
+		group := staleGroupData{
+			Id:          types.StringValue(g.Id),
+			DisplayName: types.StringValue(g.DisplayName),
+			Members:     types.Int64Value(int64(len(g.GroupMembers))),
+		}
+
+		meta, diags := metaValueFrom(ctx, g.Meta)
+		diagnostics.Append(diags...)
+		group.Meta = meta
+
+		staleGroups = append(staleGroups, group)
+	}
+
+	return staleGroups, diagnostics
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/tenant"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type tenantSamlMetadataData struct {
+	MetadataXml         types.String `tfsdk:"metadata_xml"`
+	EntityId            types.String `tfsdk:"entity_id"`
+	SsoEndpoints        types.List   `tfsdk:"sso_endpoints"`
+	SloEndpoints        types.List   `tfsdk:"slo_endpoints"`
+	SigningCertificates types.List   `tfsdk:"signing_certificates"`
+	NameIdFormats       types.List   `tfsdk:"name_id_formats"`
+}
+
+type tenantOidcConfigurationData struct {
+	Issuer                            types.String `tfsdk:"issuer"`
+	AuthorizationEndpoint             types.String `tfsdk:"authorization_endpoint"`
+	TokenEndpoint                     types.String `tfsdk:"token_endpoint"`
+	UserInfoEndpoint                  types.String `tfsdk:"user_info_endpoint"`
+	LogoutEndpoint                    types.String `tfsdk:"logout_endpoint"`
+	JwksUri                           types.String `tfsdk:"jwks_uri"`
+	Jwks                              types.String `tfsdk:"jwks"`
+	ScopesSupported                   types.List   `tfsdk:"scopes_supported"`
+	ResponseTypesSupported            types.List   `tfsdk:"response_types_supported"`
+	GrantTypesSupported               types.List   `tfsdk:"grant_types_supported"`
+	SubjectTypesSupported             types.List   `tfsdk:"subject_types_supported"`
+	IdTokenSigningAlgValuesSupported  types.List   `tfsdk:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported types.List   `tfsdk:"token_endpoint_auth_methods_supported"`
+	ClaimsSupported                   types.List   `tfsdk:"claims_supported"`
+}
+
+func tenantSamlMetadataValueFrom(ctx context.Context, metadataXml string, metadata utils.SamlIdpMetadata) (tenantSamlMetadataData, diag.Diagnostics) {
+
+	var diagnostics, diags diag.Diagnostics
+
+	// the endpoints are returned as advertised, including those with bindings not supported by corporate IdPs
+	data := tenantSamlMetadataData{
+		MetadataXml: types.StringValue(metadataXml),
+		EntityId:    types.StringValue(metadata.EntityId),
+	}
+
+	data.SsoEndpoints, diags = types.ListValueFrom(ctx, saml2SsoEndpointObjType, metadata.SsoEndpoints)
+	diagnostics.Append(diags...)
+
+	data.SloEndpoints, diags = types.ListValueFrom(ctx, saml2SloEndpointObjType, metadata.SloEndpoints)
+	diagnostics.Append(diags...)
+
+	data.SigningCertificates, diags = types.ListValueFrom(ctx, saml2SigningCertificateObjType, metadata.CertificatesForSigning)
+	diagnostics.Append(diags...)
+
+	data.NameIdFormats, diags = types.ListValueFrom(ctx, types.StringType, metadata.NameIdFormats)
+	diagnostics.Append(diags...)
+
+	return data, diagnostics
+}
+
+func tenantOidcConfigurationValueFrom(ctx context.Context, config tenant.OIDCConfiguration) (tenantOidcConfigurationData, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+
+	data := tenantOidcConfigurationData{
+		Issuer:                types.StringValue(config.Issuer),
+		AuthorizationEndpoint: discoveredValue(config.AuthorizationEndpoint),
+		TokenEndpoint:         discoveredValue(config.TokenEndpoint),
+		UserInfoEndpoint:      discoveredValue(config.UserInfoEndpoint),
+		LogoutEndpoint:        discoveredValue(config.EndSessionEndpoint),
+		JwksUri:               discoveredValue(config.JwksUri),
+		Jwks:                  discoveredValue(config.Jwks),
+	}
+
+	supported := func(values []string) types.List {
+		list, diags := types.ListValueFrom(ctx, types.StringType, values)
+		diagnostics.Append(diags...)
+		return list
+	}
+
+	data.ScopesSupported = supported(config.ScopesSupported)
+	data.ResponseTypesSupported = supported(config.ResponseTypesSupported)
+	data.GrantTypesSupported = supported(config.GrantTypesSupported)
+	data.SubjectTypesSupported = supported(config.SubjectTypesSupported)
+	data.IdTokenSigningAlgValuesSupported = supported(config.IdTokenSigningAlgValuesSupported)
+	data.TokenEndpointAuthMethodsSupported = supported(config.TokenEndpointAuthMethodsSupported)
+	data.ClaimsSupported = supported(config.ClaimsSupported)
+
+	return data, diagnostics
+}
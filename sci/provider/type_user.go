@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -20,6 +22,15 @@ type nameData struct {
 	HonorificPrefix types.String `tfsdk:"honorific_prefix"`
 }
 
+// scimMetaData holds the read-only SCIM meta attributes of a resource
+type scimMetaData struct {
+	ResourceType types.String `tfsdk:"resource_type"`
+	Created      types.String `tfsdk:"created"`
+	LastModified types.String `tfsdk:"last_modified"`
+	Location     types.String `tfsdk:"location"`
+	Version      types.String `tfsdk:"version"`
+}
+
 type userData struct {
 	Id               types.String `tfsdk:"id"`
 	Schemas          types.Set    `tfsdk:"schemas" json:"schemas"`
@@ -33,6 +44,14 @@ type userData struct {
 	SapExtensionUser types.Object `tfsdk:"sap_extension_user" json:"urn:ietf:params:scim:schemas:extension:sap:2.0:User"`
 	CustomSchemas    types.String `tfsdk:"custom_schemas"`
 	Groups           types.List   `tfsdk:"groups" json:"groups"`
+	Meta             types.Object `tfsdk:"meta"`
+}
+
+// userResourceData extends the user with attributes that only control the behaviour of the resource
+type userResourceData struct {
+	userData
+	DeletionMode types.String `tfsdk:"deletion_mode"`
+	ForceDelete  types.Bool   `tfsdk:"force_delete"`
 }
 
 func userValueFrom(ctx context.Context, u users.User, cS string) (userData, diag.Diagnostics) {
@@ -121,9 +140,41 @@ func userValueFrom(ctx context.Context, u users.User, cS string) (userData, diag
 		user.Groups = types.ListNull(groupListObjType)
 	}
 
+	// Meta
+	user.Meta, diags = metaValueFrom(ctx, u.Meta)
+	diagnostics.Append(diags...)
+
 	return user, diagnostics
 }
 
+func metaValueFrom(ctx context.Context, m users.Meta) (types.Object, diag.Diagnostics) {
+
+	if m == (users.Meta{}) {
+		return types.ObjectNull(scimMetaObjType), nil
+	}
+
+	// mapping is done manually to handle null values
+	meta := scimMetaData{}
+
+	if len(m.ResourceType) > 0 {
+		meta.ResourceType = types.StringValue(m.ResourceType)
+	}
+	if len(m.Created) > 0 {
+		meta.Created = types.StringValue(m.Created)
+	}
+	if len(m.LastModified) > 0 {
+		meta.LastModified = types.StringValue(m.LastModified)
+	}
+	if len(m.Location) > 0 {
+		meta.Location = types.StringValue(m.Location)
+	}
+	if len(m.Version) > 0 {
+		meta.Version = types.StringValue(m.Version)
+	}
+
+	return types.ObjectValueFrom(ctx, scimMetaObjType, meta)
+}
+
 func usersValueFrom(ctx context.Context, u users.UsersResponse, customSchemas map[int]string) []userData {
 	users := []userData{}
 
@@ -372,66 +423,130 @@ func getUserUpdateRequest(ctx context.Context, plan userData, state userData) ([
 		}
 	}
 
-	if !plan.CustomSchemas.Equal(state.CustomSchemas) {
+	customSchemasReqs, diags := getCustomSchemasUpdateRequest(plan.CustomSchemas, state.CustomSchemas)
+	if diags.HasError() {
+		return reqs, diags
+	}
+	reqs = append(reqs, customSchemasReqs...)
 
-		if plan.CustomSchemas.IsNull() && state.CustomSchemas.IsNull() {
-			return reqs, diags
-		}
+	return reqs, diags
+}
 
-		planCustomSchemasMap := make(map[string]map[string]any)
-		stateCustomSchemasMap := make(map[string]map[string]any)
+// getCustomSchemasUpdateRequest builds the patch operations for the attributes of the custom schemas which are added, changed or removed
+func getCustomSchemasUpdateRequest(plan types.String, state types.String) ([]generic.PatchRequest, diag.Diagnostics) {
 
-		if !plan.CustomSchemas.IsNull() {
-			planCustomSchemas := plan.CustomSchemas.ValueString()
-			if err := json.Unmarshal([]byte(planCustomSchemas), &planCustomSchemasMap); err != nil {
-				diags.AddError("Failed to unmarshal custom schemas", err.Error())
-				return reqs, diags
-			}
+	var diags diag.Diagnostics
+	reqs := []generic.PatchRequest{}
+
+	if plan.Equal(state) {
+		return reqs, diags
+	}
+
+	planCustomSchemasMap := make(map[string]map[string]any)
+	stateCustomSchemasMap := make(map[string]map[string]any)
+
+	if !plan.IsNull() {
+		if err := json.Unmarshal([]byte(plan.ValueString()), &planCustomSchemasMap); err != nil {
+			diags.AddError("Failed to unmarshal custom schemas", err.Error())
+			return reqs, diags
 		}
+	}
 
-		if !state.CustomSchemas.IsNull() {
-			stateCustomSchemas := state.CustomSchemas.ValueString()
-			if err := json.Unmarshal([]byte(stateCustomSchemas), &stateCustomSchemasMap); err != nil {
-				diags.AddError("Failed to unmarshal custom schemas", err.Error())
-				return reqs, diags
-			}
+	if !state.IsNull() {
+		if err := json.Unmarshal([]byte(state.ValueString()), &stateCustomSchemasMap); err != nil {
+			diags.AddError("Failed to unmarshal custom schemas", err.Error())
+			return reqs, diags
 		}
+	}
 
-		for schema, planAttributesMap := range planCustomSchemasMap {
-			stateAttributesMap, schemaFound := stateCustomSchemasMap[schema]
-
-			if schemaFound {
-				for attrKey, planAttrValue := range planAttributesMap {
-					if stateAttrValue, attrFound := stateAttributesMap[attrKey]; attrFound {
-						if !reflect.DeepEqual(planAttrValue, stateAttrValue) {
-							reqs = append(reqs, utils.GenerateReplacePatchRequest(schema+":"+attrKey, planAttrValue))
-						}
-						delete(stateAttributesMap, attrKey)
-					} else {
-						reqs = append(reqs, utils.GenerateAddPatchRequest(schema+":"+attrKey, planAttrValue))
-					}
-				}
+	for schema, planAttributesMap := range planCustomSchemasMap {
+		stateAttributesMap, schemaFound := stateCustomSchemasMap[schema]
 
-				for attrKey := range stateAttributesMap {
-					if _, exists := planAttributesMap[attrKey]; !exists {
-						reqs = append(reqs, utils.GenerateDeletePatchRequest(schema+":"+attrKey))
+		if schemaFound {
+			for attrKey, planAttrValue := range planAttributesMap {
+				if stateAttrValue, attrFound := stateAttributesMap[attrKey]; attrFound {
+					if !reflect.DeepEqual(planAttrValue, stateAttrValue) {
+						reqs = append(reqs, utils.GenerateReplacePatchRequest(schema+":"+attrKey, planAttrValue))
 					}
+					delete(stateAttributesMap, attrKey)
+				} else {
+					reqs = append(reqs, utils.GenerateAddPatchRequest(schema+":"+attrKey, planAttrValue))
 				}
-				delete(stateCustomSchemasMap, schema)
-			} else {
-				for attrKey, attrValue := range planAttributesMap {
-					reqs = append(reqs, utils.GenerateAddPatchRequest(schema+":"+attrKey, attrValue))
+			}
+
+			for attrKey := range stateAttributesMap {
+				if _, exists := planAttributesMap[attrKey]; !exists {
+					reqs = append(reqs, utils.GenerateDeletePatchRequest(schema+":"+attrKey))
 				}
 			}
+			delete(stateCustomSchemasMap, schema)
+		} else {
+			for attrKey, attrValue := range planAttributesMap {
+				reqs = append(reqs, utils.GenerateAddPatchRequest(schema+":"+attrKey, attrValue))
+			}
 		}
+	}
 
-		for schema := range stateCustomSchemasMap {
-			if _, schemaFound := planCustomSchemasMap[schema]; !schemaFound {
-				reqs = append(reqs, utils.GenerateDeletePatchRequest(schema))
-			}
+	for schema := range stateCustomSchemasMap {
+		if _, schemaFound := planCustomSchemasMap[schema]; !schemaFound {
+			reqs = append(reqs, utils.GenerateDeletePatchRequest(schema))
 		}
+	}
+
+	return reqs, diags
+}
 
+// getUserDeactivateRequest builds the patch operations used to deactivate a user instead of deleting it
+func getUserDeactivateRequest() ([]generic.PatchRequest, diag.Diagnostics) {
+
+	reqs := []generic.PatchRequest{}
+
+	argsType := reflect.TypeFor[userData]()
+
+	patchReq, diags := utils.GetScimPatchRequest("Active", "", false, argsType)
+	if diags.HasError() {
+		return reqs, diags
+	}
+	reqs = append(reqs, patchReq)
+
+	sapExtensionPath, diags := utils.GetAttributeTag("SapExtensionUser", argsType)
+	if diags.HasError() {
+		return reqs, diags
+	}
+
+	patchReq, diags = utils.GetScimPatchRequest("Status", sapExtensionPath, "inactive", reflect.TypeFor[users.SAPExtension]())
+	if diags.HasError() {
+		return reqs, diags
 	}
+	reqs = append(reqs, patchReq)
 
 	return reqs, diags
 }
+
+// validateUserDeletion prevents the deletion of a user that is still a member of groups
+func validateUserDeletion(userName string, groups []users.Group) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if len(groups) == 0 {
+		return diags
+	}
+
+	groupNames := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if len(group.Display) > 0 {
+			groupNames = append(groupNames, group.Display)
+		} else {
+			groupNames = append(groupNames, group.Value)
+		}
+	}
+
+	diags.AddError(
+		"User is a member of groups",
+		fmt.Sprintf("The user %s cannot be deleted as it is still a member of the following groups : %s.\n"+
+			"Remove the memberships of the user, set force_delete to true or set deletion_mode to deactivate or retain.",
+			userName, strings.Join(groupNames, ", ")),
+	)
+
+	return diags
+}
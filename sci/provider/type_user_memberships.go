@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/groups"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// number of groups read with a single request when all groups of the tenant are listed
+const groupsPageSize = 100
+
+type userMembershipsData struct {
+	UserId        types.String `tfsdk:"user_id"`
+	UserName      types.String `tfsdk:"user_name"`
+	IncludeNested types.Bool   `tfsdk:"include_nested"`
+	MaxDepth      types.Int64  `tfsdk:"max_depth"`
+	Groups        types.List   `tfsdk:"groups"`
+}
+
+type userMembershipData struct {
+	GroupId     types.String `tfsdk:"group_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Direct      types.Bool   `tfsdk:"direct"`
+	Depth       types.Int64  `tfsdk:"depth"`
+}
+
+type userGroupMembershipsData struct {
+	Id            types.String `tfsdk:"id"`
+	UserId        types.String `tfsdk:"user_id"`
+	GroupIds      types.Set    `tfsdk:"group_ids"`
+	Authoritative types.Bool   `tfsdk:"authoritative"`
+}
+
+var userMembershipObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"group_id":     types.StringType,
+		"display_name": types.StringType,
+		"direct":       types.BoolType,
+		"depth":        types.Int64Type,
+	},
+}
+
+// getUserByIdOrName reads the user either by its ID or, if no ID is given, by its user name
+func getUserByIdOrName(ctx context.Context, client *cli.SciClient, userId string, userName string) (users.User, error) {
+
+	if len(userId) > 0 {
+		user, _, err := client.User.GetByUserId(ctx, userId, false, "")
+		return user, err
+	}
+
+	res, _, err := client.User.List(ctx, fmt.Sprintf(`userName eq "%s"`, scimFilterValue(userName)), 1)
+	if err != nil {
+		return users.User{}, err
+	}
+
+	if len(res.Resources) == 0 {
+		return users.User{}, fmt.Errorf("user %s is not found", userName)
+	}
+
+	return res.Resources[0], nil
+}
+
+// scimFilterValue escapes the value to be used as a string literal in a SCIM filter
+func scimFilterValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// directGroupIds returns the IDs of the groups the user is a direct member of
+func directGroupIds(user users.User) []string {
+
+	groupIds := []string{}
+	for _, group := range user.Groups {
+		if group.Type != "indirect" {
+			groupIds = append(groupIds, group.Value)
+		}
+	}
+
+	return groupIds
+}
+
+// getUserMemberships returns the groups the user is a member of
+// the direct memberships are taken from the user, the nested memberships are resolved from the members of all groups up to the maximum depth
+// allGroups is only needed if nested memberships are requested
+func getUserMemberships(user users.User, allGroups []groups.Group, includeNested bool, maxDepth int) []userMembershipData {
+
+	memberships := []userMembershipData{}
+	seen := map[string]bool{}
+
+	displayNames := map[string]string{}
+	for _, group := range user.Groups {
+		displayNames[group.Value] = group.Display
+	}
+
+	// the groups containing each user or group, keyed by the ID of the member
+	parents := map[string][]groups.Group{}
+	for _, group := range allGroups {
+		displayNames[group.Id] = group.DisplayName
+		for _, member := range group.GroupMembers {
+			parents[member.Value] = append(parents[member.Value], group)
+		}
+	}
+
+	level := directGroupIds(user)
+
+	for depth := 1; len(level) > 0 && (depth == 1 || includeNested && depth <= maxDepth); depth++ {
+
+		next := []string{}
+
+		for _, groupId := range level {
+			if seen[groupId] {
+				continue
+			}
+			seen[groupId] = true
+
+			memberships = append(memberships, userMembershipData{
+				GroupId:     types.StringValue(groupId),
+				DisplayName: types.StringValue(displayNames[groupId]),
+				Direct:      types.BoolValue(depth == 1),
+				Depth:       types.Int64Value(int64(depth)),
+			})
+
+			for _, parent := range parents[groupId] {
+				next = append(next, parent.Id)
+			}
+		}
+
+		slices.Sort(next)
+		level = slices.Compact(next)
+	}
+
+	return memberships
+}
+
+// userGroupMembershipsValueFrom maps the direct groups of the user to the state
+// an authoritative resource reflects all direct groups, otherwise only the managed groups which are still assigned are kept
+// if managedIds is nil, e.g. after an import, all direct groups are adopted
+func userGroupMembershipsValueFrom(ctx context.Context, user users.User, managedIds []string, authoritative bool) (userGroupMembershipsData, diag.Diagnostics) {
+
+	groupIds := []string{}
+	for _, groupId := range directGroupIds(user) {
+		if authoritative || managedIds == nil || slices.Contains(managedIds, groupId) {
+			groupIds = append(groupIds, groupId)
+		}
+	}
+
+	groupSet, diags := types.SetValueFrom(ctx, types.StringType, groupIds)
+
+	return userGroupMembershipsData{
+		Id:            types.StringValue(user.Id),
+		UserId:        types.StringValue(user.Id),
+		GroupIds:      groupSet,
+		Authoritative: types.BoolValue(authoritative),
+	}, diags
+}
+
+// applyUserGroupMembershipsDiff adds the user to and removes the user from the groups, with a PATCH request per affected group
+// the flag reports whether any of the changes have been applied before an error occurred
+func applyUserGroupMembershipsDiff(ctx context.Context, client *cli.SciClient, userId string, add []string, remove []string) (bool, error) {
+
+	applied := false
+
+	for _, groupId := range remove {
+		if _, _, err := client.Group.RemoveMembers(ctx, groupId, []string{userId}); err != nil {
+			return applied, fmt.Errorf("removing the user from group %s failed: %s", groupId, err)
+		}
+		applied = true
+	}
+
+	for _, groupId := range add {
+		if _, _, err := client.Group.AddMembers(ctx, groupId, []groups.GroupMember{{Value: userId, Type: "User"}}); err != nil {
+			return applied, fmt.Errorf("adding the user to group %s failed: %s", groupId, err)
+		}
+		applied = true
+	}
+
+	return applied, nil
+}
+
+// groupMembersOf returns the groups as members, so that the membership diff of groups can be reused for users
+func groupMembersOf(groupIds []string) []groups.GroupMember {
+
+	members := make([]groups.GroupMember, len(groupIds))
+	for i, groupId := range groupIds {
+		members[i] = groups.GroupMember{Value: groupId}
+	}
+
+	return members
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type userPasswordData struct {
+	Id                     types.String `tfsdk:"id"`
+	UserId                 types.String `tfsdk:"user_id"`
+	PasswordWo             types.String `tfsdk:"password_wo"`
+	RotationTrigger        types.String `tfsdk:"rotation_trigger"`
+	ForceChangeOnNextLogon types.Bool   `tfsdk:"force_change_on_next_logon"`
+}
+
+// userPasswordErrorDiagnostics maps the error returned while setting the password to diagnostics
+// password policy violations are reported by the API with a 400 status and a scimType or detail referring to the password,
+// they are surfaced with a dedicated summary, all other errors are passed through unchanged
+func userPasswordErrorDiagnostics(summary string, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var scimErr cli.ScimResponseError
+	if errors.As(err, &scimErr) && isPasswordPolicyViolation(scimErr) {
+		diags.AddAttributeError(
+			userPasswordPath,
+			"Password rejected by the password policy",
+			fmt.Sprintf("The password does not satisfy the password policy of the tenant : %s", scimErr.Detail),
+		)
+		return diags
+	}
+
+	diags.AddError(summary, fmt.Sprintf("%s", err))
+	return diags
+}
+
+func isPasswordPolicyViolation(scimErr cli.ScimResponseError) bool {
+	if status, _ := strconv.Atoi(scimErr.Status); status != http.StatusBadRequest {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(scimErr.ScimType), "password") || strings.Contains(strings.ToLower(scimErr.Detail), "password")
+}
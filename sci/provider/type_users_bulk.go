@@ -0,0 +1,561 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/bulk"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/users"
+	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// number of users requested per page when the users of the tenant are listed
+const usersBulkPageSize = 100
+
+type usersBulkData struct {
+	Id             types.String `tfsdk:"id"`
+	Filter         types.String `tfsdk:"filter"`
+	BatchSize      types.Int64  `tfsdk:"batch_size"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	AdoptExisting  types.Bool   `tfsdk:"adopt_existing_users"`
+	Users          types.List   `tfsdk:"users"`
+	UserIds        types.Map    `tfsdk:"user_ids"`
+}
+
+type bulkUserData struct {
+	UserName    types.String `tfsdk:"user_name"`
+	Email       types.String `tfsdk:"email"`
+	GivenName   types.String `tfsdk:"given_name"`
+	FamilyName  types.String `tfsdk:"family_name"`
+	DisplayName types.String `tfsdk:"display_name"`
+	UserType    types.String `tfsdk:"user_type"`
+	Active      types.Bool   `tfsdk:"active"`
+}
+
+var bulkUserObjType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"user_name":    types.StringType,
+		"email":        types.StringType,
+		"given_name":   types.StringType,
+		"family_name":  types.StringType,
+		"display_name": types.StringType,
+		"user_type":    types.StringType,
+		"active":       types.BoolType,
+	},
+}
+
+// usersBulkOperation is a single create, update or delete of a user computed from the diff
+type usersBulkOperation struct {
+	method   string
+	userName string
+	userId   string
+	user     *users.User
+	patch    []generic.PatchRequest
+}
+
+type usersBulkResult struct {
+	operation usersBulkOperation
+	userId    string
+	err       error
+}
+
+// primaryEmail returns the primary email of the user, or the first email if none is marked as primary
+func primaryEmail(u users.User) string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+
+	return ""
+}
+
+// usersBulkValueFrom refreshes the configured users with the values of the tenant
+// attributes which are not configured are not read, so that changes made by other tools to these attributes do not cause a diff
+func usersBulkValueFrom(ctx context.Context, state usersBulkData, tenantUsers []users.User) (usersBulkData, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	var stateUsers []bulkUserData
+	diags = state.Users.ElementsAs(ctx, &stateUsers, true)
+	if diags.HasError() {
+		return state, diags
+	}
+
+	managedIds := map[string]string{}
+	diags = state.UserIds.ElementsAs(ctx, &managedIds, true)
+	if diags.HasError() {
+		return state, diags
+	}
+
+	tenantUsersByName := make(map[string]users.User, len(tenantUsers))
+	for _, u := range tenantUsers {
+		tenantUsersByName[u.UserName] = u
+	}
+
+	refreshedUsers := []bulkUserData{}
+	refreshedIds := map[string]string{}
+
+	for _, stateUser := range stateUsers {
+		tenantUser, found := tenantUsersByName[stateUser.UserName.ValueString()]
+		if !found {
+			// the user has either been deleted outside of Terraform or could not be created
+			continue
+		}
+
+		refreshedUsers = append(refreshedUsers, bulkUserValueFrom(stateUser, tenantUser))
+		refreshedIds[tenantUser.UserName] = tenantUser.Id
+	}
+
+	// users which could not be deleted are still managed by the resource,
+	// they are added to the list so that the deletion is planned again
+	for userName := range managedIds {
+		if _, found := refreshedIds[userName]; found {
+			continue
+		}
+
+		tenantUser, found := tenantUsersByName[userName]
+		if !found {
+			continue
+		}
+
+		refreshedUsers = append(refreshedUsers, bulkUserData{
+			UserName:    types.StringValue(tenantUser.UserName),
+			Email:       types.StringValue(primaryEmail(tenantUser)),
+			GivenName:   types.StringNull(),
+			FamilyName:  types.StringNull(),
+			DisplayName: types.StringNull(),
+			UserType:    types.StringNull(),
+			Active:      types.BoolNull(),
+		})
+		refreshedIds[tenantUser.UserName] = tenantUser.Id
+	}
+
+	state.Users, diags = types.ListValueFrom(ctx, bulkUserObjType, refreshedUsers)
+	if diags.HasError() {
+		return state, diags
+	}
+
+	state.UserIds, diags = types.MapValueFrom(ctx, types.StringType, refreshedIds)
+	return state, diags
+}
+
+func bulkUserValueFrom(config bulkUserData, u users.User) bulkUserData {
+
+	user := bulkUserData{
+		UserName:    types.StringValue(u.UserName),
+		Email:       types.StringValue(primaryEmail(u)),
+		GivenName:   types.StringNull(),
+		FamilyName:  types.StringNull(),
+		DisplayName: types.StringNull(),
+		UserType:    types.StringNull(),
+		Active:      types.BoolNull(),
+	}
+
+	var name users.Name
+	if u.Name != nil {
+		name = *u.Name
+	}
+
+	if !config.GivenName.IsNull() {
+		user.GivenName = types.StringValue(name.GivenName)
+	}
+
+	if !config.FamilyName.IsNull() {
+		user.FamilyName = types.StringValue(name.FamilyName)
+	}
+
+	if !config.DisplayName.IsNull() {
+		user.DisplayName = types.StringValue(u.DisplayName)
+	}
+
+	if !config.UserType.IsNull() {
+		user.UserType = types.StringValue(u.UserType)
+	}
+
+	if !config.Active.IsNull() {
+		user.Active = types.BoolValue(u.Active)
+	}
+
+	return user
+}
+
+// getBulkUserRequest builds the request body for the creation of a user
+func getBulkUserRequest(plan bulkUserData) *users.User {
+
+	schemas := make([]string, 0, len(defaultUserSchemas))
+	for _, schema := range defaultUserSchemas {
+		schemas = append(schemas, schema.(types.String).ValueString())
+	}
+
+	user := &users.User{
+		Schemas:  schemas,
+		UserName: plan.UserName.ValueString(),
+		Emails: []users.Email{
+			{
+				Value:   plan.Email.ValueString(),
+				Type:    "work",
+				Primary: true,
+			},
+		},
+		DisplayName: plan.DisplayName.ValueString(),
+		UserType:    plan.UserType.ValueString(),
+		Active:      plan.Active.ValueBool(),
+	}
+
+	if !plan.GivenName.IsNull() || !plan.FamilyName.IsNull() {
+		user.Name = &users.Name{
+			GivenName:  plan.GivenName.ValueString(),
+			FamilyName: plan.FamilyName.ValueString(),
+		}
+	}
+
+	return user
+}
+
+// getBulkUserUpdateRequest compares the configured user with the user of the tenant
+// only the attributes which are configured are compared
+func getBulkUserUpdateRequest(plan bulkUserData, u users.User) ([]generic.PatchRequest, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	reqs := []generic.PatchRequest{}
+
+	argsType := reflect.TypeFor[users.User]()
+
+	if plan.Email.ValueString() != primaryEmail(u) {
+		patchReq, diags := utils.GetScimPatchRequest("Emails", "", []users.Email{
+			{
+				Value:   plan.Email.ValueString(),
+				Type:    "work",
+				Primary: true,
+			},
+		}, argsType)
+		if diags.HasError() {
+			return reqs, diags
+		}
+		reqs = append(reqs, patchReq)
+	}
+
+	var name users.Name
+	if u.Name != nil {
+		name = *u.Name
+	}
+
+	if (!plan.GivenName.IsNull() && plan.GivenName.ValueString() != name.GivenName) ||
+		(!plan.FamilyName.IsNull() && plan.FamilyName.ValueString() != name.FamilyName) {
+
+		if !plan.GivenName.IsNull() {
+			name.GivenName = plan.GivenName.ValueString()
+		}
+		if !plan.FamilyName.IsNull() {
+			name.FamilyName = plan.FamilyName.ValueString()
+		}
+
+		patchReq, diags := utils.GetScimPatchRequest("Name", "", name, argsType)
+		if diags.HasError() {
+			return reqs, diags
+		}
+		reqs = append(reqs, patchReq)
+	}
+
+	if !plan.DisplayName.IsNull() && plan.DisplayName.ValueString() != u.DisplayName {
+		patchReq, diags := utils.GetScimPatchRequest("DisplayName", "", plan.DisplayName.ValueString(), argsType)
+		if diags.HasError() {
+			return reqs, diags
+		}
+		reqs = append(reqs, patchReq)
+	}
+
+	if !plan.UserType.IsNull() && plan.UserType.ValueString() != u.UserType {
+		patchReq, diags := utils.GetScimPatchRequest("UserType", "", plan.UserType.ValueString(), argsType)
+		if diags.HasError() {
+			return reqs, diags
+		}
+		reqs = append(reqs, patchReq)
+	}
+
+	if !plan.Active.IsNull() && plan.Active.ValueBool() != u.Active {
+		patchReq, diags := utils.GetScimPatchRequest("Active", "", plan.Active.ValueBool(), argsType)
+		if diags.HasError() {
+			return reqs, diags
+		}
+		reqs = append(reqs, patchReq)
+	}
+
+	return reqs, diags
+}
+
+// getUsersBulkOperations computes the operations required to align the tenant with the configured users
+// users which are managed by the resource but no longer configured are deleted
+// users of the tenant which are not yet managed by the resource are only adopted if this is enabled, as they are deleted along with the resource
+func getUsersBulkOperations(plan []bulkUserData, managedIds map[string]string, tenantUsers []users.User, adoptExisting bool) ([]usersBulkOperation, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	operations := []usersBulkOperation{}
+
+	tenantUsersByName := make(map[string]users.User, len(tenantUsers))
+	for _, u := range tenantUsers {
+		tenantUsersByName[u.UserName] = u
+	}
+
+	configured := make(map[string]bool, len(plan))
+
+	for _, planUser := range plan {
+		userName := planUser.UserName.ValueString()
+		configured[userName] = true
+
+		tenantUser, found := tenantUsersByName[userName]
+		if !found {
+			operations = append(operations, usersBulkOperation{
+				method:   http.MethodPost,
+				userName: userName,
+				user:     getBulkUserRequest(planUser),
+			})
+			continue
+		}
+
+		// the user is skipped, so that the other users are still applied
+		if _, managed := managedIds[userName]; !managed && !adoptExisting {
+			diags.AddWarning(
+				"User already exists",
+				fmt.Sprintf("The user %s already exists in the tenant and is not managed by the resource, the user is skipped. "+
+					"Set adopt_existing_users to true to manage and eventually delete the existing user with the resource, or remove the user from the configuration.", userName),
+			)
+			continue
+		}
+
+		patch, patchDiags := getBulkUserUpdateRequest(planUser, tenantUser)
+		diags.Append(patchDiags...)
+		if diags.HasError() {
+			return operations, diags
+		}
+
+		// the operation is kept even if there is nothing to update, so that an adopted user is managed by the resource
+		operations = append(operations, usersBulkOperation{
+			method:   http.MethodPatch,
+			userName: userName,
+			userId:   tenantUser.Id,
+			patch:    patch,
+		})
+	}
+
+	for userName, userId := range managedIds {
+		if configured[userName] {
+			continue
+		}
+
+		operations = append(operations, usersBulkOperation{
+			method:   http.MethodDelete,
+			userName: userName,
+			userId:   userId,
+		})
+	}
+
+	return operations, diags
+}
+
+// applyUsersBulkOperations applies the operations in batches using the SCIM bulk endpoint
+// if the tenant does not support the bulk endpoint, the operations are applied with individual calls instead
+func applyUsersBulkOperations(ctx context.Context, client *cli.SciClient, operations []usersBulkOperation, batchSize int, maxConcurrency int) []usersBulkResult {
+
+	results := make([]usersBulkResult, 0, len(operations))
+
+	// updates without any changes do not require any call
+	pending := []usersBulkOperation{}
+	for _, operation := range operations {
+		if operation.method == http.MethodPatch && len(operation.patch) == 0 {
+			results = append(results, usersBulkResult{operation: operation, userId: operation.userId})
+			continue
+		}
+		pending = append(pending, operation)
+	}
+
+	for start := 0; start < len(pending); start += batchSize {
+		batch := pending[start:min(start+batchSize, len(pending))]
+
+		batchResults, err := applyUsersBulkBatch(ctx, client, batch)
+		if err != nil && isBulkUnsupported(err) {
+			return append(results, applyUsersIndividually(ctx, client, pending[start:], maxConcurrency)...)
+		}
+
+		results = append(results, batchResults...)
+	}
+
+	return results
+}
+
+func applyUsersBulkBatch(ctx context.Context, client *cli.SciClient, batch []usersBulkOperation) ([]usersBulkResult, error) {
+
+	results := make([]usersBulkResult, len(batch))
+	bulkOperations := make([]bulk.Operation, len(batch))
+
+	for i, operation := range batch {
+		results[i] = usersBulkResult{operation: operation, userId: operation.userId}
+
+		bulkOperations[i] = bulk.Operation{
+			Method: operation.method,
+			Path:   "/Users",
+		}
+
+		switch operation.method {
+		case http.MethodPost:
+			bulkOperations[i].Data = operation.user
+		case http.MethodPatch:
+			bulkOperations[i].Path += "/" + operation.userId
+			bulkOperations[i].Data = users.PatchRequestBody{
+				Schemas:    []string{cli.ScimUpdateSchemas},
+				Operations: operation.patch,
+			}
+		case http.MethodDelete:
+			bulkOperations[i].Path += "/" + operation.userId
+		}
+	}
+
+	bulkResults, err := client.Bulk.Execute(ctx, bulkOperations)
+	if err != nil && len(bulkResults) == 0 {
+		for i := range results {
+			results[i].err = err
+		}
+		return results, err
+	}
+
+	for _, bulkResult := range bulkResults {
+		switch {
+		case bulkResult.Err == nil:
+			if bulkResult.Method == http.MethodPost {
+				results[bulkResult.Index].userId = bulkResult.ResourceId()
+			}
+
+		case bulkResult.Status == http.StatusNotFound && bulkResult.Method == http.MethodDelete:
+			// the user has already been deleted
+
+		default:
+			results[bulkResult.Index].err = bulkResult.Err
+		}
+	}
+
+	return results, err
+}
+
+// isBulkUnsupported checks whether the error indicates that the tenant does not offer the SCIM bulk endpoint
+func isBulkUnsupported(err error) bool {
+	if errors.Is(err, cli.ErrBulkNotSupported) {
+		return true
+	}
+
+	status := cli.ScimErrorStatus(err)
+	return status == http.StatusNotFound || status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented
+}
+
+// applyUsersIndividually applies each operation with a dedicated call, with at most maxConcurrency calls in parallel
+func applyUsersIndividually(ctx context.Context, client *cli.SciClient, operations []usersBulkOperation, maxConcurrency int) []usersBulkResult {
+
+	results := make([]usersBulkResult, len(operations))
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	var wg sync.WaitGroup
+	for i, operation := range operations {
+		wg.Go(func() {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result := usersBulkResult{operation: operation, userId: operation.userId}
+
+			switch operation.method {
+			case http.MethodPost:
+				var res users.User
+				res, _, result.err = client.User.Create(ctx, "", operation.user)
+				result.userId = res.Id
+			case http.MethodPatch:
+				_, _, result.err = client.User.Update(ctx, operation.userId, operation.patch, "", "")
+			case http.MethodDelete:
+				// the user has already been deleted if it cannot be found
+				if err := client.User.Delete(ctx, operation.userId, ""); err != nil && cli.ScimErrorStatus(err) != http.StatusNotFound {
+					result.err = err
+				}
+			}
+
+			results[i] = result
+		})
+	}
+	wg.Wait()
+
+	return results
+}
+
+// usersBulkResultsValue adds the users applied successfully to the managed users and reports the failures as warnings
+func usersBulkResultsValue(managedIds map[string]string, results []usersBulkResult) (map[string]string, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	for _, result := range results {
+		userName := result.operation.userName
+
+		if result.err != nil {
+			diags.AddWarning(
+				fmt.Sprintf("Error applying changes to user %s", userName),
+				fmt.Sprintf("%s", result.err),
+			)
+			continue
+		}
+
+		switch result.operation.method {
+		case http.MethodDelete:
+			delete(managedIds, userName)
+		default:
+			managedIds[userName] = result.userId
+		}
+	}
+
+	return managedIds, diags
+}
+
+// usersBulkAppliedUsers returns the planned users which are managed by the resource after the apply
+// users which could not be created or adopted are left out, so that they are planned again
+func usersBulkAppliedUsers(planUsers []bulkUserData, managedIds map[string]string) ([]bulkUserData, []string) {
+
+	applied := make([]bulkUserData, 0, len(planUsers))
+	pending := []string{}
+
+	for _, planUser := range planUsers {
+		if _, managed := managedIds[planUser.UserName.ValueString()]; managed {
+			applied = append(applied, planUser)
+		} else {
+			pending = append(pending, planUser.UserName.ValueString())
+		}
+	}
+
+	return applied, pending
+}
+
+// usersBulkDeletionErrors reports the users which could not be deleted along with the resource
+// users which have already been deleted are not reported, so that the users which are left in the tenant are not lost from the state
+func usersBulkDeletionErrors(results []usersBulkResult) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	for _, result := range results {
+		if result.err != nil {
+			diags.AddError(
+				fmt.Sprintf("Error deleting user %s", result.operation.userName),
+				fmt.Sprintf("%s", result.err),
+			)
+		}
+	}
+
+	return diags
+}
@@ -2,13 +2,17 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
 	corporateidps "github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/corporateIdps"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/cli/apiObjects/generic"
 	"github.com/SAP/terraform-provider-sap-cloud-identity-services/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -26,6 +30,11 @@ type signingCertificateData struct {
 	Default           types.Bool   `tfsdk:"default"`
 	ValidFrom         types.String `tfsdk:"valid_from"`
 	ValidTo           types.String `tfsdk:"valid_to"`
+	SubjectDn         types.String `tfsdk:"subject_dn"`
+	Sha1Fingerprint   types.String `tfsdk:"sha1_fingerprint"`
+	Sha256Fingerprint types.String `tfsdk:"sha256_fingerprint"`
+	NotBefore         types.String `tfsdk:"not_before"`
+	NotAfter          types.String `tfsdk:"not_after"`
 }
 
 type saml2ConfigData struct {
@@ -38,6 +47,9 @@ type saml2ConfigData struct {
 	SigningCertificates types.List   `tfsdk:"signing_certificates" json:"certificatesForSigning"`
 	SsoEndpoints        types.List   `tfsdk:"sso_endpoints"        json:"ssoEndpoints"`
 	SloEndpoints        types.List   `tfsdk:"slo_endpoints"        json:"sloEndpoints"`
+	MetadataXml         types.String `tfsdk:"metadata_xml"         json:"-"`
+	MetadataFile        types.String `tfsdk:"metadata_file"        json:"-"`
+	MetadataSigningCert types.String `tfsdk:"metadata_signing_certificate" json:"-"`
 }
 
 type oidcAdditionalConfigData struct {
@@ -63,6 +75,7 @@ type oidcConfigData struct {
 	LogoutEndpoint           types.String `tfsdk:"logout_endpoint"             json:"endSessionEndpoint"`
 	UserInfoEndpoint         types.String `tfsdk:"user_info_endpoint"          json:"userInfoEndpoint"`
 	IsClientSecretConfigured types.Bool   `tfsdk:"is_client_secret_configured" json:"isClientSecretConfigured"`
+	ResolveDiscovery         types.Bool   `tfsdk:"resolve_discovery"           json:"-"`
 }
 
 type loginHintConfigData struct {
@@ -182,9 +195,16 @@ func corporateIdPValueFrom(ctx context.Context, c corporateidps.IdentityProvider
 
 			for _, certificate := range c.Saml2Configuration.CertificatesForSigning {
 
+				details := utils.CertificateDetailsOf(certificate.Base64Certificate)
+
 				certificateData := signingCertificateData{
 					Base64Certificate: types.StringValue(certificate.Base64Certificate),
 					Default:           types.BoolValue(certificate.IsDefault),
+					SubjectDn:         types.StringPointerValue(details.SubjectDn),
+					Sha1Fingerprint:   types.StringPointerValue(details.Sha1Fingerprint),
+					Sha256Fingerprint: types.StringPointerValue(details.Sha256Fingerprint),
+					NotBefore:         types.StringPointerValue(details.NotBefore),
+					NotAfter:          types.StringPointerValue(details.NotAfter),
 				}
 
 				if len(certificate.Dn) > 0 {
@@ -781,6 +801,84 @@ func mapSigningCertificates(ctx context.Context, plan corporateIdPData, state *c
 	return diags
 }
 
+// mapSamlMetadata copies metadata_xml, metadata_file and metadata_signing_certificate from plan into state, as the metadata is not stored by the tenant
+func mapSamlMetadata(ctx context.Context, plan corporateIdPData, state *corporateIdPData) diag.Diagnostics {
+	var samlPlan, samlState saml2ConfigData
+	diags := plan.Saml2Config.As(ctx, &samlPlan, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})
+	if diags.HasError() || (samlPlan.MetadataXml.IsNull() && samlPlan.MetadataFile.IsNull()) {
+		return diags
+	}
+
+	diags = state.Saml2Config.As(ctx, &samlState, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	samlState.MetadataXml = samlPlan.MetadataXml
+	samlState.MetadataFile = samlPlan.MetadataFile
+	samlState.MetadataSigningCert = samlPlan.MetadataSigningCert
+
+	state.Saml2Config, diags = types.ObjectValueFrom(ctx, IdPSaml2ConfigObjType.AttrTypes, samlState)
+	return diags
+}
+
+// checkSamlMetadataDrift warns if the SAML configuration of the tenant differs from the configuration derived from the metadata of the state
+// the next plan derives the configuration from the metadata again, which restores it in the tenant
+func checkSamlMetadataDrift(ctx context.Context, state corporateIdPData) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	var samlState saml2ConfigData
+	diags := state.Saml2Config.As(ctx, &samlState, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})
+	diagnostics.Append(diags...)
+
+	document := idpSamlMetadataDocument(samlState.MetadataXml, samlState.MetadataFile)
+	if diagnostics.HasError() || document.IsNull() || document.IsUnknown() {
+		return diagnostics
+	}
+
+	metadata, err := idpSamlMetadataFrom(document.ValueString(), samlState.MetadataSigningCert)
+	if err != nil {
+		diagnostics.AddAttributeWarning(path.Root("saml2_config"), "Invalid SAML Metadata", fmt.Sprintf("The configuration cannot be compared with the SAML metadata: %s", err))
+		return diagnostics
+	}
+
+	derived, diags := saml2IdPConfigFromMetadata(ctx, metadata)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	drifted := []string{}
+	if !derived.SsoEndpoints.Equal(samlState.SsoEndpoints) {
+		drifted = append(drifted, "sso_endpoints")
+	}
+	if !derived.SloEndpoints.Equal(samlState.SloEndpoints) {
+		drifted = append(drifted, "slo_endpoints")
+	}
+	if !derived.SigningCertificates.Equal(samlState.SigningCertificates) {
+		drifted = append(drifted, "signing_certificates")
+	}
+
+	if len(drifted) > 0 {
+		diagnostics.AddAttributeWarning(
+			path.Root("saml2_config"),
+			"SAML Metadata Drift",
+			fmt.Sprintf("The configuration of the corporate identity provider in the tenant differs from the SAML metadata for: %s. Applying the configuration restores the values derived from the metadata.", strings.Join(drifted, ", ")),
+		)
+	}
+
+	return diagnostics
+}
+
 func mapOidcClientSecret(ctx context.Context, plan corporateIdPData, state *corporateIdPData) diag.Diagnostics {
 
 	var oidcPlan oidcConfigData
@@ -813,3 +911,105 @@ func mapOidcClientSecret(ctx context.Context, plan corporateIdPData, state *corp
 
 	return nil
 }
+
+// tokenEndpointAuthMethodsSupported maps the token endpoint auth methods of the API to their name in the OpenID Provider Metadata
+var tokenEndpointAuthMethodsSupported = map[string]string{
+	"clientSecretPost":     "client_secret_post",
+	"clientSecretBasic":    "client_secret_basic",
+	"privateKeyJwt":        "private_key_jwt",
+	"privateKeyJwtRfc7523": "private_key_jwt",
+}
+
+// oidcConfigFromDiscovery fills the computed attributes of the planned OIDC configuration from the discovery of the IdP
+// and validates that the configured scopes and token endpoint auth method are supported, if the IdP advertises them
+func oidcConfigFromDiscovery(ctx context.Context, discovery corporateidps.OIDCDiscovery, oidcPlan oidcConfigData) (oidcConfigData, diag.Diagnostics) {
+
+	var diagnostics diag.Diagnostics
+	oidcPath := path.Root("oidc_config")
+
+	if !oidcPlan.Scopes.IsNull() && !oidcPlan.Scopes.IsUnknown() && len(discovery.ScopesSupported) > 0 {
+
+		var scopes []types.String
+		diagnostics.Append(oidcPlan.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if diagnostics.HasError() {
+			return oidcPlan, diagnostics
+		}
+
+		for _, scope := range scopes {
+			if !scope.IsUnknown() && !slices.Contains(discovery.ScopesSupported, scope.ValueString()) {
+				diagnostics.AddAttributeError(oidcPath.AtName("scopes"), "Unsupported Scope", fmt.Sprintf("the scope %s is not supported by the IdP, supported scopes : %s", scope.ValueString(), strings.Join(discovery.ScopesSupported, ", ")))
+			}
+		}
+	}
+
+	if !oidcPlan.TokenEndpointAuthMethod.IsNull() && !oidcPlan.TokenEndpointAuthMethod.IsUnknown() && len(discovery.TokenEndpointAuthMethodsSupported) > 0 {
+
+		method := oidcPlan.TokenEndpointAuthMethod.ValueString()
+		if !slices.Contains(discovery.TokenEndpointAuthMethodsSupported, tokenEndpointAuthMethodsSupported[method]) {
+			diagnostics.AddAttributeError(oidcPath.AtName("token_endpoint_auth_method"), "Unsupported Token Endpoint Auth Method", fmt.Sprintf("the token endpoint auth method %s is not supported by the IdP, supported methods : %s", method, strings.Join(discovery.TokenEndpointAuthMethodsSupported, ", ")))
+		}
+	}
+
+	if diagnostics.HasError() {
+		return oidcPlan, diagnostics
+	}
+
+	oidcPlan.Issuer = discoveredValue(discovery.Issuer)
+	oidcPlan.AuthorizationEndpoint = discoveredValue(discovery.AuthorizationEndpoint)
+	oidcPlan.TokenEndpoint = discoveredValue(discovery.TokenEndpoint)
+	oidcPlan.UserInfoEndpoint = discoveredValue(discovery.UserInfoEndpoint)
+	oidcPlan.LogoutEndpoint = discoveredValue(discovery.EndSessionEndpoint)
+	oidcPlan.JwksUri = discoveredValue(discovery.JwksUri)
+	oidcPlan.Jwks = discoveredValue(discovery.Jwks)
+
+	return oidcPlan, diagnostics
+}
+
+// discoveredValue returns null for values the IdP does not advertise, as the API omits them as well
+func discoveredValue(value string) types.String {
+	if len(value) == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+func mapOidcDiscovery(ctx context.Context, plan corporateIdPData, state *corporateIdPData) diag.Diagnostics {
+
+	var oidcPlan, oidcState oidcConfigData
+	diags := plan.OidcConfig.As(ctx, &oidcPlan, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	diags = state.OidcConfig.As(ctx, &oidcState, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	oidcState.ResolveDiscovery = oidcPlan.ResolveDiscovery
+
+	// the resolved JSON Web Key Set is kept, unless the tenant stores different keys
+	if !oidcPlan.Jwks.IsNull() && !oidcPlan.Jwks.IsUnknown() && jsonEqual(oidcPlan.Jwks.ValueString(), oidcState.Jwks.ValueString()) {
+		oidcState.Jwks = oidcPlan.Jwks
+	}
+
+	state.OidcConfig, diags = types.ObjectValueFrom(ctx, oidcConfigObjType.AttrTypes, oidcState)
+	return diags
+}
+
+// jsonEqual reports whether both strings are valid JSON documents of the same value
+func jsonEqual(a, b string) bool {
+
+	var valueA, valueB any
+	if json.Unmarshal([]byte(a), &valueA) != nil || json.Unmarshal([]byte(b), &valueB) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(valueA, valueB)
+}